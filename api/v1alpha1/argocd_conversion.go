@@ -1,12 +1,18 @@
 package v1alpha1
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"reflect"
 
+	jsonpatch "github.com/evanphx/json-patch"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 var conversionLogger = ctrl.Log.WithName("conversion-webhook")
@@ -17,159 +23,260 @@ func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.ArgoCD)
 
 	// ObjectMeta conversion
-	dst.ObjectMeta = src.ObjectMeta
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
 
 	// Spec conversion
+	dst.Spec = *ConvertAlphaToBetaSpec(&src.Spec)
+
+	// v1beta1 fields that can't be represented in v1alpha1 are restored from the conversion data, so that updates
+	// made through v1alpha1 don't clobber them
+	if data, ok := dst.Annotations[common.AnnotationConversionData]; ok {
+		delete(dst.Annotations, common.AnnotationConversionData)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+		if err := restoreConversionData(&dst.Spec, data); err != nil {
+			return err
+		}
+	}
+
+	// Status conversion
+	dst.Status = *ConvertAlphaToBetaStatus(&src.Status)
+
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this (v1alpha1) version.
+func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
+	conversionLogger.V(1).Info("v1beta1 to v1alpha1 conversion requested.")
+
+	src := srcRaw.(*v1beta1.ArgoCD)
+
+	// ObjectMeta conversion
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	// Spec conversion
+	dst.Spec = *ConvertBetaToAlphaSpec(&src.Spec)
+
+	// The v1beta1 spec is stashed in the conversion data when it can't be represented in v1alpha1, to be restored
+	// on the conversion back to v1beta1
+	if err := setConversionData(&dst.ObjectMeta, &src.Spec, &dst.Spec); err != nil {
+		return err
+	}
+
+	// Status conversion
+	dst.Status = *ConvertBetaToAlphaStatus(&src.Status)
+
+	return nil
+}
+
+// ConvertAlphaToBetaSpec converts a v1alpha1 ArgoCD spec to v1beta1.
+func ConvertAlphaToBetaSpec(src *ArgoCDSpec) *v1beta1.ArgoCDSpec {
+	dst := &v1beta1.ArgoCDSpec{}
 
 	// sso field
-	sso := ConvertAlphaToBetaSSO(src.Spec.SSO)
+	sso := ConvertAlphaToBetaSSO(src.SSO)
 
 	// in case of conflict, deprecated fields will have more priority during conversion to beta
 	// deprecated keycloak configs set in alpha (.spec.sso.image, .spec.sso.version, .spec.sso.verifyTLS, .spec.sso.resources),
 	// override .spec.sso.keycloak in beta
-	if src.Spec.SSO != nil && !reflect.DeepEqual(src.Spec.SSO, &ArgoCDSSOSpec{}) {
-		if src.Spec.SSO.Image != "" || src.Spec.SSO.Version != "" || src.Spec.SSO.VerifyTLS != nil || src.Spec.SSO.Resources != nil {
+	if src.SSO != nil && !reflect.DeepEqual(src.SSO, &ArgoCDSSOSpec{}) {
+		if src.SSO.Image != "" || src.SSO.Version != "" || src.SSO.VerifyTLS != nil || src.SSO.Resources != nil {
 			if sso.Keycloak == nil {
 				sso.Keycloak = &v1beta1.ArgoCDKeycloakSpec{}
 			}
-			sso.Keycloak.Image = src.Spec.SSO.Image
-			sso.Keycloak.Version = src.Spec.SSO.Version
-			sso.Keycloak.VerifyTLS = src.Spec.SSO.VerifyTLS
-			sso.Keycloak.Resources = src.Spec.SSO.Resources
-
+			// only the deprecated fields that are set override .spec.sso.keycloak, so that the others are kept
+			if src.SSO.Image != "" {
+				sso.Keycloak.Image = src.SSO.Image
+			}
+			if src.SSO.Version != "" {
+				sso.Keycloak.Version = src.SSO.Version
+			}
+			if src.SSO.VerifyTLS != nil {
+				sso.Keycloak.VerifyTLS = src.SSO.VerifyTLS
+			}
+			if src.SSO.Resources != nil {
+				sso.Keycloak.Resources = src.SSO.Resources
+			}
 		}
 	}
 
 	// deprecated dex configs set in alpha (.spec.dex), override .spec.sso.dex in beta
-	if src.Spec.Dex != nil && !reflect.DeepEqual(src.Spec.Dex, &ArgoCDDexSpec{}) && (src.Spec.Dex.Config != "" || src.Spec.Dex.OpenShiftOAuth) {
+	if src.Dex != nil && !reflect.DeepEqual(src.Dex, &ArgoCDDexSpec{}) && (src.Dex.Config != "" || src.Dex.OpenShiftOAuth) {
 		if sso == nil {
 			sso = &v1beta1.ArgoCDSSOSpec{}
 		}
 		sso.Provider = v1beta1.SSOProviderTypeDex
-		sso.Dex = ConvertAlphaToBetaDex(src.Spec.Dex)
+		sso.Dex = ConvertAlphaToBetaDex(src.Dex)
 	}
 
-	dst.Spec.SSO = sso
+	dst.SSO = sso
 
 	// rest of the fields
-	dst.Spec.ApplicationSet = ConvertAlphaToBetaApplicationSet(src.Spec.ApplicationSet)
-	dst.Spec.ExtraConfig = src.Spec.ExtraConfig
-	dst.Spec.ApplicationInstanceLabelKey = src.Spec.ApplicationInstanceLabelKey
-	dst.Spec.ConfigManagementPlugins = src.Spec.ConfigManagementPlugins
-	dst.Spec.Controller = *ConvertAlphaToBetaController(&src.Spec.Controller)
-	dst.Spec.DisableAdmin = src.Spec.DisableAdmin
-	dst.Spec.ExtraConfig = src.Spec.ExtraConfig
-	dst.Spec.GATrackingID = src.Spec.GATrackingID
-	dst.Spec.GAAnonymizeUsers = src.Spec.GAAnonymizeUsers
+	dst.ApplicationSet = ConvertAlphaToBetaApplicationSet(src.ApplicationSet)
+	dst.ExtraConfig = src.ExtraConfig
+	dst.ApplicationInstanceLabelKey = src.ApplicationInstanceLabelKey
+	dst.ConfigManagementPlugins = src.ConfigManagementPlugins
+	dst.Controller = *ConvertAlphaToBetaController(&src.Controller)
+	dst.DisableAdmin = src.DisableAdmin
+	dst.ExtraConfig = src.ExtraConfig
+	dst.GATrackingID = src.GATrackingID
+	dst.GAAnonymizeUsers = src.GAAnonymizeUsers
 	//nolint:staticcheck
-	dst.Spec.Grafana = *ConvertAlphaToBetaGrafana(&src.Spec.Grafana)
-	dst.Spec.HA = *ConvertAlphaToBetaHA(&src.Spec.HA)
-	dst.Spec.HelpChatURL = src.Spec.HelpChatURL
-	dst.Spec.HelpChatText = src.Spec.HelpChatText
-	dst.Spec.Image = src.Spec.Image
-	dst.Spec.Import = (*v1beta1.ArgoCDImportSpec)(src.Spec.Import)
-	dst.Spec.InitialRepositories = src.Spec.InitialRepositories
-	dst.Spec.InitialSSHKnownHosts = v1beta1.SSHHostsSpec(src.Spec.InitialSSHKnownHosts)
-	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
-	dst.Spec.KustomizeVersions = ConvertAlphaToBetaKustomizeVersions(src.Spec.KustomizeVersions)
-	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = v1beta1.ArgoCDMonitoringSpec(src.Spec.Monitoring)
-	dst.Spec.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = v1beta1.ArgoCDNotifications(src.Spec.Notifications)
-	dst.Spec.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = v1beta1.ArgoCDRBACSpec(src.Spec.RBAC)
-	dst.Spec.Redis = *ConvertAlphaToBetaRedis(&src.Spec.Redis)
-	dst.Spec.Repo = *ConvertAlphaToBetaRepo(&src.Spec.Repo)
-	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
-	dst.Spec.ResourceHealthChecks = ConvertAlphaToBetaResourceHealthChecks(src.Spec.ResourceHealthChecks)
-	dst.Spec.ResourceIgnoreDifferences = ConvertAlphaToBetaResourceIgnoreDifferences(src.Spec.ResourceIgnoreDifferences)
-	dst.Spec.ResourceActions = ConvertAlphaToBetaResourceActions(src.Spec.ResourceActions)
-	dst.Spec.ResourceExclusions = src.Spec.ResourceExclusions
-	dst.Spec.ResourceInclusions = src.Spec.ResourceInclusions
-	dst.Spec.ResourceTrackingMethod = src.Spec.ResourceTrackingMethod
-	dst.Spec.Server = *ConvertAlphaToBetaServer(&src.Spec.Server)
-	dst.Spec.SourceNamespaces = src.Spec.SourceNamespaces
-	dst.Spec.StatusBadgeEnabled = src.Spec.StatusBadgeEnabled
-	dst.Spec.TLS = *ConvertAlphaToBetaTLS(&src.Spec.TLS)
-	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
-	dst.Spec.Version = src.Spec.Version
-	dst.Spec.Banner = (*v1beta1.Banner)(src.Spec.Banner)
-	dst.Spec.DefaultClusterScopedRoleDisabled = src.Spec.DefaultClusterScopedRoleDisabled
-	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
-
-	// Status conversion
-	dst.Status = v1beta1.ArgoCDStatus(src.Status)
-
-	return nil
-}
-
-// ConvertFrom converts from the Hub version (v1beta1) to this (v1alpha1) version.
-func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
-	conversionLogger.V(1).Info("v1beta1 to v1alpha1 conversion requested.")
-
-	src := srcRaw.(*v1beta1.ArgoCD)
-
-	// ObjectMeta conversion
-	dst.ObjectMeta = src.ObjectMeta
-
-	// Spec conversion
+	dst.Grafana = *ConvertAlphaToBetaGrafana(&src.Grafana)
+	dst.HA = *ConvertAlphaToBetaHA(&src.HA)
+	dst.HelpChatURL = src.HelpChatURL
+	dst.HelpChatText = src.HelpChatText
+	dst.Image = src.Image
+	dst.Import = (*v1beta1.ArgoCDImportSpec)(src.Import)
+	dst.InitialRepositories = src.InitialRepositories
+	dst.InitialSSHKnownHosts = v1beta1.SSHHostsSpec(src.InitialSSHKnownHosts)
+	dst.KustomizeBuildOptions = src.KustomizeBuildOptions
+	dst.KustomizeVersions = ConvertAlphaToBetaKustomizeVersions(src.KustomizeVersions)
+	dst.OIDCConfig = src.OIDCConfig
+	dst.Monitoring = v1beta1.ArgoCDMonitoringSpec{
+		Enabled:        src.Monitoring.Enabled,
+		DisableMetrics: src.Monitoring.DisableMetrics,
+	}
+	dst.NodePlacement = (*v1beta1.ArgoCDNodePlacementSpec)(src.NodePlacement)
+	dst.Notifications = *ConvertAlphaToBetaNotifications(&src.Notifications)
+	dst.Prometheus = *ConvertAlphaToBetaPrometheus(&src.Prometheus)
+	dst.RBAC = *ConvertAlphaToBetaRBAC(&src.RBAC)
+	dst.Redis = *ConvertAlphaToBetaRedis(&src.Redis)
+	dst.Repo = *ConvertAlphaToBetaRepo(&src.Repo)
+	dst.RepositoryCredentials = src.RepositoryCredentials
+	dst.ResourceHealthChecks = ConvertAlphaToBetaResourceHealthChecks(src.ResourceHealthChecks)
+	dst.ResourceIgnoreDifferences = ConvertAlphaToBetaResourceIgnoreDifferences(src.ResourceIgnoreDifferences)
+	dst.ResourceActions = ConvertAlphaToBetaResourceActions(src.ResourceActions)
+	dst.ResourceExclusions = src.ResourceExclusions
+	dst.ResourceInclusions = src.ResourceInclusions
+	dst.ResourceTrackingMethod = src.ResourceTrackingMethod
+	dst.Server = *ConvertAlphaToBetaServer(&src.Server)
+	dst.SourceNamespaces = src.SourceNamespaces
+	dst.StatusBadgeEnabled = src.StatusBadgeEnabled
+	dst.TLS = *ConvertAlphaToBetaTLS(&src.TLS)
+	dst.UsersAnonymousEnabled = src.UsersAnonymousEnabled
+	dst.Version = src.Version
+	dst.Banner = (*v1beta1.Banner)(src.Banner)
+	dst.DefaultClusterScopedRoleDisabled = src.DefaultClusterScopedRoleDisabled
+	dst.AggregatedClusterRoles = src.AggregatedClusterRoles
+
+	return dst
+}
+
+// ConvertBetaToAlphaSpec converts a v1beta1 ArgoCD spec to v1alpha1.
+func ConvertBetaToAlphaSpec(src *v1beta1.ArgoCDSpec) *ArgoCDSpec {
+	dst := &ArgoCDSpec{}
 
 	// sso field
 	// ignoring conversions of sso fields from v1beta1 to deprecated v1alpha1 as
 	// there is no data loss since the new fields in v1beta1 are also present in v1alpha1 &
 	// v1alpha1 is not used in business logic & only exists for presentation
-	sso := ConvertBetaToAlphaSSO(src.Spec.SSO)
-	dst.Spec.SSO = sso
+	sso := ConvertBetaToAlphaSSO(src.SSO)
+	dst.SSO = sso
 
 	// rest of the fields
-	dst.Spec.ApplicationSet = ConvertBetaToAlphaApplicationSet(src.Spec.ApplicationSet)
-	dst.Spec.ExtraConfig = src.Spec.ExtraConfig
-	dst.Spec.ApplicationInstanceLabelKey = src.Spec.ApplicationInstanceLabelKey
-	dst.Spec.ConfigManagementPlugins = src.Spec.ConfigManagementPlugins
-	dst.Spec.Controller = *ConvertBetaToAlphaController(&src.Spec.Controller)
-	dst.Spec.DisableAdmin = src.Spec.DisableAdmin
-	dst.Spec.ExtraConfig = src.Spec.ExtraConfig
-	dst.Spec.GATrackingID = src.Spec.GATrackingID
-	dst.Spec.GAAnonymizeUsers = src.Spec.GAAnonymizeUsers
+	dst.ApplicationSet = ConvertBetaToAlphaApplicationSet(src.ApplicationSet)
+	dst.ExtraConfig = src.ExtraConfig
+	dst.ApplicationInstanceLabelKey = src.ApplicationInstanceLabelKey
+	dst.ConfigManagementPlugins = src.ConfigManagementPlugins
+	dst.Controller = *ConvertBetaToAlphaController(&src.Controller)
+	dst.DisableAdmin = src.DisableAdmin
+	dst.ExtraConfig = src.ExtraConfig
+	dst.GATrackingID = src.GATrackingID
+	dst.GAAnonymizeUsers = src.GAAnonymizeUsers
 	//nolint:staticcheck
-	dst.Spec.Grafana = *ConvertBetaToAlphaGrafana(&src.Spec.Grafana)
-	dst.Spec.HA = *ConvertBetaToAlphaHA(&src.Spec.HA)
-	dst.Spec.HelpChatURL = src.Spec.HelpChatURL
-	dst.Spec.HelpChatText = src.Spec.HelpChatText
-	dst.Spec.Image = src.Spec.Image
-	dst.Spec.Import = (*ArgoCDImportSpec)(src.Spec.Import)
-	dst.Spec.InitialRepositories = src.Spec.InitialRepositories
-	dst.Spec.InitialSSHKnownHosts = SSHHostsSpec(src.Spec.InitialSSHKnownHosts)
-	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
-	dst.Spec.KustomizeVersions = ConvertBetaToAlphaKustomizeVersions(src.Spec.KustomizeVersions)
-	dst.Spec.OIDCConfig = src.Spec.OIDCConfig
-	dst.Spec.Monitoring = ArgoCDMonitoringSpec(src.Spec.Monitoring)
-	dst.Spec.NodePlacement = (*ArgoCDNodePlacementSpec)(src.Spec.NodePlacement)
-	dst.Spec.Notifications = ArgoCDNotifications(src.Spec.Notifications)
-	dst.Spec.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Spec.Prometheus)
-	dst.Spec.RBAC = ArgoCDRBACSpec(src.Spec.RBAC)
-	dst.Spec.Redis = *ConvertBetaToAlphaRedis(&src.Spec.Redis)
-	dst.Spec.Repo = *ConvertBetaToAlphaRepo(&src.Spec.Repo)
-	dst.Spec.RepositoryCredentials = src.Spec.RepositoryCredentials
-	dst.Spec.ResourceHealthChecks = ConvertBetaToAlphaResourceHealthChecks(src.Spec.ResourceHealthChecks)
-	dst.Spec.ResourceIgnoreDifferences = ConvertBetaToAlphaResourceIgnoreDifferences(src.Spec.ResourceIgnoreDifferences)
-	dst.Spec.ResourceActions = ConvertBetaToAlphaResourceActions(src.Spec.ResourceActions)
-	dst.Spec.ResourceExclusions = src.Spec.ResourceExclusions
-	dst.Spec.ResourceInclusions = src.Spec.ResourceInclusions
-	dst.Spec.ResourceTrackingMethod = src.Spec.ResourceTrackingMethod
-	dst.Spec.Server = *ConvertBetaToAlphaServer(&src.Spec.Server)
-	dst.Spec.SourceNamespaces = src.Spec.SourceNamespaces
-	dst.Spec.StatusBadgeEnabled = src.Spec.StatusBadgeEnabled
-	dst.Spec.TLS = *ConvertBetaToAlphaTLS(&src.Spec.TLS)
-	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
-	dst.Spec.Version = src.Spec.Version
-	dst.Spec.Banner = (*Banner)(src.Spec.Banner)
-	dst.Spec.DefaultClusterScopedRoleDisabled = src.Spec.DefaultClusterScopedRoleDisabled
-	dst.Spec.AggregatedClusterRoles = src.Spec.AggregatedClusterRoles
+	dst.Grafana = *ConvertBetaToAlphaGrafana(&src.Grafana)
+	dst.HA = *ConvertBetaToAlphaHA(&src.HA)
+	dst.HelpChatURL = src.HelpChatURL
+	dst.HelpChatText = src.HelpChatText
+	dst.Image = src.Image
+	dst.Import = (*ArgoCDImportSpec)(src.Import)
+	dst.InitialRepositories = src.InitialRepositories
+	dst.InitialSSHKnownHosts = SSHHostsSpec(src.InitialSSHKnownHosts)
+	dst.KustomizeBuildOptions = src.KustomizeBuildOptions
+	dst.KustomizeVersions = ConvertBetaToAlphaKustomizeVersions(src.KustomizeVersions)
+	dst.OIDCConfig = src.OIDCConfig
+	dst.Monitoring = ArgoCDMonitoringSpec{
+		Enabled:        src.Monitoring.Enabled,
+		DisableMetrics: src.Monitoring.DisableMetrics,
+	}
+	dst.NodePlacement = (*ArgoCDNodePlacementSpec)(src.NodePlacement)
+	dst.Notifications = *ConvertBetaToAlphaNotifications(&src.Notifications)
+	dst.Prometheus = *ConvertBetaToAlphaPrometheus(&src.Prometheus)
+	dst.RBAC = *ConvertBetaToAlphaRBAC(&src.RBAC)
+	dst.Redis = *ConvertBetaToAlphaRedis(&src.Redis)
+	dst.Repo = *ConvertBetaToAlphaRepo(&src.Repo)
+	dst.RepositoryCredentials = src.RepositoryCredentials
+	dst.ResourceHealthChecks = ConvertBetaToAlphaResourceHealthChecks(src.ResourceHealthChecks)
+	dst.ResourceIgnoreDifferences = ConvertBetaToAlphaResourceIgnoreDifferences(src.ResourceIgnoreDifferences)
+	dst.ResourceActions = ConvertBetaToAlphaResourceActions(src.ResourceActions)
+	dst.ResourceExclusions = src.ResourceExclusions
+	dst.ResourceInclusions = src.ResourceInclusions
+	dst.ResourceTrackingMethod = src.ResourceTrackingMethod
+	dst.Server = *ConvertBetaToAlphaServer(&src.Server)
+	dst.SourceNamespaces = src.SourceNamespaces
+	dst.StatusBadgeEnabled = src.StatusBadgeEnabled
+	dst.TLS = *ConvertBetaToAlphaTLS(&src.TLS)
+	dst.UsersAnonymousEnabled = src.UsersAnonymousEnabled
+	dst.Version = src.Version
+	dst.Banner = (*Banner)(src.Banner)
+	dst.DefaultClusterScopedRoleDisabled = src.DefaultClusterScopedRoleDisabled
+	dst.AggregatedClusterRoles = src.AggregatedClusterRoles
+
+	return dst
+}
+
+// setConversionData stashes the fields of the given v1beta1 spec that can't be represented by the given v1alpha1 spec
+// converted from it in the conversion data annotation of the given metadata, as a JSON merge patch to apply to the
+// v1alpha1 spec converted back to v1beta1. Stale conversion data is removed when every field can be represented.
+func setConversionData(meta *metav1.ObjectMeta, src *v1beta1.ArgoCDSpec, dst *ArgoCDSpec) error {
+	delete(meta.Annotations, common.AnnotationConversionData)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the conversion data: %w", err)
+	}
+	converted, err := json.Marshal(ConvertAlphaToBetaSpec(dst))
+	if err != nil {
+		return fmt.Errorf("failed to marshal the conversion data: %w", err)
+	}
+	if bytes.Equal(data, converted) {
+		return nil
+	}
+	patch, err := jsonpatch.CreateMergePatch(converted, data)
+	if err != nil {
+		return fmt.Errorf("failed to compute the conversion data: %w", err)
+	}
+
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[common.AnnotationConversionData] = string(patch)
+	return nil
+}
 
-	// Status conversion
-	dst.Status = ArgoCDStatus(src.Status)
+// restoreConversionData restores the fields of the given v1beta1 spec, converted from v1alpha1, that can't be
+// represented in v1alpha1 by applying the JSON merge patch of the given conversion data. The fields of the patch
+// can't be changed through v1alpha1, so the changes made through v1alpha1 since the conversion data was stashed are
+// kept.
+func restoreConversionData(spec *v1beta1.ArgoCDSpec, data string) error {
+	converted, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the converted spec: %w", err)
+	}
+	restored, err := jsonpatch.MergePatch(converted, []byte(data))
+	if err != nil {
+		return fmt.Errorf("failed to restore the conversion data: %w", err)
+	}
 
+	*spec = v1beta1.ArgoCDSpec{}
+	if err := json.Unmarshal(restored, spec); err != nil {
+		return fmt.Errorf("failed to unmarshal the restored spec: %w", err)
+	}
 	return nil
 }
 
@@ -191,6 +298,22 @@ func ConvertAlphaToBetaController(src *ArgoCDApplicationControllerSpec) *v1beta1
 	return dst
 }
 
+func ConvertAlphaToBetaNotifications(src *ArgoCDNotifications) *v1beta1.ArgoCDNotifications {
+	var dst *v1beta1.ArgoCDNotifications
+	if src != nil {
+		dst = &v1beta1.ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
 func ConvertAlphaToBetaRedis(src *ArgoCDRedisSpec) *v1beta1.ArgoCDRedisSpec {
 	var dst *v1beta1.ArgoCDRedisSpec
 	if src != nil {
@@ -236,7 +359,7 @@ func ConvertAlphaToBetaWebhookServer(src *WebhookServerSpec) *v1beta1.WebhookSer
 	if src != nil {
 		dst = &v1beta1.WebhookServerSpec{
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 			Route:   v1beta1.ArgoCDRouteSpec(src.Route),
 		}
 	}
@@ -266,7 +389,7 @@ func ConvertAlphaToBetaGrafana(src *ArgoCDGrafanaSpec) *v1beta1.ArgoCDGrafanaSpe
 			Enabled:   src.Enabled,
 			Host:      src.Host,
 			Image:     src.Image,
-			Ingress:   v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress:   ConvertAlphaToBetaIngress(src.Ingress),
 			Resources: src.Resources,
 			Route:     v1beta1.ArgoCDRouteSpec(src.Route),
 			Size:      src.Size,
@@ -282,7 +405,7 @@ func ConvertAlphaToBetaPrometheus(src *ArgoCDPrometheusSpec) *v1beta1.ArgoCDProm
 		dst = &v1beta1.ArgoCDPrometheusSpec{
 			Enabled: src.Enabled,
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 			Route:   v1beta1.ArgoCDRouteSpec(src.Route),
 			Size:    src.Size,
 		}
@@ -296,7 +419,32 @@ func ConvertAlphaToBetaSSO(src *ArgoCDSSOSpec) *v1beta1.ArgoCDSSOSpec {
 		dst = &v1beta1.ArgoCDSSOSpec{
 			Provider: v1beta1.SSOProviderType(src.Provider),
 			Dex:      ConvertAlphaToBetaDex(src.Dex),
-			Keycloak: (*v1beta1.ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertAlphaToBetaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertAlphaToBetaIngress(src ArgoCDIngressSpec) v1beta1.ArgoCDIngressSpec {
+	return v1beta1.ArgoCDIngressSpec{
+		Annotations:      src.Annotations,
+		Enabled:          src.Enabled,
+		IngressClassName: src.IngressClassName,
+		Path:             src.Path,
+		TLS:              src.TLS,
+	}
+}
+
+func ConvertAlphaToBetaKeycloak(src *ArgoCDKeycloakSpec) *v1beta1.ArgoCDKeycloakSpec {
+	var dst *v1beta1.ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -331,6 +479,19 @@ func ConvertAlphaToBetaHA(src *ArgoCDHASpec) *v1beta1.ArgoCDHASpec {
 	return dst
 }
 
+func ConvertAlphaToBetaRBAC(src *ArgoCDRBACSpec) *v1beta1.ArgoCDRBACSpec {
+	var dst *v1beta1.ArgoCDRBACSpec
+	if src != nil {
+		dst = &v1beta1.ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
 func ConvertAlphaToBetaTLS(src *ArgoCDTLSSpec) *v1beta1.ArgoCDTLSSpec {
 	var dst *v1beta1.ArgoCDTLSSpec
 	if src != nil {
@@ -349,14 +510,14 @@ func ConvertAlphaToBetaServer(src *ArgoCDServerSpec) *v1beta1.ArgoCDServerSpec {
 			Autoscale:        v1beta1.ArgoCDServerAutoscaleSpec(src.Autoscale),
 			GRPC:             *ConvertAlphaToBetaGRPC(&src.GRPC),
 			Host:             src.Host,
-			Ingress:          v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress:          ConvertAlphaToBetaIngress(src.Ingress),
 			Insecure:         src.Insecure,
 			LogLevel:         src.LogLevel,
 			LogFormat:        src.LogFormat,
 			Replicas:         src.Replicas,
 			Resources:        src.Resources,
 			Route:            v1beta1.ArgoCDRouteSpec(src.Route),
-			Service:          v1beta1.ArgoCDServerServiceSpec(src.Service),
+			Service:          v1beta1.ArgoCDServerServiceSpec{Type: src.Service.Type},
 			Env:              src.Env,
 			ExtraCommandArgs: src.ExtraCommandArgs,
 		}
@@ -369,7 +530,7 @@ func ConvertAlphaToBetaGRPC(src *ArgoCDServerGRPCSpec) *v1beta1.ArgoCDServerGRPC
 	if src != nil {
 		dst = &v1beta1.ArgoCDServerGRPCSpec{
 			Host:    src.Host,
-			Ingress: v1beta1.ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertAlphaToBetaIngress(src.Ingress),
 		}
 	}
 	return dst
@@ -437,6 +598,26 @@ func ConvertAlphaToBetaResourceHealthChecks(src []ResourceHealthCheck) []v1beta1
 	return dst
 }
 
+func ConvertAlphaToBetaStatus(src *ArgoCDStatus) *v1beta1.ArgoCDStatus {
+	var dst *v1beta1.ArgoCDStatus
+	if src != nil {
+		dst = &v1beta1.ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
+
 // Conversion funcs for v1beta1 to v1alpha1.
 func ConvertBetaToAlphaController(src *v1beta1.ArgoCDApplicationControllerSpec) *ArgoCDApplicationControllerSpec {
 	var dst *ArgoCDApplicationControllerSpec
@@ -460,7 +641,7 @@ func ConvertBetaToAlphaWebhookServer(src *v1beta1.WebhookServerSpec) *WebhookSer
 	if src != nil {
 		dst = &WebhookServerSpec{
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 			Route:   ArgoCDRouteSpec(src.Route),
 		}
 	}
@@ -490,7 +671,7 @@ func ConvertBetaToAlphaGrafana(src *v1beta1.ArgoCDGrafanaSpec) *ArgoCDGrafanaSpe
 			Enabled:   src.Enabled,
 			Host:      src.Host,
 			Image:     src.Image,
-			Ingress:   ArgoCDIngressSpec(src.Ingress),
+			Ingress:   ConvertBetaToAlphaIngress(src.Ingress),
 			Resources: src.Resources,
 			Route:     ArgoCDRouteSpec(src.Route),
 			Size:      src.Size,
@@ -506,7 +687,7 @@ func ConvertBetaToAlphaPrometheus(src *v1beta1.ArgoCDPrometheusSpec) *ArgoCDProm
 		dst = &ArgoCDPrometheusSpec{
 			Enabled: src.Enabled,
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 			Route:   ArgoCDRouteSpec(src.Route),
 			Size:    src.Size,
 		}
@@ -520,7 +701,32 @@ func ConvertBetaToAlphaSSO(src *v1beta1.ArgoCDSSOSpec) *ArgoCDSSOSpec {
 		dst = &ArgoCDSSOSpec{
 			Provider: SSOProviderType(src.Provider),
 			Dex:      ConvertBetaToAlphaDex(src.Dex),
-			Keycloak: (*ArgoCDKeycloakSpec)(src.Keycloak),
+			Keycloak: ConvertBetaToAlphaKeycloak(src.Keycloak),
+		}
+	}
+	return dst
+}
+
+func ConvertBetaToAlphaIngress(src v1beta1.ArgoCDIngressSpec) ArgoCDIngressSpec {
+	return ArgoCDIngressSpec{
+		Annotations:      src.Annotations,
+		Enabled:          src.Enabled,
+		IngressClassName: src.IngressClassName,
+		Path:             src.Path,
+		TLS:              src.TLS,
+	}
+}
+
+func ConvertBetaToAlphaKeycloak(src *v1beta1.ArgoCDKeycloakSpec) *ArgoCDKeycloakSpec {
+	var dst *ArgoCDKeycloakSpec
+	if src != nil {
+		dst = &ArgoCDKeycloakSpec{
+			Image:     src.Image,
+			Resources: src.Resources,
+			RootCA:    src.RootCA,
+			Version:   src.Version,
+			VerifyTLS: src.VerifyTLS,
+			Host:      src.Host,
 		}
 	}
 	return dst
@@ -554,6 +760,19 @@ func ConvertBetaToAlphaHA(src *v1beta1.ArgoCDHASpec) *ArgoCDHASpec {
 	return dst
 }
 
+func ConvertBetaToAlphaRBAC(src *v1beta1.ArgoCDRBACSpec) *ArgoCDRBACSpec {
+	var dst *ArgoCDRBACSpec
+	if src != nil {
+		dst = &ArgoCDRBACSpec{
+			DefaultPolicy:     src.DefaultPolicy,
+			Policy:            src.Policy,
+			Scopes:            src.Scopes,
+			PolicyMatcherMode: src.PolicyMatcherMode,
+		}
+	}
+	return dst
+}
+
 func ConvertBetaToAlphaTLS(src *v1beta1.ArgoCDTLSSpec) *ArgoCDTLSSpec {
 	var dst *ArgoCDTLSSpec
 	if src != nil {
@@ -572,14 +791,14 @@ func ConvertBetaToAlphaServer(src *v1beta1.ArgoCDServerSpec) *ArgoCDServerSpec {
 			Autoscale:        ArgoCDServerAutoscaleSpec(src.Autoscale),
 			GRPC:             *ConvertBetaToAlphaGRPC(&src.GRPC),
 			Host:             src.Host,
-			Ingress:          ArgoCDIngressSpec(src.Ingress),
+			Ingress:          ConvertBetaToAlphaIngress(src.Ingress),
 			Insecure:         src.Insecure,
 			LogLevel:         src.LogLevel,
 			LogFormat:        src.LogFormat,
 			Replicas:         src.Replicas,
 			Resources:        src.Resources,
 			Route:            ArgoCDRouteSpec(src.Route),
-			Service:          ArgoCDServerServiceSpec(src.Service),
+			Service:          ArgoCDServerServiceSpec{Type: src.Service.Type},
 			Env:              src.Env,
 			ExtraCommandArgs: src.ExtraCommandArgs,
 		}
@@ -592,7 +811,7 @@ func ConvertBetaToAlphaGRPC(src *v1beta1.ArgoCDServerGRPCSpec) *ArgoCDServerGRPC
 	if src != nil {
 		dst = &ArgoCDServerGRPCSpec{
 			Host:    src.Host,
-			Ingress: ArgoCDIngressSpec(src.Ingress),
+			Ingress: ConvertBetaToAlphaIngress(src.Ingress),
 		}
 	}
 	return dst
@@ -660,6 +879,22 @@ func ConvertBetaToAlphaResourceHealthChecks(src []v1beta1.ResourceHealthCheck) [
 	return dst
 }
 
+func ConvertBetaToAlphaNotifications(src *v1beta1.ArgoCDNotifications) *ArgoCDNotifications {
+	var dst *ArgoCDNotifications
+	if src != nil {
+		dst = &ArgoCDNotifications{
+			Replicas:  src.Replicas,
+			Enabled:   src.Enabled,
+			Env:       src.Env,
+			Image:     src.Image,
+			Version:   src.Version,
+			Resources: src.Resources,
+			LogLevel:  src.LogLevel,
+		}
+	}
+	return dst
+}
+
 func ConvertBetaToAlphaRedis(src *v1beta1.ArgoCDRedisSpec) *ArgoCDRedisSpec {
 	var dst *ArgoCDRedisSpec
 	if src != nil {
@@ -699,3 +934,23 @@ func ConvertBetaToAlphaRepo(src *v1beta1.ArgoCDRepoSpec) *ArgoCDRepoSpec {
 	}
 	return dst
 }
+
+func ConvertBetaToAlphaStatus(src *v1beta1.ArgoCDStatus) *ArgoCDStatus {
+	var dst *ArgoCDStatus
+	if src != nil {
+		dst = &ArgoCDStatus{
+			ApplicationController:    src.ApplicationController,
+			ApplicationSetController: src.ApplicationSetController,
+			SSO:                      src.SSO,
+			NotificationsController:  src.NotificationsController,
+			Phase:                    src.Phase,
+			Redis:                    src.Redis,
+			Repo:                     src.Repo,
+			Server:                   src.Server,
+			RepoTLSChecksum:          src.RepoTLSChecksum,
+			RedisTLSChecksum:         src.RedisTLSChecksum,
+			Host:                     src.Host,
+		}
+	}
+	return dst
+}
@@ -1,17 +1,21 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"testing"
 
+	fuzz "github.com/google/gofuzz"
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
 	v1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 type argoCDAlphaOpt func(*ArgoCD)
@@ -621,3 +625,125 @@ func TestBetaToAlphaConversion(t *testing.T) {
 		})
 	}
 }
+
+// newConversionFuzzer returns a fuzzer populating ArgoCD specs with values that survive a JSON round trip.
+func newConversionFuzzer(seed int64) *fuzz.Fuzzer {
+	return fuzz.NewWithSeed(seed).NilChance(0.3).NumElements(0, 2).MaxDepth(8).Funcs(
+		func(q *resourcev1.Quantity, c fuzz.Continue) {
+			*q = *resourcev1.NewQuantity(c.Int63n(1000), resourcev1.DecimalSI)
+		},
+		func(v *intstr.IntOrString, c fuzz.Continue) {
+			*v = intstr.FromInt(c.Intn(1000))
+		},
+		func(t *metav1.Time, c fuzz.Continue) {
+			*t = metav1.Unix(c.Int63n(1e9), 0)
+		},
+	)
+}
+
+func conversionJSON(t *testing.T, v interface{}) string {
+	out, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return string(out)
+}
+
+func TestConversionRoundTrip_BetaToAlphaToBeta(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		f := newConversionFuzzer(seed)
+		input := makeTestArgoCDBeta()
+		f.Fuzz(&input.Spec)
+
+		alpha := &ArgoCD{}
+		assert.NoError(t, alpha.ConvertFrom(input))
+		result := &v1beta1.ArgoCD{}
+		assert.NoError(t, alpha.ConvertTo(result))
+
+		assert.JSONEq(t, conversionJSON(t, input.Spec), conversionJSON(t, result.Spec), "seed %d", seed)
+		assert.Equal(t, input.ObjectMeta, result.ObjectMeta, "seed %d", seed)
+
+		// changes made through v1alpha1 are applied, while the fields it can't represent are kept
+		f.Fuzz(&alpha.Spec.Image)
+		f.Fuzz(&alpha.Spec.Repo.Replicas)
+		f.Fuzz(&alpha.Spec.Server.Env)
+		input.Spec.Image = alpha.Spec.Image
+		input.Spec.Repo.Replicas = alpha.Spec.Repo.Replicas
+		input.Spec.Server.Env = alpha.Spec.Server.Env
+		result = &v1beta1.ArgoCD{}
+		assert.NoError(t, alpha.ConvertTo(result))
+		assert.JSONEq(t, conversionJSON(t, input.Spec), conversionJSON(t, result.Spec), "seed %d", seed)
+	}
+}
+
+func TestConversionRoundTrip_AlphaToBetaToAlpha(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		input := makeTestArgoCDAlpha()
+		newConversionFuzzer(seed).Fuzz(&input.Spec)
+		// deprecated fields are converted to their v1beta1 replacements, and aren't expected to round trip
+		input.Spec.Dex = nil
+		input.Spec.ResourceCustomizations = ""
+		if input.Spec.SSO != nil {
+			input.Spec.SSO.Image = ""
+			input.Spec.SSO.Version = ""
+			input.Spec.SSO.VerifyTLS = nil
+			input.Spec.SSO.Resources = nil
+		}
+
+		beta := &v1beta1.ArgoCD{}
+		assert.NoError(t, input.ConvertTo(beta))
+		result := &ArgoCD{}
+		assert.NoError(t, result.ConvertFrom(beta))
+
+		assert.JSONEq(t, conversionJSON(t, input.Spec), conversionJSON(t, result.Spec), "seed %d", seed)
+		assert.Equal(t, input.ObjectMeta, result.ObjectMeta, "seed %d", seed)
+	}
+}
+
+func TestConversion_DeprecatedSSOFields(t *testing.T) {
+	input := makeTestArgoCDAlpha(func(cr *ArgoCD) {
+		cr.Spec.SSO = &ArgoCDSSOSpec{
+			Provider: SSOProviderTypeKeycloak,
+			Image:    "keycloak-custom",
+			Keycloak: &ArgoCDKeycloakSpec{
+				Version: "24.0",
+				Host:    "keycloak.example.com",
+			},
+		}
+	})
+
+	result := &v1beta1.ArgoCD{}
+	assert.NoError(t, input.ConvertTo(result))
+	assert.Equal(t, &v1beta1.ArgoCDKeycloakSpec{
+		Image:   "keycloak-custom",
+		Version: "24.0",
+		Host:    "keycloak.example.com",
+	}, result.Spec.SSO.Keycloak)
+}
+
+func TestConversion_ConversionData(t *testing.T) {
+	replicas := int32(3)
+	input := makeTestArgoCDBeta(func(cr *v1beta1.ArgoCD) {
+		cr.Spec.Repo.Cache = &v1beta1.ArgoCDRepoCacheSpec{Enabled: true}
+		cr.Spec.Notifications.SourceNamespaces = []string{"team-*"}
+	})
+
+	alpha := &ArgoCD{}
+	assert.NoError(t, alpha.ConvertFrom(input))
+	// only the fields that can't be represented in v1alpha1 are stashed
+	assert.JSONEq(t, `{"notifications":{"sourceNamespaces":["team-*"]},"repo":{"cache":{"enabled":true}}}`,
+		alpha.Annotations[common.AnnotationConversionData])
+
+	// a v1alpha1 client updates the instance
+	alpha.Spec.Repo.Replicas = &replicas
+	result := &v1beta1.ArgoCD{}
+	assert.NoError(t, alpha.ConvertTo(result))
+	assert.Equal(t, &replicas, result.Spec.Repo.Replicas)
+	assert.Equal(t, &v1beta1.ArgoCDRepoCacheSpec{Enabled: true}, result.Spec.Repo.Cache)
+	assert.Equal(t, []string{"team-*"}, result.Spec.Notifications.SourceNamespaces)
+	assert.Nil(t, result.Annotations)
+
+	// the conversion data is only stashed when needed
+	result.Spec.Repo.Cache = nil
+	result.Spec.Notifications.SourceNamespaces = nil
+	assert.NoError(t, alpha.ConvertFrom(result))
+	assert.NotContains(t, alpha.Annotations, common.AnnotationConversionData)
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func init() {
 	SchemeBuilder.Register(&NotificationsConfiguration{}, &NotificationsConfigurationList{})
@@ -33,7 +36,8 @@ type NotificationsConfiguration struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec NotificationsConfigurationSpec `json:"spec,omitempty"`
+	Spec   NotificationsConfigurationSpec   `json:"spec,omitempty"`
+	Status NotificationsConfigurationStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -59,4 +63,128 @@ type NotificationsConfigurationSpec struct {
 	Subscriptions map[string]string `json:"subscriptions,omitempty"`
 	// Context is used to define some shared context between all notification templates
 	Context map[string]string `json:"context,omitempty"`
+	// ServiceDefinitions are typed notification services, whose credentials are referenced from Secrets in the
+	// namespace of the NotificationsConfiguration and synced into the argocd-notifications-secret Secret.
+	ServiceDefinitions []NotificationsServiceSpec `json:"serviceDefinitions,omitempty"`
+}
+
+// NotificationsServiceSpec defines a notification service. Exactly one of the service types must be set.
+type NotificationsServiceSpec struct {
+	// Name of the service. The service is registered as service.<type>.<name>, or service.<type> if the name is empty.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+	// Slack defines a Slack service.
+	Slack *SlackNotificationsService `json:"slack,omitempty"`
+	// Email defines an email service.
+	Email *EmailNotificationsService `json:"email,omitempty"`
+	// Webhook defines a generic webhook service.
+	Webhook *WebhookNotificationsService `json:"webhook,omitempty"`
+	// Teams defines a Microsoft Teams service.
+	Teams *TeamsNotificationsService `json:"teams,omitempty"`
+	// PagerDuty defines a PagerDuty (Events API v2) service.
+	PagerDuty *PagerDutyNotificationsService `json:"pagerDuty,omitempty"`
+}
+
+// SlackNotificationsService defines the configuration of a Slack notification service.
+type SlackNotificationsService struct {
+	// Token references the Secret key holding the Slack app OAuth token.
+	Token corev1.SecretKeySelector `json:"token"`
+	// SigningSecret references the Secret key holding the Slack app signing secret.
+	SigningSecret *corev1.SecretKeySelector `json:"signingSecret,omitempty"`
+	// Username is the name the messages are sent as.
+	Username string `json:"username,omitempty"`
+	// Icon is the emoji or the image URL used as icon of the messages.
+	Icon string `json:"icon,omitempty"`
+	// APIURL is the URL of the Slack API, for Slack compatible services.
+	APIURL string `json:"apiURL,omitempty"`
+}
+
+// EmailNotificationsService defines the configuration of an email notification service.
+type EmailNotificationsService struct {
+	// Host is the SMTP server host.
+	Host string `json:"host"`
+	// Port is the SMTP server port.
+	Port int32 `json:"port"`
+	// From is the sender address of the emails.
+	From string `json:"from"`
+	// Username references the Secret key holding the SMTP username.
+	Username *corev1.SecretKeySelector `json:"username,omitempty"`
+	// Password references the Secret key holding the SMTP password.
+	Password *corev1.SecretKeySelector `json:"password,omitempty"`
+	// HTML sends the emails as HTML.
+	HTML bool `json:"html,omitempty"`
+	// InsecureSkipVerify disables the verification of the SMTP server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// WebhookNotificationsService defines the configuration of a generic webhook notification service.
+type WebhookNotificationsService struct {
+	// URL of the webhook.
+	URL string `json:"url"`
+	// Headers are the HTTP headers sent with each request.
+	Headers []WebhookNotificationsHeader `json:"headers,omitempty"`
+	// BasicAuth defines the basic authentication credentials of the webhook.
+	BasicAuth *WebhookNotificationsBasicAuth `json:"basicAuth,omitempty"`
+	// InsecureSkipVerify disables the verification of the webhook server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// WebhookNotificationsHeader defines an HTTP header sent to a webhook. Exactly one of Value and ValueFrom must be set.
+type WebhookNotificationsHeader struct {
+	// Name of the header.
+	Name string `json:"name"`
+	// Value of the header.
+	Value string `json:"value,omitempty"`
+	// ValueFrom references the Secret key holding the value of the header.
+	ValueFrom *corev1.SecretKeySelector `json:"valueFrom,omitempty"`
+}
+
+// WebhookNotificationsBasicAuth defines the basic authentication credentials of a webhook.
+type WebhookNotificationsBasicAuth struct {
+	// Username is the basic authentication username.
+	Username string `json:"username"`
+	// Password references the Secret key holding the basic authentication password.
+	Password corev1.SecretKeySelector `json:"password"`
+}
+
+// TeamsNotificationsService defines the configuration of a Microsoft Teams notification service.
+type TeamsNotificationsService struct {
+	// RecipientURLs maps the channel names used in subscriptions to the Secret keys holding their webhook URLs.
+	RecipientURLs map[string]corev1.SecretKeySelector `json:"recipientURLs"`
+}
+
+// PagerDutyNotificationsService defines the configuration of a PagerDuty Events API v2 notification service.
+type PagerDutyNotificationsService struct {
+	// ServiceKeys maps the service names used in subscriptions to the Secret keys holding their integration keys.
+	ServiceKeys map[string]corev1.SecretKeySelector `json:"serviceKeys"`
+}
+
+const (
+	// NotificationsConfigurationConditionInvalid is set when the NotificationsConfiguration can not be applied, for
+	// instance because of an invalid trigger or template.
+	NotificationsConfigurationConditionInvalid = "Invalid"
+
+	// NotificationsConfigurationConditionSynced is set when the notifications configmap matches the
+	// NotificationsConfiguration. It is false when the configmap could not be updated, or was modified manually.
+	NotificationsConfigurationConditionSynced = "Synced"
+
+	// NotificationsConfigurationConditionConflicted is set when triggers, templates or services of the
+	// NotificationsConfiguration are ignored, as they are defined by a NotificationsConfiguration of higher precedence.
+	NotificationsConfigurationConditionConflicted = "Conflicted"
+
+	// NotificationsConfigurationConditionRestricted is set when services, subscriptions or context keys of a
+	// NotificationsConfiguration of a notifications source namespace are ignored, as the namespace isn't trusted.
+	NotificationsConfigurationConditionRestricted = "Restricted"
+)
+
+// NotificationsConfigurationStatus defines the observed state of a NotificationsConfiguration.
+type NotificationsConfigurationStatus struct {
+	// ObservedGeneration is the generation of the NotificationsConfiguration last processed by the operator.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of the NotificationsConfiguration.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Triggers lists the names of the configured triggers.
+	Triggers []string `json:"triggers,omitempty"`
+	// Services lists the names of the configured services.
+	Services []string `json:"services,omitempty"`
 }
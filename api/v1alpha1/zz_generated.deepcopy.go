@@ -1027,6 +1027,31 @@ func (in *Banner) DeepCopy() *Banner {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationsService) DeepCopyInto(out *EmailNotificationsService) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Password != nil {
+		in, out := &in.Password, &out.Password
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationsService.
+func (in *EmailNotificationsService) DeepCopy() *EmailNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IgnoreDifferenceCustomization) DeepCopyInto(out *IgnoreDifferenceCustomization) {
 	*out = *in
@@ -1078,6 +1103,7 @@ func (in *NotificationsConfiguration) DeepCopyInto(out *NotificationsConfigurati
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfiguration.
@@ -1168,6 +1194,13 @@ func (in *NotificationsConfigurationSpec) DeepCopyInto(out *NotificationsConfigu
 			(*out)[key] = val
 		}
 	}
+	if in.ServiceDefinitions != nil {
+		in, out := &in.ServiceDefinitions, &out.ServiceDefinitions
+		*out = make([]NotificationsServiceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfigurationSpec.
@@ -1180,6 +1213,100 @@ func (in *NotificationsConfigurationSpec) DeepCopy() *NotificationsConfiguration
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfigurationStatus) DeepCopyInto(out *NotificationsConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfigurationStatus.
+func (in *NotificationsConfigurationStatus) DeepCopy() *NotificationsConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsServiceSpec) DeepCopyInto(out *NotificationsServiceSpec) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackNotificationsService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailNotificationsService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookNotificationsService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(TeamsNotificationsService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutyNotificationsService)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsServiceSpec.
+func (in *NotificationsServiceSpec) DeepCopy() *NotificationsServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyNotificationsService) DeepCopyInto(out *PagerDutyNotificationsService) {
+	*out = *in
+	if in.ServiceKeys != nil {
+		in, out := &in.ServiceKeys, &out.ServiceKeys
+		*out = make(map[string]v1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyNotificationsService.
+func (in *PagerDutyNotificationsService) DeepCopy() *PagerDutyNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceAction) DeepCopyInto(out *ResourceAction) {
 	*out = *in
@@ -1268,6 +1395,112 @@ func (in *SSHHostsSpec) DeepCopy() *SSHHostsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotificationsService) DeepCopyInto(out *SlackNotificationsService) {
+	*out = *in
+	in.Token.DeepCopyInto(&out.Token)
+	if in.SigningSecret != nil {
+		in, out := &in.SigningSecret, &out.SigningSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotificationsService.
+func (in *SlackNotificationsService) DeepCopy() *SlackNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamsNotificationsService) DeepCopyInto(out *TeamsNotificationsService) {
+	*out = *in
+	if in.RecipientURLs != nil {
+		in, out := &in.RecipientURLs, &out.RecipientURLs
+		*out = make(map[string]v1.SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamsNotificationsService.
+func (in *TeamsNotificationsService) DeepCopy() *TeamsNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamsNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotificationsBasicAuth) DeepCopyInto(out *WebhookNotificationsBasicAuth) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationsBasicAuth.
+func (in *WebhookNotificationsBasicAuth) DeepCopy() *WebhookNotificationsBasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotificationsBasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotificationsHeader) DeepCopyInto(out *WebhookNotificationsHeader) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationsHeader.
+func (in *WebhookNotificationsHeader) DeepCopy() *WebhookNotificationsHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotificationsHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookNotificationsService) DeepCopyInto(out *WebhookNotificationsService) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]WebhookNotificationsHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(WebhookNotificationsBasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookNotificationsService.
+func (in *WebhookNotificationsService) DeepCopy() *WebhookNotificationsService {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookNotificationsService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookServerSpec) DeepCopyInto(out *WebhookServerSpec) {
 	*out = *in
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"strings"
+	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 
@@ -26,7 +27,9 @@ import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func init() {
@@ -66,6 +69,41 @@ type ArgoCD struct {
 	Status ArgoCDStatus `json:"status,omitempty"`
 }
 
+// ArgoCDAdminPasswordRotationSpec defines the rotation policy of the admin password.
+// +kubebuilder:validation:XValidation:rule="(has(self.digits) ? self.digits : 0) + (has(self.symbols) ? self.symbols : 0) <= (has(self.length) ? self.length : 32)",message="digits and symbols must not exceed the length of the password"
+type ArgoCDAdminPasswordRotationSpec struct {
+	// Enabled toggles the rotation of the admin password.
+	Enabled bool `json:"enabled"`
+
+	// Interval is the time between two rotations of the admin password, counted from its last change.
+	Interval metav1.Duration `json:"interval"`
+
+	// Length is the length of the generated password.
+	// +kubebuilder:default=32
+	// +kubebuilder:validation:Minimum=16
+	// +kubebuilder:validation:Maximum=64
+	Length int `json:"length,omitempty"`
+
+	// Digits is the number of digits in the generated password.
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	Digits int `json:"digits,omitempty"`
+
+	// Symbols is the number of symbols in the generated password.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=64
+	Symbols int `json:"symbols,omitempty"`
+
+	// NoUpper excludes uppercase letters from the generated password.
+	NoUpper bool `json:"noUpper,omitempty"`
+}
+
+// IsEnabled returns true if the admin password is rotated.
+func (s *ArgoCDAdminPasswordRotationSpec) IsEnabled() bool {
+	return s != nil && s.Enabled && s.Interval.Duration > 0
+}
+
 // ArgoCDApplicationControllerProcessorsSpec defines the options for the ArgoCD Application Controller processors.
 type ArgoCDApplicationControllerProcessorsSpec struct {
 	// Operation is the number of application operation processors.
@@ -99,6 +137,9 @@ type ArgoCDApplicationControllerSpec struct {
 	// ParallelismLimit defines the limit for parallel kubectl operations
 	ParallelismLimit int32 `json:"parallelismLimit,omitempty"`
 
+	// MetricsService defines the options for the Service exposing the metrics of the Application Controller component.
+	MetricsService ArgoCDServiceSpec `json:"metricsService,omitempty"`
+
 	// AppSync is used to control the sync frequency, by default the ArgoCD
 	// controller polls Git every 3m.
 	//
@@ -207,6 +248,9 @@ type ArgoCDApplicationSet struct {
 
 	// Custom labels to pods deployed by the operator
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Service defines the options for the Service backing the ApplicationSet controller webhook and metrics.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
 }
 
 func (a *ArgoCDApplicationSet) IsEnabled() bool {
@@ -255,6 +299,131 @@ type ArgoCDDexSpec struct {
 
 	// Env lets you specify environment variables for Dex.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Service defines the options for the Service backing the Dex server.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+}
+
+// DriftDetectionMode defines how the operator reacts to drift of operator-managed resources.
+type DriftDetectionMode string
+
+const (
+	// DriftDetectionModeReconcile records drift and restores the desired state of the drifted resources.
+	DriftDetectionModeReconcile DriftDetectionMode = "Reconcile"
+
+	// DriftDetectionModeReport records drift without changing the drifted resources.
+	DriftDetectionModeReport DriftDetectionMode = "Report"
+)
+
+// ArgoCDDriftDetectionSpec defines the options for detecting drift between the desired and the live
+// state of the resources managed by the operator.
+type ArgoCDDriftDetectionSpec struct {
+	// Enabled defines whether drift detection is enabled for this instance.
+	Enabled bool `json:"enabled"`
+
+	// Mode defines how the operator reacts to drift. Reconcile (default) reverts drifted resources to their
+	// desired state, Report only records the drift and leaves the live resources untouched.
+	// +kubebuilder:validation:Enum=Reconcile;Report
+	Mode DriftDetectionMode `json:"mode,omitempty"`
+}
+
+// IsReportOnly returns true if drifted resources should only be reported and not reverted.
+func (d *ArgoCDDriftDetectionSpec) IsReportOnly() bool {
+	return d != nil && d.Enabled && d.Mode == DriftDetectionModeReport
+}
+
+// ManagedNamespacesRBACProfile defines the permissions of Argo CD in a managed namespace.
+// +kubebuilder:validation:Enum=admin;read-only
+type ManagedNamespacesRBACProfile string
+
+const (
+	// ManagedNamespacesRBACProfileAdmin allows Argo CD to manage every resource of the namespace.
+	ManagedNamespacesRBACProfileAdmin ManagedNamespacesRBACProfile = "admin"
+
+	// ManagedNamespacesRBACProfileReadOnly allows Argo CD to observe the resources of the namespace, Secrets
+	// included, without changing them.
+	ManagedNamespacesRBACProfileReadOnly ManagedNamespacesRBACProfile = "read-only"
+)
+
+// ManagedNamespacesSpec selects namespaces to be managed by an ArgoCD instance.
+type ManagedNamespacesSpec struct {
+	// Name identifies the selection in the status of the managed namespaces.
+	Name string `json:"name"`
+
+	// Selector selects the managed namespaces by their labels.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// RBACProfile defines the permissions of the application controller and server in the selected namespaces,
+	// admin by default.
+	RBACProfile ManagedNamespacesRBACProfile `json:"rbacProfile,omitempty"`
+}
+
+const (
+	// ManagedNamespaceReasonInstance is the reason of the namespace of the ArgoCD instance.
+	ManagedNamespaceReasonInstance = "Instance"
+
+	// ManagedNamespaceReasonLabel is the reason of the namespaces labelled with argocd.argoproj.io/managed-by.
+	ManagedNamespaceReasonLabel = "Label"
+
+	// ManagedNamespaceReasonSelector is the reason of the namespaces selected by `.spec.managedNamespaces`.
+	ManagedNamespaceReasonSelector = "Selector"
+)
+
+// ManagedNamespace describes a namespace managed by an ArgoCD instance.
+type ManagedNamespace struct {
+	// Name is the name of the namespace.
+	Name string `json:"name"`
+
+	// Reason is why the namespace is managed: Instance for the namespace of the ArgoCD, Label for a namespace
+	// labelled with argocd.argoproj.io/managed-by, or Selector for a namespace selected by `.spec.managedNamespaces`.
+	Reason string `json:"reason"`
+
+	// Selector is the name of the `.spec.managedNamespaces` entry selecting the namespace, for the Selector reason.
+	Selector string `json:"selector,omitempty"`
+
+	// RBACProfile is the permissions of Argo CD in the namespace.
+	RBACProfile ManagedNamespacesRBACProfile `json:"rbacProfile"`
+}
+
+// DriftedResource describes an operator-managed resource whose live state differed from its desired state.
+type DriftedResource struct {
+	// Kind is the kind of the drifted resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the drifted resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the drifted resource. Empty for cluster scoped resources.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Fields lists the paths of the fields whose live value differed from the desired value.
+	Fields []string `json:"fields,omitempty"`
+
+	// Reverted is true if the operator restored the desired state of the resource.
+	Reverted bool `json:"reverted,omitempty"`
+}
+
+// ExecShell is a shell that may be used by the terminal feature of the Argo CD UI.
+// +kubebuilder:validation:Enum=bash;sh;powershell;cmd
+type ExecShell string
+
+// ArgoCDExecSpec defines the options of the web-based terminal of the Argo CD UI.
+type ArgoCDExecSpec struct {
+	// Enabled toggles the web-based terminal, sets `exec.enabled` in argocd-cm.
+	Enabled bool `json:"enabled"`
+
+	// Shells is the ordered list of shells tried when opening a terminal, sets `exec.shells` in argocd-cm.
+	Shells []ExecShell `json:"shells,omitempty"`
+}
+
+// ExtraConfigConflict describes an ExtraConfig entry set for an argocd-cm key that is managed by the operator.
+type ExtraConfigConflict struct {
+	// Key is the conflicting argocd-cm key.
+	Key string `json:"key"`
+
+	// Ignored is true if the ExtraConfig value was discarded because the key may not be overridden
+	// through ExtraConfig, false if the ExtraConfig value took precedence over the managed value.
+	Ignored bool `json:"ignored,omitempty"`
 }
 
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
@@ -290,6 +459,30 @@ type ArgoCDGrafanaSpec struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ArgoCDHelmSpec defines the Helm options of Argo CD.
+type ArgoCDHelmSpec struct {
+	// ValuesFileSchemes are the URL schemes allowed for remote Helm values files, sets `helm.valuesFileSchemes` in argocd-cm.
+	ValuesFileSchemes []string `json:"valuesFileSchemes,omitempty"`
+}
+
+// ArgoCDGatewaySpec defines the desired state for the Gateway API routes exposing a component.
+type ArgoCDGatewaySpec struct {
+	// Annotations is the map of annotations to apply to the route.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is the map of labels to add to the route.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Enabled will toggle the creation of the route.
+	Enabled bool `json:"enabled"`
+
+	// ParentRefs references the Gateways the route attaches to.
+	ParentRefs []gatewayv1.ParentReference `json:"parentRefs,omitempty"`
+
+	// Path used for the HTTPRoute, defaults to /. It is ignored for GRPCRoutes.
+	Path string `json:"path,omitempty"`
+}
+
 // ArgoCDHASpec defines the desired state for High Availability support for Argo CD.
 type ArgoCDHASpec struct {
 	// Enabled will toggle HA support globally for Argo CD.
@@ -317,11 +510,28 @@ type ArgoCDImportSpec struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// IngressControllerProfile is the ingress controller an Ingress is configured for.
+// +kubebuilder:validation:Enum=nginx;traefik;haproxy;contour;alb
+type IngressControllerProfile string
+
+const (
+	IngressControllerProfileNginx   IngressControllerProfile = "nginx"
+	IngressControllerProfileTraefik IngressControllerProfile = "traefik"
+	IngressControllerProfileHAProxy IngressControllerProfile = "haproxy"
+	IngressControllerProfileContour IngressControllerProfile = "contour"
+	IngressControllerProfileALB     IngressControllerProfile = "alb"
+)
+
 // ArgoCDIngressSpec defines the desired state for the Ingress resources.
 type ArgoCDIngressSpec struct {
-	// Annotations is the map of annotations to apply to the Ingress.
+	// Annotations is the map of annotations to apply to the Ingress. The annotations replace the nginx defaults,
+	// or are merged on top of the defaults of the ControllerProfile when it is set.
 	Annotations map[string]string `json:"annotations,omitempty"`
 
+	// ControllerProfile selects the default annotations and backend protocol of the Ingress for the given
+	// ingress controller.
+	ControllerProfile IngressControllerProfile `json:"controllerProfile,omitempty"`
+
 	// Enabled will toggle the creation of the Ingress.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
@@ -329,6 +539,10 @@ type ArgoCDIngressSpec struct {
 	// IngressClassName for the Ingress resource.
 	IngressClassName *string `json:"ingressClassName,omitempty"`
 
+	// GRPCMultiplexing serves the GRPC endpoint on the host of the Argo CD Server Ingress using TLS passthrough.
+	// Only supported by the nginx and haproxy controller profiles, it is ignored for the other Ingresses.
+	GRPCMultiplexing bool `json:"grpcMultiplexing,omitempty"`
+
 	// Path used for the Ingress resource.
 	Path string `json:"path,omitempty"`
 
@@ -360,6 +574,9 @@ type ArgoCDKeycloakSpec struct {
 
 	// Host is the hostname to use for Ingress/Route resources.
 	Host string `json:"host,omitempty"`
+
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Keycloak component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -394,6 +611,16 @@ type ArgoCDNotifications struct {
 
 	// LogLevel describes the log level that should be used by the argocd-notifications. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// SourceNamespaces lists the namespaces, as names or glob patterns, whose NotificationsConfigurations are merged
+	// into the notifications configuration of this instance, along with the ones of the instance namespace. Only the
+	// triggers and templates of the source namespaces are merged, unless they are trusted.
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
+
+	// TrustedSourceNamespaces lists the source namespaces, as names or glob patterns, whose NotificationsConfigurations
+	// may also define services, reading their Secrets from the source namespace, and subscriptions applying to every
+	// Application of the instance. The context is never taken from a source namespace.
+	TrustedSourceNamespaces []string `json:"trustedSourceNamespaces,omitempty"`
 }
 
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
@@ -442,6 +669,10 @@ type ArgoCDRBACSpec struct {
 	// PolicyMatcherMode configures the matchers function mode for casbin.
 	// There are two options for this, 'glob' for glob matcher or 'regex' for regex matcher.
 	PolicyMatcherMode *string `json:"policyMatcherMode,omitempty"`
+
+	// LogEnforceEnable toggles the logging of the RBAC enforcement decisions of the Argo CD server,
+	// sets `server.rbac.log.enforce.enable` in argocd-cm.
+	LogEnforceEnable *bool `json:"logEnforceEnable,omitempty"`
 }
 
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
@@ -471,6 +702,9 @@ type ArgoCDRedisSpec struct {
 
 	// Remote specifies the remote URL of the Redis container. (optional, by default, a local instance managed by the operator is used.)
 	Remote *string `json:"remote,omitempty"`
+
+	// Service defines the options for the Service backing the Redis server, when not running in HA mode.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
 }
 
 func (a *ArgoCDRedisSpec) IsEnabled() bool {
@@ -525,6 +759,9 @@ type ArgoCDRepoSpec struct {
 	// ExecTimeout specifies the timeout in seconds for tool execution
 	ExecTimeout *int `json:"execTimeout,omitempty"`
 
+	// Cache defines the persistent cache of the repo server, and the tuning of its caching and parallelism.
+	Cache *ArgoCDRepoCacheSpec `json:"cache,omitempty"`
+
 	// Env lets you specify environment for repo server pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
 
@@ -540,6 +777,13 @@ type ArgoCDRepoSpec struct {
 	// SidecarContainers defines the list of sidecar containers for the repo server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
+	// Plugins defines the Config Management Plugins to run as sidecars of the repo server. The operator generates
+	// the sidecar containers, along with the volumes needed by the argocd-cmp-server to register the plugins.
+	Plugins []ArgoCDRepoPluginSpec `json:"plugins,omitempty"`
+
+	// Service defines the options for the Service backing the Repo Server component.
+	Service ArgoCDServiceSpec `json:"service,omitempty"`
+
 	// Enabled is the flag to enable Repo Server during ArgoCD installation. (optional, default `true`)
 	Enabled *bool `json:"enabled,omitempty"`
 
@@ -553,6 +797,70 @@ type ArgoCDRepoSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 }
 
+// ArgoCDRepoPluginSpec defines a Config Management Plugin run as a sidecar of the repo server.
+type ArgoCDRepoPluginSpec struct {
+	// Name of the plugin, used as name of the sidecar container.
+	// +kubebuilder:validation:MaxLength=48
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Image of the sidecar container. It must provide the tools run by the plugin.
+	Image string `json:"image"`
+
+	// Config is the content of the plugin.yaml file, defining the ConfigManagementPlugin. The operator stores it in
+	// the argocd-cmp-cm ConfigMap. Either Config or ConfigMap must be set.
+	Config string `json:"config,omitempty"`
+
+	// ConfigMap selects the key of a user managed ConfigMap holding the plugin.yaml file of the plugin.
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// Resources defines the Compute Resources required by the sidecar container.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env lets you specify environment variables for the sidecar container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// ArgoCDRepoCacheEvictionPolicy defines what happens to the repo server cache volumes when replicas go away.
+type ArgoCDRepoCacheEvictionPolicy string
+
+const (
+	// ArgoCDRepoCacheEvictionPolicyRetain keeps the cache volumes when the repo server is scaled down or deleted.
+	ArgoCDRepoCacheEvictionPolicyRetain ArgoCDRepoCacheEvictionPolicy = "Retain"
+
+	// ArgoCDRepoCacheEvictionPolicyDelete deletes the cache volumes when the repo server is scaled down or deleted.
+	ArgoCDRepoCacheEvictionPolicyDelete ArgoCDRepoCacheEvictionPolicy = "Delete"
+)
+
+// ArgoCDRepoCacheSpec defines the persistent Git and Helm cache of the repo server.
+type ArgoCDRepoCacheSpec struct {
+	// Enabled runs the repo server as a StatefulSet, with a PersistentVolumeClaim per replica mounted at /tmp, where
+	// the repo server keeps its Git working copies and Helm charts. The cache then survives pod restarts.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Size is the requested size of the cache volume of each replica. Defaults to 10Gi.
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the StorageClass used for the cache volumes. The default StorageClass is used if not set.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// EvictionPolicy defines whether the cache volumes are retained or deleted when the repo server is scaled down
+	// or deleted. Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	EvictionPolicy ArgoCDRepoCacheEvictionPolicy `json:"evictionPolicy,omitempty"`
+
+	// ParallelismLimit limits the number of concurrent manifest generations per repo server replica.
+	// +kubebuilder:validation:Minimum=0
+	ParallelismLimit *int32 `json:"parallelismLimit,omitempty"`
+
+	// LsRemoteParallelismLimit limits the number of concurrent git ls-remote requests per repo server replica.
+	// +kubebuilder:validation:Minimum=0
+	LsRemoteParallelismLimit *int32 `json:"lsRemoteParallelismLimit,omitempty"`
+
+	// Expiration is how long the generated manifests are kept in the repository cache. Defaults to 24h.
+	Expiration *metav1.Duration `json:"expiration,omitempty"`
+}
+
 func (a *ArgoCDRepoSpec) IsEnabled() bool {
 	return a.Enabled == nil || (a.Enabled != nil && *a.Enabled)
 }
@@ -561,6 +869,10 @@ func (a *ArgoCDRepoSpec) IsRemote() bool {
 	return a.Remote != nil && *a.Remote != ""
 }
 
+func (a *ArgoCDRepoSpec) IsCacheEnabled() bool {
+	return a.Cache != nil && a.Cache.Enabled
+}
+
 // ArgoCDRouteSpec defines the desired state for an OpenShift Route.
 type ArgoCDRouteSpec struct {
 	// Annotations is the map of annotations to use for the Route resource.
@@ -599,6 +911,9 @@ type ArgoCDServerGRPCSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API GRPCRoute for the Argo CD Server GRPC endpoint.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Ingress defines the desired state for the Argo CD Server GRPC Ingress.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
@@ -606,12 +921,21 @@ type ArgoCDServerGRPCSpec struct {
 
 // ArgoCDServerSpec defines the options for the ArgoCD Server component.
 type ArgoCDServerSpec struct {
+	// AdditionalHosts are the hostnames, besides Host, to use for the Ingress and Gateway API resources of the Argo CD Server component.
+	AdditionalHosts []string `json:"additionalHosts,omitempty"`
+
 	// Autoscale defines the autoscale options for the Argo CD Server component.
 	Autoscale ArgoCDServerAutoscaleSpec `json:"autoscale,omitempty"`
 
+	// CanonicalHost is the hostname used in the external URL of Argo CD. It must be Host or one of AdditionalHosts and defaults to Host.
+	CanonicalHost string `json:"canonicalHost,omitempty"`
+
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Argo CD Server component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// InitContainers defines the list of initialization containers for the Argo CD Server component.
 	InitContainers []corev1.Container `json:"initContainers,omitempty"`
 
@@ -645,6 +969,9 @@ type ArgoCDServerSpec struct {
 	// Service defines the options for the Service backing the ArgoCD Server component.
 	Service ArgoCDServerServiceSpec `json:"service,omitempty"`
 
+	// MetricsService defines the options for the Service exposing the metrics of the ArgoCD Server component.
+	MetricsService ArgoCDServiceSpec `json:"metricsService,omitempty"`
+
 	// SidecarContainers defines the list of sidecar containers for the server deployment
 	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
 
@@ -681,6 +1008,42 @@ type ArgoCDServerServiceSpec struct {
 	// Type is the ServiceType to use for the Service resource.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Type'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Type corev1.ServiceType `json:"type"`
+
+	ArgoCDServiceOptions `json:",inline"`
+}
+
+// ArgoCDServiceSpec defines the Service options for an Argo CD component.
+type ArgoCDServiceSpec struct {
+	// Type is the ServiceType to use for the Service resource. Defaults to ClusterIP.
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	ArgoCDServiceOptions `json:",inline"`
+}
+
+// ArgoCDServiceOptions defines the customizations of the Service resource of an Argo CD component.
+type ArgoCDServiceOptions struct {
+	// Annotations is the map of annotations to add to the Service resource, for example to configure a cloud load balancer.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ExternalTrafficPolicy defines how the traffic from outside the cluster is routed to the endpoints of a NodePort or LoadBalancer Service.
+	//+kubebuilder:validation:Enum=Cluster;Local
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
+
+	// IPFamilyPolicy defines the dual-stack-ness of the Service resource.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// Labels is the map of labels to add to the Service resource.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// LoadBalancerSourceRanges restricts the client IPs allowed to access a LoadBalancer Service.
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+
+	// NodePorts is the map of the ports of a NodePort or LoadBalancer Service, by name, to the node ports to expose them on.
+	NodePorts map[string]int32 `json:"nodePorts,omitempty"`
+
+	// SessionAffinity enables client IP based session affinity.
+	//+kubebuilder:validation:Enum=ClientIP;None
+	SessionAffinity corev1.ServiceAffinity `json:"sessionAffinity,omitempty"`
 }
 
 // Resource Customization for custom health check
@@ -746,6 +1109,92 @@ type KustomizeVersionSpec struct {
 	Version string `json:"version,omitempty"`
 	// Path is the path to a configured kustomize version on the filesystem of your repo server.
 	Path string `json:"path,omitempty"`
+	// BuildOptions are the build options used with this kustomize version, sets `kustomize.buildOptions.<version>` in argocd-cm.
+	BuildOptions string `json:"buildOptions,omitempty"`
+}
+
+// LocalUserCapability is a capability of an Argo CD local user.
+// +kubebuilder:validation:Enum=login;apiKey
+type LocalUserCapability string
+
+const (
+	// LocalUserCapabilityLogin allows the user to log in to the UI and the CLI.
+	LocalUserCapabilityLogin LocalUserCapability = "login"
+
+	// LocalUserCapabilityAPIKey allows API tokens to be generated for the user.
+	LocalUserCapabilityAPIKey LocalUserCapability = "apiKey"
+)
+
+// LocalUserSpec defines a local user of Argo CD.
+type LocalUserSpec struct {
+	// Name is the name of the user, it may not be admin.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Enabled toggles the user, defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Capabilities are the capabilities of the user, defaults to login.
+	Capabilities []LocalUserCapability `json:"capabilities,omitempty"`
+
+	// PasswordSecret references the key of a Secret, in the namespace of the ArgoCD, holding the bcrypt hash of the password of the user.
+	// When omitted, the password set with `argocd account update-password` is kept.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+
+	// APIToken defines the API token generated by the operator for the user, it requires the apiKey capability.
+	APIToken *LocalUserAPITokenSpec `json:"apiToken,omitempty"`
+}
+
+// IsEnabled returns true if the user is enabled.
+func (u LocalUserSpec) IsEnabled() bool {
+	return u.Enabled == nil || *u.Enabled
+}
+
+// GetCapabilities returns the capabilities of the user.
+func (u LocalUserSpec) GetCapabilities() []LocalUserCapability {
+	if len(u.Capabilities) == 0 {
+		return []LocalUserCapability{LocalUserCapabilityLogin}
+	}
+	return u.Capabilities
+}
+
+// HasCapability returns true if the user has the given capability.
+func (u LocalUserSpec) HasCapability(c LocalUserCapability) bool {
+	for _, uc := range u.GetCapabilities() {
+		if uc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPITokenEnabled returns true if an API token should be generated for the user.
+func (u LocalUserSpec) IsAPITokenEnabled() bool {
+	return u.APIToken != nil && u.APIToken.Enabled
+}
+
+// LocalUserAPITokenSpec defines the API token generated for a local user. The token is written to
+// the `apiToken` key of the `<argocd name>-local-user-<user name>` Secret.
+type LocalUserAPITokenSpec struct {
+	// Enabled toggles the generation of the API token.
+	Enabled bool `json:"enabled"`
+
+	// TTL is the lifetime of the token, the token does not expire when omitted.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// RenewBefore is the time before the expiry of the token at which it is renewed, defaults to a third of the TTL.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// GetRenewBefore returns the time before the expiry of the token at which it is renewed.
+func (t *LocalUserAPITokenSpec) GetRenewBefore() time.Duration {
+	if t.RenewBefore != nil {
+		return t.RenewBefore.Duration
+	}
+	if t.TTL != nil {
+		return t.TTL.Duration / 3
+	}
+	return 0
 }
 
 // ArgoCDMonitoringSpec is used to configure workload status monitoring for a given Argo CD instance.
@@ -757,6 +1206,141 @@ type ArgoCDMonitoringSpec struct {
 	Enabled bool `json:"enabled"`
 	// DisableMetrics field can be used to enable or disable the collection of Metrics on Openshift
 	DisableMetrics *bool `json:"disableMetrics,omitempty"`
+	// Alerts configures the alert rules of the PrometheusRule created for this instance.
+	Alerts ArgoCDMonitoringAlertsSpec `json:"alerts,omitempty"`
+	// ServiceMonitor configures the ServiceMonitors created for the components of this instance.
+	ServiceMonitor ArgoCDServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+	// Dashboards publishes the Argo CD Grafana dashboards for discovery by an existing Grafana.
+	Dashboards ArgoCDDashboardsSpec `json:"dashboards,omitempty"`
+}
+
+// ArgoCDDashboardsSpec configures the Grafana dashboards published for an Argo CD instance.
+type ArgoCDDashboardsSpec struct {
+	// Enabled publishes the dashboards in a ConfigMap labelled for the Grafana dashboards sidecar.
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the labels of the published dashboards.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Folder is the Grafana folder of the dashboards.
+	Folder string `json:"folder,omitempty"`
+	// ScopeToNamespace restricts the dashboards to the metrics of the namespace of the instance, instead of letting
+	// the namespace be selected.
+	ScopeToNamespace bool `json:"scopeToNamespace,omitempty"`
+	// GrafanaOperator publishes the dashboards as GrafanaDashboard resources of the grafana-operator as well.
+	GrafanaOperator ArgoCDGrafanaOperatorDashboardsSpec `json:"grafanaOperator,omitempty"`
+}
+
+// ArgoCDGrafanaOperatorDashboardsSpec configures the GrafanaDashboard resources published for an Argo CD instance.
+type ArgoCDGrafanaOperatorDashboardsSpec struct {
+	// Enabled publishes the dashboards as GrafanaDashboard resources, when the grafana-operator API is available.
+	Enabled bool `json:"enabled,omitempty"`
+	// InstanceSelector selects the Grafana instances importing the dashboards.
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+	// AllowCrossNamespaceImport lets Grafana instances of other namespaces import the dashboards.
+	AllowCrossNamespaceImport bool `json:"allowCrossNamespaceImport,omitempty"`
+}
+
+// ArgoCDMonitoringAlertsSpec configures the alert rules of the PrometheusRule of an Argo CD instance.
+type ArgoCDMonitoringAlertsSpec struct {
+	// ApplicationAlertsDisabled disables the alerts on the health and the sync status of the Applications.
+	ApplicationAlertsDisabled bool `json:"applicationAlertsDisabled,omitempty"`
+	// Labels are added to the labels of every alert rule.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Overrides tune or disable the alert rules created by the operator.
+	Overrides []ArgoCDAlertOverride `json:"overrides,omitempty"`
+	// CustomRules are additional alert rules added to the PrometheusRule.
+	CustomRules []ArgoCDAlertRule `json:"customRules,omitempty"`
+}
+
+// ArgoCDAlertOverride tunes an alert rule created by the operator.
+type ArgoCDAlertOverride struct {
+	// Alert is the name of the alert rule, such as ServerNotReady.
+	Alert string `json:"alert"`
+	// Disabled removes the alert rule.
+	Disabled bool `json:"disabled,omitempty"`
+	// For is the duration the condition of the alert must hold before it fires, such as 10m.
+	For string `json:"for,omitempty"`
+	// Severity is the value of the severity label of the alert.
+	Severity string `json:"severity,omitempty"`
+	// Labels are added to the labels of the alert.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ArgoCDAlertRule is an alert rule of the PrometheusRule of an Argo CD instance.
+type ArgoCDAlertRule struct {
+	// Alert is the name of the alert.
+	Alert string `json:"alert"`
+	// Expr is the PromQL expression of the alert.
+	Expr string `json:"expr"`
+	// For is the duration the expression must hold before the alert fires, such as 10m.
+	For string `json:"for,omitempty"`
+	// Labels are the labels of the alert.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations of the alert.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ArgoCDServiceMonitorSpec configures the scrape endpoints of the ServiceMonitors of an Argo CD instance.
+type ArgoCDServiceMonitorSpec struct {
+	// Interval is the interval at which the metrics are scraped, such as 30s.
+	Interval string `json:"interval,omitempty"`
+	// ScrapeTimeout is the timeout of a scrape, such as 10s.
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// Scheme is the HTTP scheme of the scrapes, http or https.
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+	// TLSConfig is the TLS configuration of the scrapes.
+	TLSConfig *ArgoCDServiceMonitorTLSConfig `json:"tlsConfig,omitempty"`
+	// BearerTokenSecret is the key of the secret holding the bearer token of the scrapes.
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+	// Labels are added to the labels of the ServiceMonitors, such as the label selecting them in the Prometheus spec.
+	Labels map[string]string `json:"labels,omitempty"`
+	// MetricRelabelings are applied to the scraped samples before ingestion.
+	MetricRelabelings []ArgoCDRelabelConfig `json:"metricRelabelings,omitempty"`
+	// RedisExporter deploys a Redis exporter alongside Redis, scraped by a ServiceMonitor.
+	RedisExporter ArgoCDRedisExporterSpec `json:"redisExporter,omitempty"`
+}
+
+// ArgoCDServiceMonitorTLSConfig is the TLS configuration of the scrapes of the ServiceMonitors of an Argo CD instance.
+type ArgoCDServiceMonitorTLSConfig struct {
+	// CASecret is the key of the secret holding the CA certificate verifying the scraped endpoints.
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+	// CertSecret is the key of the secret holding the client certificate of the scrapes.
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+	// KeySecret is the key of the secret holding the client key of the scrapes.
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+	// ServerName is the server name verified in the certificates of the scraped endpoints.
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificates of the scraped endpoints.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ArgoCDRelabelConfig is a relabeling of the samples scraped by the ServiceMonitors of an Argo CD instance.
+type ArgoCDRelabelConfig struct {
+	// SourceLabels are the labels whose values are concatenated and matched against Regex.
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// Separator is placed between the concatenated source label values, ; by default.
+	Separator string `json:"separator,omitempty"`
+	// TargetLabel is the label written by the replace action.
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Regex is matched against the concatenated source label values, (.*) by default.
+	Regex string `json:"regex,omitempty"`
+	// Replacement is the value written by the replace action, $1 by default.
+	Replacement string `json:"replacement,omitempty"`
+	// Action is the relabeling action, replace by default.
+	// +kubebuilder:validation:Enum=replace;keep;drop;labelmap;labeldrop;labelkeep
+	Action string `json:"action,omitempty"`
+}
+
+// ArgoCDRedisExporterSpec configures the Redis exporter of an Argo CD instance.
+type ArgoCDRedisExporterSpec struct {
+	// Enabled deploys the exporter as a sidecar of Redis. It isn't supported when HA is enabled.
+	Enabled bool `json:"enabled,omitempty"`
+	// Image is the container image of the exporter.
+	Image string `json:"image,omitempty"`
+	// Version is the tag of the container image of the exporter.
+	Version string `json:"version,omitempty"`
+	// Resources defines the Compute Resources required by the exporter.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
@@ -771,6 +1355,9 @@ type ArgoCDNodePlacementSpec struct {
 // +k8s:openapi-gen=true
 type ArgoCDSpec struct {
 
+	// AdminPasswordRotation defines the rotation policy of the admin password.
+	AdminPasswordRotation *ArgoCDAdminPasswordRotationSpec `json:"adminPasswordRotation,omitempty"`
+
 	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
 	ApplicationSet *ArgoCDApplicationSet `json:"applicationSet,omitempty"`
 
@@ -779,6 +1366,7 @@ type ArgoCDSpec struct {
 	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
 
 	// ConfigManagementPlugins is used to specify additional config management plugins.
+	// Deprecated: plugins configured in argocd-cm are not supported from ArgoCD v2.8, use .spec.repo.plugins instead.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Config Management Plugins'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ConfigManagementPlugins string `json:"configManagementPlugins,omitempty"`
 
@@ -788,12 +1376,20 @@ type ArgoCDSpec struct {
 	// DisableAdmin will disable the admin user.
 	DisableAdmin bool `json:"disableAdmin,omitempty"`
 
+	// DriftDetection defines the options for detecting drift of the resources managed by the operator.
+	DriftDetection *ArgoCDDriftDetectionSpec `json:"driftDetection,omitempty"`
+
+	// Exec defines the options of the web-based terminal of the Argo CD UI.
+	Exec *ArgoCDExecSpec `json:"exec,omitempty"`
+
 	// ExtraConfig can be used to add fields to Argo CD configmap that are not supported by Argo CD CRD.
 	//
 	// Note: ExtraConfig takes precedence over Argo CD CRD.
 	// For example, A user sets `argocd.Spec.DisableAdmin` = true and also
 	// `a.Spec.ExtraConfig["admin.enabled"]` = true. In this case, operator updates
 	// Argo CD Configmap as follows -> argocd-cm.Data["admin.enabled"] = true.
+	// Keys set from typed settings, such as `exec.enabled` through `.spec.exec`, are the exception:
+	// conflicting ExtraConfig values for them are ignored. Conflicts are reported in `.status.extraConfigConflicts`.
 	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
 
 	// GATrackingID is the google analytics tracking ID to use.
@@ -804,12 +1400,16 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Google Analytics Anonymize Users'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GAAnonymizeUsers bool `json:"gaAnonymizeUsers,omitempty"`
 
-	// Deprecated: Grafana defines the Grafana server options for ArgoCD.
+	// Deprecated: Grafana defines the Grafana server options for ArgoCD. Use Monitoring.Dashboards to publish the
+	// Argo CD dashboards to an existing Grafana instead.
 	Grafana ArgoCDGrafanaSpec `json:"grafana,omitempty"`
 
 	// HA options for High Availability support for the Redis component.
 	HA ArgoCDHASpec `json:"ha,omitempty"`
 
+	// Helm defines the Helm options of Argo CD.
+	Helm *ArgoCDHelmSpec `json:"helm,omitempty"`
+
 	// HelpChatURL is the URL for getting chat help, this will typically be your Slack channel for support.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Help Chat URL'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	HelpChatURL string `json:"helpChatURL,omitempty"`
@@ -839,6 +1439,13 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kustomize Build Options'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	KustomizeVersions []KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
 
+	// LocalUsers are the local users of Argo CD, in addition to the admin user.
+	LocalUsers []LocalUserSpec `json:"localUsers,omitempty"`
+
+	// ManagedNamespaces onboards the namespaces selected by labels as managed by this instance, in addition to the
+	// namespaces labelled with argocd.argoproj.io/managed-by. Managed namespaces are reported in `.status.managedNamespaces`.
+	ManagedNamespaces []ManagedNamespacesSpec `json:"managedNamespaces,omitempty"`
+
 	// OIDCConfig is the OIDC configuration as an alternative to dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	OIDCConfig string `json:"oidcConfig,omitempty"`
@@ -904,9 +1511,15 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Status Badge Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	StatusBadgeEnabled bool `json:"statusBadgeEnabled,omitempty"`
 
+	// Timeouts defines the reconciliation timeouts of Argo CD.
+	Timeouts *ArgoCDTimeoutsSpec `json:"timeouts,omitempty"`
+
 	// TLS defines the TLS options for ArgoCD.
 	TLS ArgoCDTLSSpec `json:"tls,omitempty"`
 
+	// UI defines the customizations of the Argo CD UI.
+	UI *ArgoCDUISpec `json:"ui,omitempty"`
+
 	// UsersAnonymousEnabled toggles anonymous user access.
 	// The anonymous users get default role permissions specified argocd-rbac-cm.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Anonymous Users Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -1009,8 +1622,44 @@ type ArgoCDStatus struct {
 
 	// Host is the hostname of the Ingress.
 	Host string `json:"host,omitempty"`
+
+	// DriftedResources lists the operator-managed resources whose live state differed from the desired state
+	// during the last reconciliation. Only populated when drift detection is enabled.
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
+
+	// LastAppliedSpecHash is the hash of the spec, without the drift detection options, whose resources were last
+	// reconciled. Drift detection uses it to tell the changes of the spec from drift.
+	LastAppliedSpecHash string `json:"lastAppliedSpecHash,omitempty"`
+
+	// ExtraConfigConflicts lists the ExtraConfig entries set for argocd-cm keys managed by the operator.
+	ExtraConfigConflicts []ExtraConfigConflict `json:"extraConfigConflicts,omitempty"`
+
+	// AdminPasswordLastRotation is the time of the last rotation of the admin password by the operator.
+	AdminPasswordLastRotation *metav1.Time `json:"adminPasswordLastRotation,omitempty"`
+
+	// ManagedNamespaces lists the namespaces managed by the ArgoCD and why.
+	ManagedNamespaces []ManagedNamespace `json:"managedNamespaces,omitempty"`
+
+	// Conditions describe the state of the ArgoCD.
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// ArgoCDConditionConflicted is set when the ArgoCD is not reconciled, as another ArgoCD of its namespace is
+	// managing the Argo CD resources of the namespace. Only the oldest ArgoCD of a namespace is reconciled.
+	ArgoCDConditionConflicted = "Conflicted"
+
+	// ArgoCDConditionRepoPluginsConfigMapConflicted is set when the repo server plugins are configured inline, but the
+	// argocd-cmp-cm ConfigMap of the namespace exists and is not owned by the ArgoCD. The ConfigMap is left untouched.
+	ArgoCDConditionRepoPluginsConfigMapConflicted = "RepoPluginsConfigMapConflicted"
+
+	// ArgoCDConditionAdminPasswordRotationFailed is set when the admin password is due for rotation, but no password
+	// can be generated with the rotation policy. The admin password is left unchanged.
+	ArgoCDConditionAdminPasswordRotationFailed = "AdminPasswordRotationFailed"
+)
+
 // Banner defines an additional banner message to be displayed in Argo CD UI
 // https://argo-cd.readthedocs.io/en/stable/operator-manual/custom-styles/#banners
 type Banner struct {
@@ -1020,6 +1669,15 @@ type Banner struct {
 	URL string `json:"url,omitempty"`
 }
 
+// ArgoCDTimeoutsSpec defines the reconciliation timeouts of Argo CD.
+type ArgoCDTimeoutsSpec struct {
+	// Reconciliation is the interval after which applications are refreshed, sets `timeout.reconciliation` in argocd-cm.
+	Reconciliation *metav1.Duration `json:"reconciliation,omitempty"`
+
+	// HardReconciliation is the interval after which applications are hard refreshed, sets `timeout.hard.reconciliation` in argocd-cm.
+	HardReconciliation *metav1.Duration `json:"hardReconciliation,omitempty"`
+}
+
 // ArgoCDTLSSpec defines the TLS options for ArgCD.
 type ArgoCDTLSSpec struct {
 	// CA defines the CA options.
@@ -1029,6 +1687,12 @@ type ArgoCDTLSSpec struct {
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
 }
 
+// ArgoCDUISpec defines the customizations of the Argo CD UI.
+type ArgoCDUISpec struct {
+	// CSSURL is the URL of a custom stylesheet loaded by the UI, sets `ui.cssurl` in argocd-cm.
+	CSSURL string `json:"cssURL,omitempty"`
+}
+
 type SSHHostsSpec struct {
 	// ExcludeDefaultHosts describes whether you would like to include the default
 	// list of SSH Known Hosts provided by ArgoCD.
@@ -1046,6 +1710,9 @@ type WebhookServerSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Application set webhook component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Ingress defines the desired state for an Ingress for the Application set webhook component.
 	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
 
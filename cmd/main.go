@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -34,10 +35,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
 	"github.com/argoproj-labs/argocd-operator/controllers/argocdexport"
+	"github.com/argoproj-labs/argocd-operator/controllers/tracing"
 
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
@@ -48,6 +52,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"go.uber.org/zap/zapcore"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -69,6 +74,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
 	utilruntime.Must(v1beta1.AddToScheme(scheme))
@@ -82,10 +88,27 @@ func printVersion() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == planCommand {
+		if err := runPlan(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == migrateCommand {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
 	var labelSelectorFlag string
+	var migrateStorageVersion bool
+	var tracingEndpoint string
 
 	var secureMetrics = false
 	var enableHTTP2 = false
@@ -93,6 +116,8 @@ func main() {
 	flag.StringVar(&metricsAddr, "metrics-bind-address", fmt.Sprintf(":%d", common.OperatorMetricsPort), "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&labelSelectorFlag, "label-selector", env.StringFromEnv(common.ArgoCDLabelSelectorKey, common.ArgoCDDefaultLabelSelector), "The label selector is used to map to a subset of ArgoCD instances to reconcile")
+	flag.BoolVar(&migrateStorageVersion, "migrate-storage-version", env.ParseBoolFromEnv(common.ArgoCDMigrateStorageVersionKey, false), "Migrate the ArgoCD instances to the storage version of the ArgoCD CRD at startup.")
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", env.StringFromEnv(common.ArgoCDTracingEndpointKey, ""), "The HTTP endpoint of the OTLP collector the traces of the reconciliations are exported to, such as http://otel-collector:4318. Tracing is disabled if empty.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -215,6 +240,18 @@ func main() {
 		}
 	}
 
+	// Setup Scheme for Gateway API routes if available.
+	if argocd.IsGatewayAPIAvailable() {
+		if err := gatewayv1.Install(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "")
+			os.Exit(1)
+		}
+		if err := gatewayv1alpha2.Install(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	// Set up the scheme for openshift config if available
 	if argocd.IsVersionAPIAvailable() {
 		if err := configv1.Install(mgr.GetScheme()); err != nil {
@@ -242,8 +279,24 @@ func main() {
 		setupLog.Info("Keycloak instance cannot be managed using OpenShift Template, as DeploymentConfig/Template API is not present")
 	}
 
+	argocdClient := mgr.GetClient()
+	if tracingEndpoint != "" {
+		shutdownTracing, err := tracing.Setup(context.Background(), tracingEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "failed to flush the traces")
+			}
+		}()
+		argocdClient = tracing.NewClient(argocdClient)
+		setupLog.Info("exporting traces", "endpoint", tracingEndpoint)
+	}
+
 	if err = (&argocd.ReconcileArgoCD{
-		Client:        mgr.GetClient(),
+		Client:        argocdClient,
 		Scheme:        mgr.GetScheme(),
 		LabelSelector: labelSelectorFlag,
 	}).SetupWithManager(mgr); err != nil {
@@ -272,6 +325,16 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	if migrateStorageVersion {
+		migration, err := newStorageMigration(mgr)
+		if err == nil {
+			err = mgr.Add(migration)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to set up the storage version migration")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
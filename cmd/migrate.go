@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/argoproj-labs/argocd-operator/controllers/storagemigration"
+)
+
+// migrateCommand is the name of the subcommand migrating the ArgoCD instances to the storage version.
+const migrateCommand = "migrate-storage-version"
+
+// serviceAccountNamespaceFile holds the namespace of the operator when running in a cluster.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// runMigrate migrates every ArgoCD instance to the storage version of the ArgoCD CRD, then exits.
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet(migrateCommand, flag.ExitOnError)
+	var namespace string
+	var pageSize int64
+	fs.StringVar(&namespace, "namespace", "", "Namespace of the ConfigMap recording the migration progress, defaults to the namespace of the operator.")
+	fs.Int64Var(&pageSize, "page-size", storagemigration.DefaultPageSize, "Number of ArgoCD instances migrated between two progress records.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stderr)))
+
+	namespace, err := getOperatorNamespace(namespace)
+	if err != nil {
+		return err
+	}
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	cl, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	m := &storagemigration.Migrator{Client: cl, Namespace: namespace, PageSize: pageSize}
+	return m.Run(context.Background())
+}
+
+// newStorageMigration returns a runnable migrating the ArgoCD instances to the storage version once the manager
+// started, and won the leader election if enabled.
+func newStorageMigration(mgr manager.Manager) (manager.Runnable, error) {
+	namespace, err := getOperatorNamespace("")
+	if err != nil {
+		return nil, err
+	}
+	// The migration reads the instances from the API server rather than from the cache of the manager.
+	cl, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return nil, err
+	}
+
+	m := &storagemigration.Migrator{Client: cl, Namespace: namespace}
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		// A failed migration doesn't stop the operator, it is resumed on the next start.
+		if err := m.Run(ctx); err != nil {
+			setupLog.Error(err, "failed to migrate the ArgoCD instances to the storage version")
+		}
+		return nil
+	}), nil
+}
+
+// getOperatorNamespace returns the given namespace if set, or the namespace the operator runs in.
+func getOperatorNamespace(namespace string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+	data, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", fmt.Errorf("the namespace of the operator can't be determined, it must be set with -namespace: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	v1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	v1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argocd"
+)
+
+// planCommand is the name of the subcommand rendering the resources of an ArgoCD instance.
+const planCommand = "plan"
+
+// runPlan renders every resource the operator would create for the ArgoCD read from the
+// given file and writes them to stdout as a multi document YAML stream.
+func runPlan(args []string) error {
+	fs := flag.NewFlagSet(planCommand, flag.ExitOnError)
+	var file, namespace, managedNamespaces string
+	var opts argocd.PlanOptions
+	fs.StringVar(&file, "f", "", "Path to the ArgoCD manifest to render, - reads from stdin.")
+	fs.StringVar(&namespace, "namespace", "argocd", "Namespace of the ArgoCD instance, if not set in the manifest.")
+	fs.StringVar(&managedNamespaces, "managed-namespaces", "", "Comma separated list of namespaces managed by the instance.")
+	fs.BoolVar(&opts.RouteAPIAvailable, "route-api", false, "Render the resources as if the OpenShift Route API was available.")
+	fs.BoolVar(&opts.GatewayAPIAvailable, "gateway-api", false, "Render the resources as if the Gateway API was available.")
+	fs.BoolVar(&opts.GRPCRouteAPIAvailable, "grpcroute-api", false, "Render the resources as if the experimental GRPCRoute resource of the Gateway API was available.")
+	fs.BoolVar(&opts.PrometheusAPIAvailable, "prometheus-api", false, "Render the resources as if the Prometheus API was available.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if file == "" {
+		return fmt.Errorf("the ArgoCD manifest to render must be given with -f")
+	}
+	if managedNamespaces != "" {
+		opts.ManagedNamespaces = strings.Split(managedNamespaces, ",")
+	}
+
+	// Reconciler logs go to stderr, keeping stdout for the rendered manifests.
+	ctrl.SetLogger(zap.New(zap.WriteTo(os.Stderr), zap.Level(zapcore.ErrorLevel)))
+
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+
+	cr, err := decodeArgoCD(data)
+	if err != nil {
+		return err
+	}
+	if cr.Namespace == "" {
+		cr.Namespace = namespace
+	}
+
+	objs, err := argocd.Plan(cr, opts)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "---\n%s", out)
+	}
+	return nil
+}
+
+// decodeArgoCD decodes a v1alpha1 or v1beta1 ArgoCD manifest into a v1beta1 ArgoCD.
+func decodeArgoCD(data []byte) (*v1beta1.ArgoCD, error) {
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ArgoCD manifest: %w", err)
+	}
+
+	switch cr := obj.(type) {
+	case *v1beta1.ArgoCD:
+		return cr, nil
+	case *v1alpha1.ArgoCD:
+		dst := &v1beta1.ArgoCD{}
+		if err := cr.ConvertTo(dst); err != nil {
+			return nil, err
+		}
+		return dst, nil
+	default:
+		return nil, fmt.Errorf("expected an ArgoCD manifest, got %T", obj)
+	}
+}
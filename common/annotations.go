@@ -9,6 +9,38 @@ const (
 	// namespace a specific object is associated with
 	AnnotationNamespace = "argocds.argoproj.io/namespace"
 
+	// AnnotationLocalUsers is the annotation on the argocd-secret Secret that lists the local users
+	// managed by the operator
+	AnnotationLocalUsers = "argocds.argoproj.io/local-users"
+
+	// AnnotationLocalUserTokenID is the annotation on the Secret of a local user that holds the ID
+	// of the API token generated by the operator
+	AnnotationLocalUserTokenID = "argocds.argoproj.io/token-id"
+
+	// AnnotationLocalUserTokenExpiresAt is the annotation on the Secret of a local user that holds the
+	// expiry time of the API token generated by the operator
+	AnnotationLocalUserTokenExpiresAt = "argocds.argoproj.io/token-expires-at"
+
+	// AnnotationServiceAnnotations is the annotation on services that lists the annotations set from
+	// the service options of the ArgoCD
+	AnnotationServiceAnnotations = "argocds.argoproj.io/service-annotations"
+
+	// AnnotationServiceLabels is the annotation on services that lists the labels set from the service
+	// options of the ArgoCD
+	AnnotationServiceLabels = "argocds.argoproj.io/service-labels"
+
+	// AnnotationNotificationsSecretKeys is the annotation on the notifications secret that lists the keys
+	// synced from the Secrets referenced by the typed notification services
+	AnnotationNotificationsSecretKeys = "argocds.argoproj.io/notifications-secret-keys"
+
+	// AnnotationNotificationsConfigHash is the annotation on the notifications configmap that holds the hash
+	// of the configuration last applied from the NotificationsConfiguration, used to detect manual edits
+	AnnotationNotificationsConfigHash = "argocds.argoproj.io/notifications-config-hash"
+
+	// AnnotationConversionData is the annotation on v1alpha1 ArgoCDs that holds the fields of the v1beta1 spec
+	// which can't be represented in v1alpha1, restored on the conversion back to v1beta1
+	AnnotationConversionData = "argocds.argoproj.io/conversion-data"
+
 	// AnnotationOpenShiftServiceCA is the annotation on services used to
 	// request a TLS certificate from OpenShift's Service CA for AutoTLS
 	AnnotationOpenShiftServiceCA = "service.beta.openshift.io/serving-cert-secret-name"
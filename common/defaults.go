@@ -48,6 +48,9 @@ const (
 	// ArgoCDNotificationsControllerComponent is the name of the Notifications controller control plane component
 	ArgoCDNotificationsControllerComponent = "argocd-notifications-controller"
 
+	// ArgoCDDefaultNotificationsConfigurationName is the name of the default NotificationsConfiguration of an instance
+	ArgoCDDefaultNotificationsConfigurationName = "default-notifications-configuration"
+
 	// ArgoCDApplicationSetControllerComponent is the name of the ApplictionSet controller control plane component
 	ArgoCDApplicationSetControllerComponent = "argocd-applicationset-controller"
 
@@ -72,6 +75,10 @@ const (
 	// ArgoCDDefaultArgoVersion is the Argo CD container image digest to use when version not specified.
 	ArgoCDDefaultArgoVersion = "sha256:68894064bc381c19ea951029510aa614bd26bf46c2ec65ea445c7d8d095a9417" // v2.12.3
 
+	// ArgoCDDefaultArgoVersionName is the Argo CD version of the ArgoCDDefaultArgoVersion digest, which must be
+	// updated along with it.
+	ArgoCDDefaultArgoVersionName = "v2.12.3"
+
 	// ArgoCDDefaultBackupKeyLength is the length of the generated default backup key.
 	ArgoCDDefaultBackupKeyLength = 32
 
@@ -183,6 +190,9 @@ const (
 	// ArgoCDDefaultRBACScopes is the default Argo CD RBAC scopes.
 	ArgoCDDefaultRBACScopes = "[groups]"
 
+	// ArgoCDDefaultGrafanaConfigPath is the default directory of the Grafana dashboards when not specified.
+	ArgoCDDefaultGrafanaConfigPath = "/var/lib/grafana"
+
 	// ArgoCDDefaultRedisConfigPath is the default Redis configuration directory when not specified.
 	ArgoCDDefaultRedisConfigPath = "/var/lib/redis"
 
@@ -213,6 +223,18 @@ const (
 	// ArgoCDDefaultRedisVersionHA is the Redis container image tag to use when not specified in HA mode.
 	ArgoCDDefaultRedisVersionHA = "sha256:8061ca607db2a0c80010aeb5fc9bed0253448bc68711eaa14253a392f6c48280" // 6.2.4-alpine
 
+	// ArgoCDDefaultRedisExporterImage is the Redis exporter container image to use when not specified.
+	ArgoCDDefaultRedisExporterImage = "quay.io/oliver006/redis_exporter"
+
+	// ArgoCDDefaultRedisExporterVersion is the Redis exporter container image tag to use when not specified.
+	ArgoCDDefaultRedisExporterVersion = "v1.58.0"
+
+	// ArgoCDDefaultRedisExporterPort is the default metrics listen port of the Redis exporter.
+	ArgoCDDefaultRedisExporterPort = 9121
+
+	// ArgoCDDefaultRepoCacheSize is the default size of the persistent cache volume of each repo server replica.
+	ArgoCDDefaultRepoCacheSize = "10Gi"
+
 	// ArgoCDDefaultRepoMetricsPort is the default listen port for the Argo CD repo server metrics.
 	ArgoCDDefaultRepoMetricsPort = 8084
 
@@ -25,6 +25,12 @@ const (
 	// ArgoCDKeyApplicationInstanceLabelKey is the configuration key for the application instance label.
 	ArgoCDKeyApplicationInstanceLabelKey = "application.instanceLabelKey"
 
+	// ArgoCDKeyAccountsPrefix is the prefix of the configuration keys for local users.
+	ArgoCDKeyAccountsPrefix = "accounts."
+
+	// ArgoCDKeyAPIToken is the key of the API token in the Secret of a local user.
+	ArgoCDKeyAPIToken = "apiToken"
+
 	// ArgoCDKeyAdminPassword is the admin password key for labels.
 	ArgoCDKeyAdminPassword = "admin.password"
 
@@ -46,6 +52,12 @@ const (
 	// ArgoCDKeyDexConfig is the key for dex configuration.
 	ArgoCDKeyDexConfig = "dex.config"
 
+	// ArgoCDKeyExecEnabled is the configuration key for enabling the web-based terminal.
+	ArgoCDKeyExecEnabled = "exec.enabled"
+
+	// ArgoCDKeyExecShells is the configuration key for the shells of the web-based terminal.
+	ArgoCDKeyExecShells = "exec.shells"
+
 	// ArgoCDKeyFailureDomainZone is the failure-domain zone key for labels.
 	ArgoCDKeyFailureDomainZone = "failure-domain.beta.kubernetes.io/zone"
 
@@ -55,6 +67,9 @@ const (
 	// ArgoCDKeyGAAnonymizeUsers is the configuration key for the Google Analytics user anonymization.
 	ArgoCDKeyGAAnonymizeUsers = "ga.anonymizeusers"
 
+	// ArgoCDKeyHelmValuesFileSchemes is the configuration key for the URL schemes allowed for Helm values files.
+	ArgoCDKeyHelmValuesFileSchemes = "helm.valuesFileSchemes"
+
 	// ArgoCDKeyHelpChatURL is the congifuration key for the help chat URL.
 	ArgoCDKeyHelpChatURL = "help.chatUrl"
 
@@ -79,6 +94,12 @@ const (
 	// ArgoCDKeyKustomizeBuildOptions is the configuration key for the kustomize build options.
 	ArgoCDKeyKustomizeBuildOptions = "kustomize.buildOptions"
 
+	// ArgoCDKeyGrafanaDashboard is the label of the ConfigMaps discovered by the Grafana dashboards sidecar.
+	ArgoCDKeyGrafanaDashboard = "grafana_dashboard"
+
+	// ArgoCDKeyGrafanaFolder is the annotation of the ConfigMaps holding the Grafana folder of their dashboards.
+	ArgoCDKeyGrafanaFolder = "grafana_folder"
+
 	// ArgoCDKeyMetrics is the resource metrics key for labels.
 	ArgoCDKeyMetrics = "metrics"
 
@@ -94,6 +115,9 @@ const (
 	// ArgoCDKeyManagedBy is the managed-by key for labels.
 	ArgoCDKeyManagedBy = "app.kubernetes.io/managed-by"
 
+	// ArgoCDKeyVersion is the resource version key for labels.
+	ArgoCDKeyVersion = "app.kubernetes.io/version"
+
 	// ArgoCDKeyStatefulSetPodName is the resource StatefulSet Pod Name key for labels.
 	ArgoCDKeyStatefulSetPodName = "statefulset.kubernetes.io/pod-name"
 
@@ -127,12 +151,18 @@ const (
 	// ArgoCDKeyRepositoryCredentials is the configuration key for repository.credentials.
 	ArgoCDKeyRepositoryCredentials = "repository.credentials"
 
+	// ArgoCDKeyServerRBACLogEnforceEnable is the configuration key for logging RBAC enforcement decisions.
+	ArgoCDKeyServerRBACLogEnforceEnable = "server.rbac.log.enforce.enable"
+
 	// ArgoCDKeyServerSecretKey is the server secret key property name for the Argo secret.
 	ArgoCDKeyServerSecretKey = "server.secretkey"
 
 	// ArgoCDKeyServerURL is the key for server url.
 	ArgoCDKeyServerURL = "url"
 
+	// ArgoCDKeyServerAdditionalURLs is the key for the additional urls the server is exposed under.
+	ArgoCDKeyServerAdditionalURLs = "additionalUrls"
+
 	// ArgoCDKeySSHKnownHosts is the resource ssh_known_hosts key for labels.
 	ArgoCDKeySSHKnownHosts = "ssh_known_hosts"
 
@@ -145,6 +175,15 @@ const (
 	// ArgoCDKeyBannerURL is the configuration key for a banner message URL.
 	ArgoCDKeyBannerURL = "ui.bannerurl"
 
+	// ArgoCDKeyUICSSURL is the configuration key for a custom UI stylesheet URL.
+	ArgoCDKeyUICSSURL = "ui.cssurl"
+
+	// ArgoCDKeyTimeoutReconciliation is the configuration key for the application reconciliation timeout.
+	ArgoCDKeyTimeoutReconciliation = "timeout.reconciliation"
+
+	// ArgoCDKeyTimeoutHardReconciliation is the configuration key for the application hard reconciliation timeout.
+	ArgoCDKeyTimeoutHardReconciliation = "timeout.hard.reconciliation"
+
 	// ArgoCDKeyTLSCACert is the key for TLS CA certificates.
 	ArgoCDKeyTLSCACert = "ca.crt"
 
@@ -197,9 +236,20 @@ const (
 	// ArgoCDSecretTypeLabel is needed for cluster secrets
 	ArgoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
 
+	// ArgoCDLocalUserLabel identifies the Secret holding the API token of a local user, its value is the user name.
+	ArgoCDLocalUserLabel = "argocd.argoproj.io/local-user"
+
+	// ArgoCDDisableReconcilerHooksAnnotation is the annotation of an ArgoCD listing the comma separated names of the
+	// reconciler hooks disabled for it.
+	ArgoCDDisableReconcilerHooksAnnotation = "argocd.argoproj.io/disable-reconciler-hooks"
+
 	// ArgoCDManagedByLabel is needed to identify namespace managed by an instance on ArgoCD
 	ArgoCDManagedByLabel = "argocd.argoproj.io/managed-by"
 
+	// ArgoCDManagedBySelectorAnnotation is set on the namespaces labelled with argocd.argoproj.io/managed-by by the
+	// operator, as they are selected by a managedNamespaces selector of an ArgoCD. Its value is the selector name.
+	ArgoCDManagedBySelectorAnnotation = "argocd.argoproj.io/managed-by-selector"
+
 	// ArgoCDManagedByClusterArgoCDLabel is needed to identify namespace mentioned as sourceNamespace on ArgoCD
 	ArgoCDManagedByClusterArgoCDLabel = "argocd.argoproj.io/managed-by-cluster-argocd"
 
@@ -217,4 +267,12 @@ const (
 
 	// Label Selector is an env variable for ArgoCD instance reconcilliation.
 	ArgoCDLabelSelectorKey = "ARGOCD_LABEL_SELECTOR"
+
+	// ArgoCDMigrateStorageVersionKey is an env variable enabling the migration of the ArgoCD instances to the
+	// storage version at startup.
+	ArgoCDMigrateStorageVersionKey = "ARGOCD_MIGRATE_STORAGE_VERSION"
+
+	// ArgoCDTracingEndpointKey is an env variable holding the HTTP endpoint of the OTLP collector the traces of the
+	// operator are exported to.
+	ArgoCDTracingEndpointKey = "ARGOCD_TRACING_ENDPOINT"
 )
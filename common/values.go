@@ -23,6 +23,9 @@ const (
 	// ArgoCDCASuffix is the name suffix for ArgoCD CA resources.
 	ArgoCDCASuffix = "ca"
 
+	// ArgoCDCMPConfigMapName is the name of the ConfigMap holding the plugin.yaml files of the repo server plugins.
+	ArgoCDCMPConfigMapName = "argocd-cmp-cm"
+
 	// ArgoCDConfigMapName is the upstream hard-coded ArgoCD ConfigMap name.
 	ArgoCDConfigMapName = "argocd-cm"
 
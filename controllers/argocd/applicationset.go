@@ -842,7 +842,10 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 		return nil
 	} else {
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			return nil // Service found, do nothing
+			if ensureServiceSpec(svc, cr.Spec.ApplicationSet.Service) {
+				return r.Client.Update(context.TODO(), svc)
+			}
+			return nil
 		}
 	}
 	svc.Spec.Ports = []corev1.ServicePort{
@@ -858,6 +861,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoproj.ArgoCD) er
 			TargetPort: intstr.FromInt(8080),
 		},
 	}
+	ensureServiceSpec(svc, cr.Spec.ApplicationSet.Service)
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr),
@@ -980,7 +984,7 @@ func (r *ReconcileArgoCD) setManagedApplicationSetSourceNamespaces(cr *argoproj.
 // reconcileSourceNamespaceRole creates/updates role
 func (r *ReconcileArgoCD) reconcileSourceNamespaceRole(role v1.Role, cr *argoproj.ArgoCD) error {
 
-	if err := applyReconcilerHook(cr, role, ""); err != nil {
+	if err := applyReconcilerHook(cr, &role, ""); err != nil {
 		return err
 	}
 
@@ -1017,7 +1021,7 @@ func (r *ReconcileArgoCD) reconcileSourceNamespaceRole(role v1.Role, cr *argopro
 // reconcileSourceNamespaceRole creates/updates rolebinding
 func (r *ReconcileArgoCD) reconcileSourceNamespaceRoleBinding(roleBinding v1.RoleBinding, cr *argoproj.ArgoCD) error {
 
-	if err := applyReconcilerHook(cr, roleBinding, ""); err != nil {
+	if err := applyReconcilerHook(cr, &roleBinding, ""); err != nil {
 		return err
 	}
 
@@ -24,9 +24,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -45,20 +47,34 @@ type ReconcileArgoCD struct {
 	client.Client
 	Scheme            *runtime.Scheme
 	ManagedNamespaces *corev1.NamespaceList
+	// Stores the managedNamespaces selections of the namespaces selected by labels, keyed by namespace
+	ManagedNamespaceSelections map[string]argoproj.ManagedNamespacesSpec
 	// Stores a list of ApplicationSourceNamespaces as keys
 	ManagedSourceNamespaces map[string]string
 	// Stores a list of ApplicationSetSourceNamespaces as keys
 	ManagedApplicationSetSourceNamespaces map[string]string
 	// Stores label selector used to reconcile a subset of ArgoCD
 	LabelSelector string
+	// Overrides the availability of the optional APIs discovered at operator startup, when set
+	apis *apiAvailability
+	// Stores the managedNamespaces selections of the reconciled instances, read by the namespace watch
+	managedNamespacesSelections managedNamespacesSelections
+}
+
+// apiAvailability defines the optional APIs available to a reconciler.
+type apiAvailability struct {
+	route      bool
+	gateway    bool
+	grpcRoute  bool
+	prometheus bool
 }
 
 var log = logr.Log.WithName("controller_argocd")
 
 // Map to keep track of running Argo CD instances using their namespaces as key and phase as value
 // This map will be used for the performance metrics purposes
-// Important note: This assumes that each instance only contains one Argo CD instance
-// as, having multiple Argo CD instances in the same namespace is considered an anti-pattern
+// Important note: Only one Argo CD instance is reconciled per namespace, the other instances of
+// a namespace are marked as conflicted and are not tracked
 var ActiveInstanceMap = make(map[string]string)
 
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles;clusterrolebindings,verbs=*
@@ -69,17 +85,20 @@ var ActiveInstanceMap = make(map[string]string)
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
+//+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=*
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=create;delete;get;list;patch;update;watch;
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;prometheusrules;servicemonitors,verbs=*
+//+kubebuilder:rbac:groups=grafana.integreatly.org,resources=grafanadashboards,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=*,verbs=*
 //+kubebuilder:rbac:groups="",resources=pods;pods/log,verbs=get
 //+kubebuilder:rbac:groups=template.openshift.io,resources=templates;templateinstances;templateconfigs,verbs=*
 //+kubebuilder:rbac:groups="oauth.openshift.io",resources=oauthclients,verbs=get;list;watch;create;delete;patch;update
-//+kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations;notificationsconfigurations/finalizers,verbs=*
+//+kubebuilder:rbac:groups=argoproj.io,resources=notificationsconfigurations;notificationsconfigurations/finalizers;notificationsconfigurations/status,verbs=*
 //+kubebuilder:rbac:groups="apiregistration.k8s.io",resources="apiservices",verbs=get;list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -90,7 +109,11 @@ var ActiveInstanceMap = make(map[string]string)
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.9.2/pkg/reconcile
-func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+
+	ctx, span := tracing.Start(tracing.WithInstance(ctx, request.Namespace, request.Name), "Reconcile")
+	defer func() { tracing.End(span, err) }()
+	defer r.pushClient(func(c client.Client) client.Client { return tracing.WithContext(ctx, c) })()
 
 	reconcileStartTS := time.Now()
 	defer func() {
@@ -101,12 +124,13 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 	reqLogger.Info("Reconciling ArgoCD")
 
 	argocd := &argoproj.ArgoCD{}
-	err := r.Client.Get(ctx, request.NamespacedName, argocd)
+	err = r.Client.Get(ctx, request.NamespacedName, argocd)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			r.forgetManagedNamespacesSelections(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -125,6 +149,25 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, fmt.Errorf("error: failed to reconcile ArgoCD instance: '%s'", request.NamespacedName)
 	}
 
+	// Only the oldest Argo CD instance of a namespace is reconciled, as the resources of an instance have fixed names
+	conflicted, err := r.reconcileConflictedCondition(argocd)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if conflicted {
+		if argocd.GetDeletionTimestamp() != nil {
+			// the resources of the namespace belong to the active instance and are not removed with this one
+			if argocd.IsDeletionFinalizerPresent() {
+				return reconcile.Result{}, r.removeDeletionFinalizer(argocd)
+			}
+			return reconcile.Result{}, nil
+		}
+		// the instance is reconciled again once the active instance is deleted
+		condition := meta.FindStatusCondition(argocd.Status.Conditions, argoproj.ArgoCDConditionConflicted)
+		reqLogger.Info(condition.Message)
+		return reconcile.Result{}, nil
+	}
+
 	newPhase := argocd.Status.Phase
 	// If we discover a new Argo CD instance in a previously un-seen namespace
 	// we add it to the map and increment active instance count by phase
@@ -150,6 +193,8 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 	ActiveInstanceReconciliationCount.WithLabelValues(argocd.Namespace).Inc()
 
 	if argocd.GetDeletionTimestamp() != nil {
+		// the namespaces selected by the instance are released below, further label changes are ignored
+		r.forgetManagedNamespacesSelections(request.NamespacedName)
 
 		// Argo CD instance marked for deletion; remove entry from activeInstances map and decrement active instance count
 		// by phase as well as total
@@ -158,12 +203,18 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		ActiveInstancesTotal.Dec()
 		ActiveInstanceReconciliationCount.DeleteLabelValues(argocd.Namespace)
 		ReconcileTime.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
+		DriftedResources.DeletePartialMatch(prometheus.Labels{"namespace": argocd.Namespace})
+		deleteInstanceMetrics(argocd)
 
 		if argocd.IsDeletionFinalizerPresent() {
 			if err := r.deleteClusterResources(argocd); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to delete ClusterResources: %w", err)
 			}
 
+			if err := r.releaseSelectedNamespaces(argocd); err != nil {
+				return reconcile.Result{}, fmt.Errorf("failed to release the namespaces selected by managedNamespaces, error: %w", err)
+			}
+
 			if isRemoveManagedByLabelOnArgoCDDeletion() {
 				if err := r.removeManagedByLabelFromNamespaces(argocd.Namespace); err != nil {
 					return reconcile.Result{}, fmt.Errorf("failed to remove label from namespace[%v], error: %w", argocd.Namespace, err)
@@ -212,18 +263,29 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err := r.reconcileResources(argocd); err != nil {
+	if err = runPhaseHooks(HookPhasePreReconcile, argocd, nil); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileResourcesDetectingDrift(ctx, argocd)
+	if hookErr := runPhaseHooks(HookPhasePostReconcile, argocd, err); err == nil {
+		err = hookErr
+	}
+	r.updateInstanceMetrics(argocd)
+	if err != nil {
 		// Error reconciling ArgoCD sub-resources - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	// Return and don't requeue
-	return reconcile.Result{}, nil
+	// Requeue to renew the API tokens of the local users and rotate the admin password on schedule
+	return reconcile.Result{RequeueAfter: earliestRequeue(r.getLocalUserTokenRenewal(argocd), r.getAdminPasswordRotation(argocd))}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
+	// the client of the reconciler is installed before the watches use it
+	r.stepClient()
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper)
+	r.setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretResourceMapper, r.applicationSetSCMTLSConfigMapMapper, r.conflictedInstanceMapper)
 	return bldr.Complete(r)
 }
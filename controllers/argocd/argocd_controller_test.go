@@ -24,9 +24,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,6 +42,7 @@ import (
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+	"github.com/argoproj-labs/argocd-operator/controllers/tracing"
 )
 
 var _ reconcile.Reconciler = &ReconcileArgoCD{}
@@ -114,6 +121,44 @@ func TestReconcileArgoCD_Reconcile(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_Tracing(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(tracing.NewClient(cl), sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(a)})
+	assert.NoError(t, err)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	children := map[trace.SpanID][]string{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+		children[span.Parent().SpanID()] = append(children[span.Parent().SpanID()], span.Name())
+		assert.Contains(t, span.Attributes(), attribute.String(string(tracing.AttributeInstanceName), a.Name), span.Name())
+	}
+
+	// the sub-reconcilers are children of the reconciliation, and the API calls of the sub-reconcilers
+	assert.Contains(t, spans, "Reconcile")
+	reconcileChildren := children[spans["Reconcile"].SpanContext().SpanID()]
+	assert.Contains(t, reconcileChildren, "Get ArgoCD")
+	assert.Contains(t, reconcileChildren, "reconcile roles")
+	assert.Contains(t, reconcileChildren, "reconcile deployments")
+	assert.Contains(t, reconcileChildren, "List NamespaceList")
+	assert.Contains(t, children[spans["reconcile deployments"].SpanContext().SpanID()], "Create Deployment")
+}
+
 func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	//ctx := context.Background()
@@ -123,10 +168,12 @@ func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	})
 	b := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
 		ac.Name = "argo-test-2"
+		ac.Namespace = "argo-test-2"
 		ac.Labels = map[string]string{"testfoo": "testbar"}
 	})
 	c := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
 		ac.Name = "argo-test-3"
+		ac.Namespace = "argo-test-3"
 	})
 
 	resObjs := []client.Object{a, b, c}
@@ -137,6 +184,8 @@ func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	rt := makeTestReconciler(cl, sch)
 
 	assert.NoError(t, createNamespace(rt, a.Namespace, ""))
+	assert.NoError(t, createNamespace(rt, b.Namespace, ""))
+	assert.NoError(t, createNamespace(rt, c.Namespace, ""))
 
 	// All ArgoCD instances should be reconciled if no label-selctor is applied to the operator.
 
@@ -222,6 +271,112 @@ func TestReconcileArgoCD_LabelSelector(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_conflicted(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	created := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	a := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Name = "argo-test-1"
+		ac.CreationTimestamp = created
+	})
+	b := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Name = "argo-test-2"
+		ac.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+	})
+	c := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Name = "argo-test-3"
+		ac.CreationTimestamp = metav1.NewTime(created.Add(2 * time.Minute))
+		ac.Finalizers = []string{common.ArgoCDDeletionFinalizer}
+	})
+
+	resObjs := []client.Object{a, b, c}
+	subresObjs := []client.Object{a, b, c}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	reconcileArgoCD := func(cr *argoproj.ArgoCD) error {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(cr)})
+		return err
+	}
+	getArgoCD := func(cr *argoproj.ArgoCD) (*argoproj.ArgoCD, error) {
+		argocd := &argoproj.ArgoCD{}
+		err := r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), argocd)
+		return argocd, err
+	}
+
+	// only the oldest instance of the namespace is reconciled
+	// a conflicted instance isn't requeued, it is reconciled again once the active instance is deleted
+	assert.NoError(t, reconcileArgoCD(b))
+	conflicted, err := getArgoCD(b)
+	assert.NoError(t, err)
+	condition := meta.FindStatusCondition(conflicted.Status.Conditions, argoproj.ArgoCDConditionConflicted)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "MultipleInstances", condition.Reason)
+	assert.False(t, conflicted.IsDeletionFinalizerPresent())
+
+	assert.NoError(t, reconcileArgoCD(a))
+	active, err := getArgoCD(a)
+	assert.NoError(t, err)
+	assert.Empty(t, active.Status.Conditions)
+	assert.True(t, active.IsDeletionFinalizerPresent())
+
+	// the deletion of a conflicted instance doesn't remove the resources of the active instance
+	assert.NoError(t, r.Client.Delete(context.TODO(), c))
+	assert.NoError(t, reconcileArgoCD(c))
+	_, err = getArgoCD(c)
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-server", Namespace: a.Namespace}, &appsv1.Deployment{}))
+
+	// the next instance is reconciled once the active instance is deleted
+	assert.NoError(t, r.Client.Delete(context.TODO(), active))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(b)}}, r.conflictedInstanceMapper(context.TODO(), active))
+	assert.NoError(t, reconcileArgoCD(a))
+	_, err = getArgoCD(a)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	assert.NoError(t, reconcileArgoCD(b))
+	active, err = getArgoCD(b)
+	assert.NoError(t, err)
+	assert.Empty(t, active.Status.Conditions)
+}
+
+func TestReconcileArgoCD_activeInstance(t *testing.T) {
+	created := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	deleted := metav1.Now()
+	argocds := []argoproj.ArgoCD{
+		*makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+			ac.Name = "deleted"
+			ac.CreationTimestamp = created
+			ac.DeletionTimestamp = &deleted
+		}),
+		*makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+			ac.Name = "other-operator"
+			ac.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+			ac.Labels = map[string]string{"operator": "other"}
+		}),
+		*makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+			ac.Name = "selected"
+			ac.CreationTimestamp = metav1.NewTime(created.Add(2 * time.Minute))
+			ac.Labels = map[string]string{"operator": "this"}
+		}),
+	}
+	r := &ReconcileArgoCD{}
+
+	// the ArgoCDs being deleted are ignored, except the reconciled one
+	assert.Equal(t, "other-operator", r.activeInstance(argocds, "").Name)
+	assert.Equal(t, "deleted", r.activeInstance(argocds, "deleted").Name)
+
+	// the ArgoCDs not matching the label selector are ignored
+	r.LabelSelector = "operator=this"
+	assert.Equal(t, "selected", r.activeInstance(argocds, "").Name)
+	r.LabelSelector = "operator=none"
+	assert.Nil(t, r.activeInstance(argocds, ""))
+}
+
 func TestReconcileArgoCD_Reconcile_RemoveManagedByLabelOnArgocdDeletion(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
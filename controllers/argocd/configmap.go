@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -115,6 +117,112 @@ func getKustomizeBuildOptions(cr *argoproj.ArgoCD) string {
 	return kbo
 }
 
+// extraConfigDeniedKeys are the operator-managed argocd-cm keys that ExtraConfig may not override,
+// as they are set from the typed settings of the ArgoCD spec. Every other operator-managed key may be
+// overridden through ExtraConfig for backward compatibility. Keys ending with a dot match as a prefix.
+var extraConfigDeniedKeys = []string{
+	common.ArgoCDKeyAccountsPrefix,
+	common.ArgoCDKeyExecEnabled,
+	common.ArgoCDKeyExecShells,
+	common.ArgoCDKeyHelmValuesFileSchemes,
+	common.ArgoCDKeyKustomizeBuildOptions + ".",
+	common.ArgoCDKeyServerRBACLogEnforceEnable,
+	common.ArgoCDKeyTimeoutHardReconciliation,
+	common.ArgoCDKeyTimeoutReconciliation,
+	common.ArgoCDKeyUICSSURL,
+}
+
+// getArgoConfigSettings will return the argocd-cm keys set from the typed settings of the given ArgoCD.
+func getArgoConfigSettings(cr *argoproj.ArgoCD) map[string]string {
+	settings := make(map[string]string)
+
+	if t := cr.Spec.Timeouts; t != nil {
+		if t.Reconciliation != nil {
+			settings[common.ArgoCDKeyTimeoutReconciliation] = t.Reconciliation.Duration.String()
+		}
+		if t.HardReconciliation != nil {
+			settings[common.ArgoCDKeyTimeoutHardReconciliation] = t.HardReconciliation.Duration.String()
+		}
+	}
+
+	if e := cr.Spec.Exec; e != nil {
+		settings[common.ArgoCDKeyExecEnabled] = fmt.Sprint(e.Enabled)
+		if len(e.Shells) > 0 {
+			shells := make([]string, 0, len(e.Shells))
+			for _, s := range e.Shells {
+				shells = append(shells, string(s))
+			}
+			settings[common.ArgoCDKeyExecShells] = strings.Join(shells, ",")
+		}
+	}
+
+	if cr.Spec.RBAC.LogEnforceEnable != nil {
+		settings[common.ArgoCDKeyServerRBACLogEnforceEnable] = fmt.Sprint(*cr.Spec.RBAC.LogEnforceEnable)
+	}
+
+	if cr.Spec.UI != nil && cr.Spec.UI.CSSURL != "" {
+		settings[common.ArgoCDKeyUICSSURL] = cr.Spec.UI.CSSURL
+	}
+
+	if cr.Spec.Helm != nil && len(cr.Spec.Helm.ValuesFileSchemes) > 0 {
+		settings[common.ArgoCDKeyHelmValuesFileSchemes] = strings.Join(cr.Spec.Helm.ValuesFileSchemes, ",")
+	}
+
+	for _, u := range cr.Spec.LocalUsers {
+		capabilities := make([]string, 0, len(u.GetCapabilities()))
+		for _, c := range u.GetCapabilities() {
+			capabilities = append(capabilities, string(c))
+		}
+		settings[common.ArgoCDKeyAccountsPrefix+u.Name] = strings.Join(capabilities, ",")
+		settings[localUserKey(u.Name, "enabled")] = fmt.Sprint(u.IsEnabled())
+	}
+
+	for _, kv := range cr.Spec.KustomizeVersions {
+		if kv.BuildOptions != "" {
+			settings[common.ArgoCDKeyKustomizeBuildOptions+"."+kv.Version] = kv.BuildOptions
+		}
+	}
+	return settings
+}
+
+// isExtraConfigKeyDenied returns true if the given argocd-cm key may not be overridden through ExtraConfig.
+func isExtraConfigKeyDenied(key string) bool {
+	for _, k := range extraConfigDeniedKeys {
+		if key == k || (strings.HasSuffix(k, ".") && strings.HasPrefix(key, k)) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyExtraConfig sets the ExtraConfig entries of the given ArgoCD in data and returns the entries
+// conflicting with a value managed by the operator. Entries for denied keys are ignored when they conflict.
+func applyExtraConfig(cr *argoproj.ArgoCD, data map[string]string) []argoproj.ExtraConfigConflict {
+	keys := make([]string, 0, len(cr.Spec.ExtraConfig))
+	for k := range cr.Spec.ExtraConfig {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var conflicts []argoproj.ExtraConfigConflict
+	for _, k := range keys {
+		v := cr.Spec.ExtraConfig[k]
+		if managed := data[k]; managed == "" || managed == v {
+			data[k] = v
+			continue
+		}
+
+		denied := isExtraConfigKeyDenied(k)
+		if denied {
+			log.Info(fmt.Sprintf("ignoring extraConfig key %s, it is managed through the typed settings of the ArgoCD", k))
+		} else {
+			data[k] = v
+		}
+		conflicts = append(conflicts, argoproj.ExtraConfigConflict{Key: k, Ignored: denied})
+	}
+	return conflicts
+}
+
 // getOIDCConfig will return the OIDC configuration for the given ArgoCD.
 func getOIDCConfig(cr *argoproj.ArgoCD) string {
 	config := common.ArgoCDDefaultOIDCConfig
@@ -338,6 +446,10 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoproj.ArgoCD, useTLSForRedi
 		return err
 	}
 
+	if err := r.reconcileRepoPluginsConfigMap(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
@@ -367,6 +479,10 @@ func (r *ReconcileArgoCD) reconcileCAConfigMap(cr *argoproj.ArgoCD) error {
 
 // reconcileConfiguration will ensure that the main ConfigMap for ArgoCD is present.
 func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
+	if err := validateArgoServerHosts(cr); err != nil {
+		return err
+	}
+
 	cm := newConfigMapWithName(common.ArgoCDConfigMapName, cr)
 
 	cm.Data = make(map[string]string)
@@ -415,6 +531,13 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 	cm.Data[common.ArgoCDKeyRepositoryCredentials] = getRepositoryCredentials(cr)
 	cm.Data[common.ArgoCDKeyStatusBadgeEnabled] = fmt.Sprint(cr.Spec.StatusBadgeEnabled)
 	cm.Data[common.ArgoCDKeyServerURL] = r.getArgoServerURI(cr)
+	if urls := r.getArgoServerAdditionalURLs(cr); len(urls) > 0 {
+		bytes, err := yaml.Marshal(urls)
+		if err != nil {
+			return err
+		}
+		cm.Data[common.ArgoCDKeyServerAdditionalURLs] = string(bytes)
+	}
 	cm.Data[common.ArgoCDKeyUsersAnonymousEnabled] = fmt.Sprint(cr.Spec.UsersAnonymousEnabled)
 
 	// create dex config if dex is enabled through `.spec.sso`
@@ -441,10 +564,12 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoproj.ArgoCD) error {
 		}
 	}
 
-	if len(cr.Spec.ExtraConfig) > 0 {
-		for k, v := range cr.Spec.ExtraConfig {
-			cm.Data[k] = v
-		}
+	for k, v := range getArgoConfigSettings(cr) {
+		cm.Data[k] = v
+	}
+
+	if err := r.reconcileStatusExtraConfigConflicts(cr, applyExtraConfig(cr, cm.Data)); err != nil {
+		return err
 	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -235,6 +236,41 @@ func TestReconcileArgoCD_reconcileArgoConfigMap(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_additionalURLs(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.internal"
+		a.Spec.Server.AdditionalHosts = []string{"argocd.example.com", "argocd.example.org"}
+		a.Spec.Server.CanonicalHost = "argocd.example.com"
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "https://argocd.example.com", cm.Data[common.ArgoCDKeyServerURL])
+	assert.Equal(t, "- https://argocd.internal\n- https://argocd.example.org\n", cm.Data[common.ArgoCDKeyServerAdditionalURLs])
+
+	// the additional urls are removed with the additional hosts
+	a.Spec.Server.AdditionalHosts = nil
+	a.Spec.Server.CanonicalHost = ""
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "https://argocd.internal", cm.Data[common.ArgoCDKeyServerURL])
+	assert.NotContains(t, cm.Data, common.ArgoCDKeyServerAdditionalURLs)
+
+	// an unknown canonical host is rejected
+	a.Spec.Server.CanonicalHost = "argocd.example.com"
+	assert.Error(t, r.reconcileArgoConfigMap(a))
+}
+
 func TestReconcileArgoCD_reconcileEmptyArgoConfigMap(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -1012,6 +1048,111 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfig(t *testing.T) {
 
 }
 
+func TestReconcileArgoCD_reconcileArgoConfigMap_withTypedSettings(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Timeouts = &argoproj.ArgoCDTimeoutsSpec{
+			Reconciliation:     &metav1.Duration{Duration: 5 * time.Minute},
+			HardReconciliation: &metav1.Duration{Duration: time.Hour},
+		}
+		a.Spec.Exec = &argoproj.ArgoCDExecSpec{
+			Enabled: true,
+			Shells:  []argoproj.ExecShell{"bash", "sh"},
+		}
+		a.Spec.RBAC.LogEnforceEnable = boolPtr(true)
+		a.Spec.UI = &argoproj.ArgoCDUISpec{CSSURL: "./custom/style.css"}
+		a.Spec.Helm = &argoproj.ArgoCDHelmSpec{ValuesFileSchemes: []string{"https", "s3"}}
+		a.Spec.KustomizeVersions = []argoproj.KustomizeVersionSpec{{
+			Version:      "v4.1.0",
+			Path:         "/path/to/kustomize-4.1",
+			BuildOptions: "--enable-helm",
+		}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+
+	want := map[string]string{
+		"timeout.reconciliation":              "5m0s",
+		"timeout.hard.reconciliation":         "1h0m0s",
+		"exec.enabled":                        "true",
+		"exec.shells":                         "bash,sh",
+		"server.rbac.log.enforce.enable":      "true",
+		"ui.cssurl":                           "./custom/style.css",
+		"helm.valuesFileSchemes":              "https,s3",
+		"kustomize.buildOptions.v4.1.0":       "--enable-helm",
+		"kustomize.version.v4.1.0":            "/path/to/kustomize-4.1",
+		common.ArgoCDKeyKustomizeBuildOptions: common.ArgoCDDefaultKustomizeBuildOptions,
+	}
+	for k, v := range want {
+		assert.Equal(t, v, cm.Data[k], k)
+	}
+	assert.Empty(t, a.Status.ExtraConfigConflicts)
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withExtraConfigConflicts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.DisableAdmin = true
+		a.Spec.Exec = &argoproj.ArgoCDExecSpec{Enabled: true}
+		a.Spec.ExtraConfig = map[string]string{
+			"admin.enabled":     "true",
+			"exec.enabled":      "false",
+			"exec.shells":       "bash",
+			"ga.trackingid":     "UA-1234",
+			"ui.cssurl":         "./custom/style.css",
+			"users.session.ttl": "12h",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+
+	// ExtraConfig overrides the keys predating the typed settings, but not the typed settings
+	assert.Equal(t, "true", cm.Data["admin.enabled"])
+	assert.Equal(t, "true", cm.Data["exec.enabled"])
+
+	// keys not managed by the operator are set from ExtraConfig without conflict
+	assert.Equal(t, "bash", cm.Data["exec.shells"])
+	assert.Equal(t, "UA-1234", cm.Data["ga.trackingid"])
+	assert.Equal(t, "./custom/style.css", cm.Data["ui.cssurl"])
+	assert.Equal(t, "12h", cm.Data["users.session.ttl"])
+
+	want := []argoproj.ExtraConfigConflict{
+		{Key: "admin.enabled"},
+		{Key: "exec.enabled", Ignored: true},
+	}
+	assert.Equal(t, want, a.Status.ExtraConfigConflicts)
+
+	// the conflicts are persisted in the status
+	live := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, live))
+	assert.Equal(t, want, live.Status.ExtraConfigConflicts)
+
+	// conflicts are cleared once resolved
+	a.Spec.ExtraConfig = nil
+	assert.NoError(t, r.reconcileArgoConfigMap(a))
+	assert.Empty(t, a.Status.ExtraConfigConflicts)
+}
+
 func Test_reconcileRBAC(t *testing.T) {
 	a := makeTestArgoCD()
 
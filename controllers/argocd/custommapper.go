@@ -78,11 +78,11 @@ func (r *ReconcileArgoCD) isUserManagedSecret(ctx context.Context, o client.Obje
 	if err != nil {
 		return namespacedName, false
 	}
-	// Return false if no ArgoCD instance or more than one is detected in the namespace.
-	if len(argocds.Items) != 1 {
+	// Return false if no ArgoCD instance is detected in the namespace.
+	argocd := r.activeInstance(argocds.Items, "")
+	if argocd == nil {
 		return namespacedName, false
 	}
-	argocd := argocds.Items[0]
 	namespacedName.Name = argocd.Name
 	namespacedName.Namespace = argocd.Namespace
 
@@ -93,6 +93,12 @@ func (r *ReconcileArgoCD) isUserManagedSecret(ctx context.Context, o client.Obje
 		ok = true
 	} else if argocd.Spec.ApplicationSet != nil && argocd.Spec.ApplicationSet.WebhookServer.Route.UseExternalCertificate() && argocd.Spec.ApplicationSet.WebhookServer.Route.TLS.ExternalCertificate.Name == o.GetName() {
 		ok = true
+	} else {
+		for _, u := range argocd.Spec.LocalUsers {
+			if u.PasswordSecret != nil && u.PasswordSecret.Name == o.GetName() {
+				ok = true
+			}
+		}
 	}
 
 	return namespacedName, ok
@@ -177,10 +183,10 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(ctx context.Context, o client.
 		if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: v}); err != nil {
 			return result
 		}
-		if len(argocds.Items) != 1 {
+		argocd := r.activeInstance(argocds.Items, "")
+		if argocd == nil {
 			return result
 		}
-		argocd := argocds.Items[0]
 		namespacedName := client.ObjectKey{
 			Name:      argocd.Name,
 			Namespace: argocd.Namespace,
@@ -191,13 +197,14 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(ctx context.Context, o client.
 	} else {
 		// If the namespace does not have the expected managed-by label,
 		// iterate through each ArgoCD instance to identify if the observed namespace
-		// matches any configured sourceNamespace pattern. If a match is found,
-		// generate a reconcile request for the instances.
+		// matches any configured sourceNamespace pattern or managedNamespaces selector.
+		// If a match is found, generate a reconcile request for the instances.
 		if err := r.Client.List(ctx, argocds, &client.ListOptions{}); err != nil {
 			return result
 		}
 		for _, argocd := range argocds.Items {
-			if glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) {
+			_, selected, _ := matchManagedNamespaces(argocd.Spec.ManagedNamespaces, labels)
+			if selected || glob.MatchStringInList(argocd.Spec.SourceNamespaces, namespaceName, glob.GLOB) {
 				namespacedName := client.ObjectKey{
 					Name:      argocd.Name,
 					Namespace: argocd.Namespace,
@@ -222,11 +229,10 @@ func (r *ReconcileArgoCD) clusterSecretResourceMapper(ctx context.Context, o cli
 			return result
 		}
 
-		if len(argocds.Items) != 1 {
+		argocd := r.activeInstance(argocds.Items, "")
+		if argocd == nil {
 			return result
 		}
-
-		argocd := argocds.Items[0]
 		namespacedName := client.ObjectKey{
 			Name:      argocd.Name,
 			Namespace: argocd.Namespace,
@@ -250,11 +256,10 @@ func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Contex
 			return result
 		}
 
-		if len(argocds.Items) != 1 {
+		argocd := r.activeInstance(argocds.Items, "")
+		if argocd == nil {
 			return result
 		}
-
-		argocd := argocds.Items[0]
 		namespacedName := client.ObjectKey{
 			Name:      argocd.Name,
 			Namespace: argocd.Namespace,
@@ -266,3 +271,22 @@ func (r *ReconcileArgoCD) applicationSetSCMTLSConfigMapMapper(ctx context.Contex
 
 	return result
 }
+
+// conflictedInstanceMapper maps the deletion of an ArgoCD to the ArgoCD taking over its namespace, which was
+// conflicted until then.
+func (r *ReconcileArgoCD) conflictedInstanceMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		return result
+	}
+	argocd := r.activeInstance(argocds.Items, "")
+	if argocd == nil || argocd.Name == o.GetName() {
+		return result
+	}
+	result = []reconcile.Request{
+		{NamespacedName: client.ObjectKeyFromObject(argocd)},
+	}
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -632,6 +633,35 @@ func TestReconcileArgoCD_namespaceResourceMapperWithManagedByLabel(t *testing.T)
 	}
 }
 
+func TestReconcileArgoCD_namespaceResourceMapperWithConflictedInstances(t *testing.T) {
+	created := metav1.Now()
+	a := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Name = "argo-test-2"
+		ac.CreationTimestamp = created
+	})
+	b := makeTestArgoCD(func(ac *argoproj.ArgoCD) {
+		ac.Name = "argo-test-1"
+		ac.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+	})
+
+	resObjs := []client.Object{a, b}
+	subresObjs := []client.Object{a, b}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the events are mapped to the oldest instance of the namespace, the only one reconciled
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "testNamespace",
+			Labels: map[string]string{common.ArgoCDManagedByLabel: a.Namespace},
+		},
+	}
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}}
+	assert.Equal(t, want, r.namespaceResourceMapper(context.TODO(), ns))
+}
+
 func TestReconcileArgoCD_namespaceResourceMapperForSpecificNamespaceWithoutManagedByLabel(t *testing.T) {
 	argocd1 := makeTestArgoCD()
 	resObjs := []client.Object{argocd1}
@@ -0,0 +1,343 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// grafanaDashboardGVK is the GroupVersionKind of the GrafanaDashboard resources of the grafana-operator.
+var grafanaDashboardGVK = schema.GroupVersionKind{Group: "grafana.integreatly.org", Version: "v1beta1", Kind: "GrafanaDashboard"}
+
+var grafanaDashboardAPIFound = false
+
+// IsGrafanaDashboardAPIAvailable returns true if the GrafanaDashboard API of the grafana-operator is present.
+func IsGrafanaDashboardAPIAvailable() bool {
+	return grafanaDashboardAPIFound
+}
+
+// verifyGrafanaDashboardAPI will verify that the GrafanaDashboard API of the grafana-operator is present.
+func verifyGrafanaDashboardAPI() error {
+	found, err := argoutil.VerifyAPI(grafanaDashboardGVK.Group, grafanaDashboardGVK.Version)
+	if err != nil {
+		return err
+	}
+	grafanaDashboardAPIFound = found
+	return nil
+}
+
+// getGrafanaConfigPath will return the path of the directory holding the Grafana dashboards.
+func getGrafanaConfigPath() string {
+	path := os.Getenv("GRAFANA_CONFIG_PATH")
+	if len(path) > 0 {
+		return path
+	}
+	return common.ArgoCDDefaultGrafanaConfigPath
+}
+
+// getGrafanaDashboardFiles will return the file names of the Grafana dashboards, in order.
+func getGrafanaDashboardFiles() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(getGrafanaConfigPath(), "dashboards", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		files = append(files, filepath.Base(path))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// getArgoVersionName will return the Argo CD version of the given instance, or an empty string when it is deployed
+// by image digest.
+func getArgoVersionName(cr *argoproj.ArgoCD) string {
+	if cr.Spec.Version == "" {
+		return common.ArgoCDDefaultArgoVersionName
+	}
+	if strings.Contains(cr.Spec.Version, ":") {
+		return ""
+	}
+	return cr.Spec.Version
+}
+
+// getGrafanaDashboards will load the Grafana dashboards of the given instance, by file name. The dashboards are
+// tagged with the Argo CD version of the instance and, when scoped to its namespace, bound to it.
+func getGrafanaDashboards(cr *argoproj.ArgoCD) (map[string]string, error) {
+	files, err := getGrafanaDashboardFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	dashboards := make(map[string]string, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(getGrafanaConfigPath(), "dashboards", file))
+		if err != nil {
+			return nil, err
+		}
+		dashboard := map[string]interface{}{}
+		if err := json.Unmarshal(data, &dashboard); err != nil {
+			return nil, fmt.Errorf("failed to parse the Grafana dashboard %s: %w", file, err)
+		}
+		customizeGrafanaDashboard(cr, dashboard)
+		data, err = json.Marshal(dashboard)
+		if err != nil {
+			return nil, err
+		}
+		dashboards[file] = string(data)
+	}
+	return dashboards, nil
+}
+
+// customizeGrafanaDashboard tags the given dashboard with the Argo CD version of the given instance and, when the
+// dashboards are scoped to the namespace of the instance, replaces the namespace variable of the dashboard with the
+// namespace. A scoped dashboard is given a unique uid and title, so that the dashboards of several instances may be
+// imported in the same Grafana.
+func customizeGrafanaDashboard(cr *argoproj.ArgoCD, dashboard map[string]interface{}) {
+	if version := getArgoVersionName(cr); version != "" {
+		tags, _ := dashboard["tags"].([]interface{})
+		dashboard["tags"] = append(tags, "argocd-"+version)
+	}
+
+	if !cr.Spec.Monitoring.Dashboards.ScopeToNamespace {
+		return
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cr.Namespace))
+	dashboard["uid"] = fmt.Sprintf("%v-%08x", dashboard["uid"], h.Sum32())
+	dashboard["title"] = fmt.Sprintf("%v (%s)", dashboard["title"], cr.Namespace)
+
+	templating, _ := dashboard["templating"].(map[string]interface{})
+	variables, _ := templating["list"].([]interface{})
+	for i, v := range variables {
+		if variable, ok := v.(map[string]interface{}); ok && variable["name"] == "namespace" {
+			variables[i] = map[string]interface{}{
+				"name":    "namespace",
+				"type":    "constant",
+				"query":   cr.Namespace,
+				"hide":    2,
+				"current": map[string]interface{}{"text": cr.Namespace, "value": cr.Namespace},
+			}
+		}
+	}
+}
+
+// getGrafanaDashboardLabels will return the labels of the published Grafana dashboards of the given instance.
+func getGrafanaDashboardLabels(name string, cr *argoproj.ArgoCD) map[string]string {
+	labels := argoutil.LabelsForCluster(cr)
+	for key, value := range cr.Spec.Monitoring.Dashboards.Labels {
+		labels[key] = value
+	}
+	labels[common.ArgoCDKeyName] = name
+	labels[common.ArgoCDKeyGrafanaDashboard] = "1"
+	if version := getArgoVersionName(cr); version != "" && len(validation.IsValidLabelValue(version)) == 0 {
+		labels[common.ArgoCDKeyVersion] = version
+	}
+	return labels
+}
+
+// reconcileDashboards will ensure that the Grafana dashboards of the given instance are published when enabled, and
+// removed otherwise.
+func (r *ReconcileArgoCD) reconcileDashboards(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileDashboardsConfigMap(cr); err != nil {
+		return err
+	}
+	if IsGrafanaDashboardAPIAvailable() {
+		return r.reconcileGrafanaOperatorDashboards(cr)
+	}
+	return nil
+}
+
+// reconcileDashboardsConfigMap will ensure that the ConfigMap holding the Grafana dashboards, discovered by the Grafana
+// dashboards sidecar, is present when the dashboards are enabled.
+func (r *ReconcileArgoCD) reconcileDashboardsConfigMap(cr *argoproj.ArgoCD) error {
+	cm := newConfigMapWithName(nameWithSuffix("grafana-dashboards", cr), cr)
+	exists := argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm)
+	if !cr.Spec.Monitoring.Dashboards.Enabled {
+		if exists {
+			// ConfigMap exists but dashboards have been disabled, delete the ConfigMap
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		return nil
+	}
+
+	dashboards, err := getGrafanaDashboards(cr)
+	if err != nil {
+		return err
+	}
+	labels := getGrafanaDashboardLabels(cm.Name, cr)
+	annotations := map[string]string{}
+	if folder := cr.Spec.Monitoring.Dashboards.Folder; folder != "" {
+		annotations[common.ArgoCDKeyGrafanaFolder] = folder
+	}
+
+	if exists {
+		changed := false
+		if !reflect.DeepEqual(cm.Data, dashboards) {
+			cm.Data = dashboards
+			changed = true
+		}
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		for key, value := range labels {
+			if cm.Labels[key] != value {
+				cm.Labels[key] = value
+				changed = true
+			}
+		}
+		if cm.Annotations[common.ArgoCDKeyGrafanaFolder] != annotations[common.ArgoCDKeyGrafanaFolder] {
+			if cm.Annotations == nil {
+				cm.Annotations = map[string]string{}
+			}
+			cm.Annotations[common.ArgoCDKeyGrafanaFolder] = annotations[common.ArgoCDKeyGrafanaFolder]
+			if annotations[common.ArgoCDKeyGrafanaFolder] == "" {
+				delete(cm.Annotations, common.ArgoCDKeyGrafanaFolder)
+			}
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil
+	}
+
+	cm.Labels = labels
+	if len(annotations) > 0 {
+		cm.Annotations = annotations
+	}
+	cm.Data = dashboards
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), cm)
+}
+
+// getGrafanaOperatorDashboardName will return the name of the GrafanaDashboard of the given dashboard file.
+func getGrafanaOperatorDashboardName(file string, cr *argoproj.ArgoCD) string {
+	return nameWithSuffix(strings.TrimPrefix(strings.TrimSuffix(file, ".json"), "argocd-")+"-dashboard", cr)
+}
+
+// reconcileGrafanaOperatorDashboards will ensure that a GrafanaDashboard of the grafana-operator is present for each
+// Grafana dashboard when enabled, and that none is present otherwise.
+func (r *ReconcileArgoCD) reconcileGrafanaOperatorDashboards(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Monitoring.Dashboards
+	enabled := spec.Enabled && spec.GrafanaOperator.Enabled
+
+	files, err := getGrafanaDashboardFiles()
+	if err != nil {
+		return err
+	}
+	var dashboards map[string]string
+	if enabled {
+		if dashboards, err = getGrafanaDashboards(cr); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		name := getGrafanaOperatorDashboardName(file, cr)
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(grafanaDashboardGVK)
+		if err := argoutil.FetchObject(r.Client, cr.Namespace, name, existing); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			existing = nil
+		}
+
+		if !enabled {
+			if existing != nil {
+				// GrafanaDashboard exists but should not, delete the GrafanaDashboard
+				if err := r.Client.Delete(context.TODO(), existing); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		desiredSpec := map[string]interface{}{
+			"json":                      dashboards[file],
+			"allowCrossNamespaceImport": spec.GrafanaOperator.AllowCrossNamespaceImport,
+			"instanceSelector":          map[string]interface{}{},
+		}
+		if spec.GrafanaOperator.InstanceSelector != nil {
+			selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec.GrafanaOperator.InstanceSelector)
+			if err != nil {
+				return err
+			}
+			desiredSpec["instanceSelector"] = selector
+		}
+		if spec.Folder != "" {
+			desiredSpec["folder"] = spec.Folder
+		}
+		labels := getGrafanaDashboardLabels(name, cr)
+
+		if existing != nil {
+			existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+			if reflect.DeepEqual(existingSpec, desiredSpec) && reflect.DeepEqual(existing.GetLabels(), labels) {
+				continue
+			}
+			existing.SetLabels(labels)
+			existing.Object["spec"] = desiredSpec
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+			continue
+		}
+
+		dashboard := &unstructured.Unstructured{}
+		dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+		dashboard.SetName(name)
+		dashboard.SetNamespace(cr.Namespace)
+		dashboard.SetLabels(labels)
+		dashboard.Object["spec"] = desiredSpec
+		if err := controllerutil.SetControllerReference(cr, dashboard, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), dashboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grafanaDashboardObject returns an empty GrafanaDashboard of the grafana-operator, to watch the GrafanaDashboards
+// owned by the ArgoCD instances.
+func grafanaDashboardObject() *unstructured.Unstructured {
+	dashboard := &unstructured.Unstructured{}
+	dashboard.SetGroupVersionKind(grafanaDashboardGVK)
+	return dashboard
+}
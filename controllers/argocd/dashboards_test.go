@@ -0,0 +1,149 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileDashboardsConfigMap(t *testing.T) {
+	t.Setenv("GRAFANA_CONFIG_PATH", "../../build/grafana")
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: a.Name + "-grafana-dashboards", Namespace: a.Namespace}
+
+	// dashboards are not published by default
+	assert.NoError(t, r.reconcileDashboards(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, &corev1.ConfigMap{})))
+
+	a.Spec.Monitoring.Dashboards = argoproj.ArgoCDDashboardsSpec{
+		Enabled: true,
+		Labels:  map[string]string{"team": "platform"},
+		Folder:  "Argo CD",
+	}
+	assert.NoError(t, r.reconcileDashboards(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "1", cm.Labels["grafana_dashboard"])
+	assert.Equal(t, "platform", cm.Labels["team"])
+	assert.Equal(t, "v2.12.3", cm.Labels["app.kubernetes.io/version"])
+	assert.Equal(t, "Argo CD", cm.Annotations["grafana_folder"])
+	assert.ElementsMatch(t, []string{"argocd-applications.json", "argocd-repo-server.json", "argocd-server.json"}, dataKeys(cm.Data))
+
+	dashboard := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(cm.Data["argocd-applications.json"]), &dashboard))
+	assert.Equal(t, "argocd-applications", dashboard["uid"])
+	assert.Equal(t, []interface{}{"argocd", "argocd-v2.12.3"}, dashboard["tags"])
+
+	// the dashboards follow the version of the instance and are scoped to its namespace
+	a.Spec.Version = "v2.13.0"
+	a.Spec.Monitoring.Dashboards.ScopeToNamespace = true
+	a.Spec.Monitoring.Dashboards.Folder = ""
+	assert.NoError(t, r.reconcileDashboards(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "v2.13.0", cm.Labels["app.kubernetes.io/version"])
+	assert.NotContains(t, cm.Annotations, "grafana_folder")
+
+	dashboard = map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(cm.Data["argocd-applications.json"]), &dashboard))
+	assert.Regexp(t, "^argocd-applications-[0-9a-f]{8}$", dashboard["uid"])
+	assert.Equal(t, "Argo CD / Applications (argocd)", dashboard["title"])
+	assert.Equal(t, []interface{}{"argocd", "argocd-v2.13.0"}, dashboard["tags"])
+	variables := dashboard["templating"].(map[string]interface{})["list"].([]interface{})
+	assert.Contains(t, variables, map[string]interface{}{
+		"name":    "namespace",
+		"type":    "constant",
+		"query":   "argocd",
+		"hide":    float64(2),
+		"current": map[string]interface{}{"text": "argocd", "value": "argocd"},
+	})
+
+	// the dashboards are removed when disabled
+	a.Spec.Monitoring.Dashboards.Enabled = false
+	assert.NoError(t, r.reconcileDashboards(a))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, &corev1.ConfigMap{})))
+}
+
+func TestReconcileGrafanaOperatorDashboards(t *testing.T) {
+	t.Setenv("GRAFANA_CONFIG_PATH", "../../build/grafana")
+	defer func(found bool) { grafanaDashboardAPIFound = found }(grafanaDashboardAPIFound)
+	grafanaDashboardAPIFound = true
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Monitoring.Dashboards = argoproj.ArgoCDDashboardsSpec{
+			Enabled: true,
+			Folder:  "Argo CD",
+			GrafanaOperator: argoproj.ArgoCDGrafanaOperatorDashboardsSpec{
+				Enabled:          true,
+				InstanceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"dashboards": "grafana"}},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getDashboard := func(name string) (*unstructured.Unstructured, error) {
+		dashboard := grafanaDashboardObject()
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, dashboard)
+		return dashboard, err
+	}
+
+	assert.NoError(t, r.reconcileDashboards(a))
+	for _, name := range []string{"argocd-applications-dashboard", "argocd-repo-server-dashboard", "argocd-server-dashboard"} {
+		dashboard, err := getDashboard(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, "v2.12.3", dashboard.GetLabels()["app.kubernetes.io/version"])
+		folder, _, _ := unstructured.NestedString(dashboard.Object, "spec", "folder")
+		assert.Equal(t, "Argo CD", folder)
+		selector, _, _ := unstructured.NestedStringMap(dashboard.Object, "spec", "instanceSelector", "matchLabels")
+		assert.Equal(t, map[string]string{"dashboards": "grafana"}, selector)
+		js, _, _ := unstructured.NestedString(dashboard.Object, "spec", "json")
+		assert.Contains(t, js, "argocd-v2.12.3")
+	}
+
+	// the dashboards are updated
+	a.Spec.Monitoring.Dashboards.GrafanaOperator.AllowCrossNamespaceImport = true
+	assert.NoError(t, r.reconcileDashboards(a))
+	dashboard, err := getDashboard("argocd-server-dashboard")
+	assert.NoError(t, err)
+	allowed, _, _ := unstructured.NestedBool(dashboard.Object, "spec", "allowCrossNamespaceImport")
+	assert.True(t, allowed)
+
+	// and removed when disabled
+	a.Spec.Monitoring.Dashboards.GrafanaOperator.Enabled = false
+	assert.NoError(t, r.reconcileDashboards(a))
+	_, err = getDashboard("argocd-server-dashboard")
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name + "-grafana-dashboards", Namespace: a.Namespace}, &corev1.ConfigMap{}))
+}
+
+func dataKeys(m map[string]string) []string {
+	result := []string{}
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -322,6 +322,17 @@ func getArgoServerCommand(cr *argoproj.ArgoCD, useTLSForRedis bool) []string {
 	cmd = append(cmd, getLogFormat(cr.Spec.Server.LogFormat))
 
 	extraArgs := cr.Spec.Server.ExtraCommandArgs
+
+	// Serve the API and the UI under the path the server is exposed under, unless set by the user
+	if rootPath := getArgoServerRootPath(cr); rootPath != "" {
+		if !containsArg(extraArgs, "--rootpath") {
+			cmd = append(cmd, "--rootpath", rootPath)
+		}
+		if !containsArg(extraArgs, "--basehref") {
+			cmd = append(cmd, "--basehref", rootPath)
+		}
+	}
+
 	err := isMergable(extraArgs, cmd)
 	if err != nil {
 		return cmd
@@ -350,6 +361,17 @@ func isMergable(extraArgs []string, cmd []string) error {
 	return nil
 }
 
+// containsArg returns true if the given argument is set in args, either as a separate value or with the
+// --name=value form.
+func containsArg(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == name || strings.HasPrefix(arg, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 // getDexServerAddress will return the Dex server address.
 func getDexServerAddress(cr *argoproj.ArgoCD) string {
 	return fmt.Sprintf("https://%s", fqdnServiceRef("dex-server", common.ArgoCDDefaultDexHTTPPort, cr))
@@ -510,6 +532,10 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 		},
 	}}
 
+	if isRedisExporterEnabled(cr) {
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getRedisExporterContainer(cr, useTLS))
+	}
+
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis")
 	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
 		{
@@ -572,6 +598,11 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 			changed = true
 		}
 
+		if hasRedisExporterChanged(existing.Spec.Template.Spec.Containers[1:], deploy.Spec.Template.Spec.Containers[1:]) {
+			existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[:1], deploy.Spec.Template.Spec.Containers[1:]...)
+			changed = true
+		}
+
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -597,6 +628,79 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoproj.ArgoCD, useTLS b
 	return r.Client.Create(context.TODO(), deploy)
 }
 
+// getRedisExporterContainer returns the Redis exporter sidecar of the Redis Deployment.
+func getRedisExporterContainer(cr *argoproj.ArgoCD, useTLS bool) corev1.Container {
+	addr := fmt.Sprintf("redis://localhost:%d", common.ArgoCDDefaultRedisPort)
+	if useTLS {
+		addr = fmt.Sprintf("rediss://localhost:%d", common.ArgoCDDefaultRedisPort)
+	}
+	env := []corev1.EnvVar{
+		{
+			Name:  "REDIS_ADDR",
+			Value: addr,
+		},
+		{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-%s", cr.Name, "redis-initial-password"),
+					},
+					Key: "admin.password",
+				},
+			},
+		},
+	}
+	if useTLS {
+		// the certificate of Redis isn't issued for localhost
+		env = append(env, corev1.EnvVar{Name: "REDIS_EXPORTER_SKIP_TLS_VERIFICATION", Value: "true"})
+	}
+
+	return corev1.Container{
+		Env:             env,
+		Image:           getRedisExporterContainerImage(cr),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            "redis-exporter",
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: common.ArgoCDDefaultRedisExporterPort,
+				Name:          common.ArgoCDKeyMetrics,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Resources: getRedisExporterResources(cr),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{
+					"ALL",
+				},
+			},
+			RunAsNonRoot: boolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: "RuntimeDefault",
+			},
+		},
+	}
+}
+
+// hasRedisExporterChanged returns true when the existing sidecars of the Redis Deployment differ from the desired
+// Redis exporter sidecar, if any.
+func hasRedisExporterChanged(existing, desired []corev1.Container) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		if existing[i].Name != desired[i].Name || existing[i].Image != desired[i].Image ||
+			!reflect.DeepEqual(existing[i].Env, desired[i].Env) ||
+			!reflect.DeepEqual(existing[i].Ports, desired[i].Ports) ||
+			!reflect.DeepEqual(existing[i].Resources, desired[i].Resources) {
+			return true
+		}
+	}
+	return false
+}
+
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoproj.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
@@ -880,6 +984,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%ds", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
+	repoEnv = argoutil.EnvMerge(repoEnv, getRepoCacheEnv(cr), true)
 
 	AddSeccompProfileForOpenShift(r.Client, &deploy.Spec.Template.Spec)
 
@@ -915,13 +1020,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	}
 
 	// If the user has specified a custom volume mount that overrides the existing /tmp mount, then we should use the user's custom mount, rather than the default.
-	volumeMountOverridesTmpVolume := false
-	for _, volumeMount := range cr.Spec.Repo.VolumeMounts {
-		if volumeMount.MountPath == "/tmp" {
-			volumeMountOverridesTmpVolume = true
-			break
-		}
-	}
+	volumeMountOverridesTmpVolume := repoVolumeMountsOverrideTmp(cr)
 
 	repoServerVolumeMounts := []corev1.VolumeMount{
 		{
@@ -957,7 +1056,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	if !volumeMountOverridesTmpVolume {
 
 		repoServerVolumeMounts = append(repoServerVolumeMounts, corev1.VolumeMount{
-			Name:      "tmp",
+			Name:      repoCacheVolumeName,
 			MountPath: "/tmp",
 		})
 
@@ -1016,6 +1115,8 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		VolumeMounts: repoServerVolumeMounts,
 	}}
 
+	deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getRepoPluginContainers(cr)...)
+
 	if cr.Spec.Repo.SidecarContainers != nil {
 		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Repo.SidecarContainers...)
 	}
@@ -1089,16 +1190,19 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		},
 	}
 
-	// If the user is not used a custom /tmp mount, then just use the default
-	if !volumeMountOverridesTmpVolume {
+	// If the user is not used a custom /tmp mount, then just use the default. With the persistent cache, /tmp is
+	// backed by the volume claim template of the repo server StatefulSet instead.
+	if !volumeMountOverridesTmpVolume && !useRepoCache(cr) {
 		repoServerVolumes = append(repoServerVolumes, corev1.Volume{
-			Name: "tmp",
+			Name: repoCacheVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
 		})
 	}
 
+	repoServerVolumes = append(repoServerVolumes, getRepoPluginVolumes(cr)...)
+
 	if cr.Spec.Repo.Volumes != nil {
 		repoServerVolumes = append(repoServerVolumes, cr.Spec.Repo.Volumes...)
 	}
@@ -1119,6 +1223,13 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		}
 	}
 
+	if useRepoCache(cr) {
+		return r.reconcileRepoStatefulSet(cr, deploy)
+	}
+	if err := r.deleteRepoStatefulSet(cr); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
@@ -1132,85 +1243,12 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 		}
 
 		changed := false
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRepoServerContainerImage(cr)
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			if existing.Spec.Template.ObjectMeta.Labels == nil {
-				existing.Spec.Template.ObjectMeta.Labels = map[string]string{
-					"image.upgraded": time.Now().UTC().Format("01022006-150406-MST"),
-				}
-			}
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
-			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
-			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Env,
-			existing.Spec.Template.Spec.Containers[0].Env) {
-			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Resources, existing.Spec.Template.Spec.Containers[0].Resources) {
-			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Command, existing.Spec.Template.Spec.Containers[0].Command) {
-			existing.Spec.Template.Spec.Containers[0].Command = deploy.Spec.Template.Spec.Containers[0].Command
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].SecurityContext, existing.Spec.Template.Spec.Containers[0].SecurityContext) {
-			existing.Spec.Template.Spec.Containers[0].SecurityContext = deploy.Spec.Template.Spec.Containers[0].SecurityContext
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[1:],
-			existing.Spec.Template.Spec.Containers[1:]) {
-			existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[0:1],
-				deploy.Spec.Template.Spec.Containers[1:]...)
-			changed = true
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers) {
-			existing.Spec.Template.Spec.InitContainers = deploy.Spec.Template.Spec.InitContainers
-			changed = true
-		}
+		updateRepoPodTemplate(&existing.Spec.Template, &deploy.Spec.Template, &changed)
 		if !reflect.DeepEqual(deploy.Spec.Replicas, existing.Spec.Replicas) {
 			existing.Spec.Replicas = deploy.Spec.Replicas
 			changed = true
 		}
 
-		if deploy.Spec.Template.Spec.AutomountServiceAccountToken != existing.Spec.Template.Spec.AutomountServiceAccountToken {
-			existing.Spec.Template.Spec.AutomountServiceAccountToken = deploy.Spec.Template.Spec.AutomountServiceAccountToken
-			changed = true
-		}
-
-		if deploy.Spec.Template.Spec.ServiceAccountName != existing.Spec.Template.Spec.ServiceAccountName {
-			existing.Spec.Template.Spec.ServiceAccountName = deploy.Spec.Template.Spec.ServiceAccountName
-			existing.Spec.Template.Spec.DeprecatedServiceAccount = deploy.Spec.Template.Spec.ServiceAccountName
-			changed = true
-		}
-
-		deploy.Spec.Template.Annotations = cr.Spec.Repo.Annotations
-		if !reflect.DeepEqual(deploy.Spec.Template.Annotations, existing.Spec.Template.Annotations) {
-			existing.Spec.Template.Annotations = deploy.Spec.Template.Annotations
-			changed = true
-		}
-
-		for key, value := range cr.Spec.Repo.Labels {
-			deploy.Spec.Template.Labels[key] = value
-		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Labels, existing.Spec.Template.Labels) {
-			existing.Spec.Template.Labels = deploy.Spec.Template.Labels
-			changed = true
-		}
-
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -1233,6 +1271,88 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoproj.ArgoCD, useTLSFor
 	return r.Client.Create(context.TODO(), deploy)
 }
 
+// updateRepoPodTemplate updates the existing repo server pod template with the desired state, and flags whether
+// anything had to be changed. It is shared by the Deployment and the cache-enabled StatefulSet of the repo server.
+func updateRepoPodTemplate(existing *corev1.PodTemplateSpec, desired *corev1.PodTemplateSpec, changed *bool) {
+	actualImage := existing.Spec.Containers[0].Image
+	desiredImage := desired.Spec.Containers[0].Image
+	if actualImage != desiredImage {
+		existing.Spec.Containers[0].Image = desiredImage
+		if existing.ObjectMeta.Labels == nil {
+			existing.ObjectMeta.Labels = map[string]string{
+				"image.upgraded": time.Now().UTC().Format("01022006-150406-MST"),
+			}
+		}
+		existing.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.NodeSelector, desired.Spec.NodeSelector) {
+		existing.Spec.NodeSelector = desired.Spec.NodeSelector
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.Spec.Tolerations, desired.Spec.Tolerations) {
+		existing.Spec.Tolerations = desired.Spec.Tolerations
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Volumes, existing.Spec.Volumes) {
+		existing.Spec.Volumes = desired.Spec.Volumes
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[0].VolumeMounts,
+		existing.Spec.Containers[0].VolumeMounts) {
+		existing.Spec.Containers[0].VolumeMounts = desired.Spec.Containers[0].VolumeMounts
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[0].Env,
+		existing.Spec.Containers[0].Env) {
+		existing.Spec.Containers[0].Env = desired.Spec.Containers[0].Env
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[0].Resources, existing.Spec.Containers[0].Resources) {
+		existing.Spec.Containers[0].Resources = desired.Spec.Containers[0].Resources
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[0].Command, existing.Spec.Containers[0].Command) {
+		existing.Spec.Containers[0].Command = desired.Spec.Containers[0].Command
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[0].SecurityContext, existing.Spec.Containers[0].SecurityContext) {
+		existing.Spec.Containers[0].SecurityContext = desired.Spec.Containers[0].SecurityContext
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.Containers[1:],
+		existing.Spec.Containers[1:]) {
+		existing.Spec.Containers = append(existing.Spec.Containers[0:1],
+			desired.Spec.Containers[1:]...)
+		*changed = true
+	}
+	if !reflect.DeepEqual(desired.Spec.InitContainers, existing.Spec.InitContainers) {
+		existing.Spec.InitContainers = desired.Spec.InitContainers
+		*changed = true
+	}
+
+	if desired.Spec.AutomountServiceAccountToken != existing.Spec.AutomountServiceAccountToken {
+		existing.Spec.AutomountServiceAccountToken = desired.Spec.AutomountServiceAccountToken
+		*changed = true
+	}
+
+	if desired.Spec.ServiceAccountName != existing.Spec.ServiceAccountName {
+		existing.Spec.ServiceAccountName = desired.Spec.ServiceAccountName
+		existing.Spec.DeprecatedServiceAccount = desired.Spec.ServiceAccountName
+		*changed = true
+	}
+
+	if !reflect.DeepEqual(desired.Annotations, existing.Annotations) {
+		existing.Annotations = desired.Annotations
+		*changed = true
+	}
+
+	if !reflect.DeepEqual(desired.Labels, existing.Labels) {
+		existing.Labels = desired.Labels
+		*changed = true
+	}
+}
+
 // reconcileServerDeployment will ensure the Deployment resource is present for the ArgoCD Server component.
 func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoproj.ArgoCD, useTLSForRedis bool) error {
 	deploy := newDeploymentWithSuffix("server", "server", cr)
@@ -1332,6 +1332,32 @@ func TestArgoCDServerDeploymentCommand(t *testing.T) {
 	assert.Equal(t, baseCommand, deployment.Spec.Template.Spec.Containers[0].Command)
 }
 
+func TestArgoCDServerDeploymentCommand_rootPath(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.Path = "/argocd"
+	})
+
+	cmd := getArgoServerCommand(a, false)
+	assert.Subset(t, cmd, []string{"--rootpath", "/argocd", "--basehref", "/argocd"})
+
+	// the root path set by the user is kept
+	a.Spec.Server.ExtraCommandArgs = []string{"--basehref", "/"}
+	cmd = getArgoServerCommand(a, false)
+	assert.Equal(t, []string{"--rootpath", "/argocd", "--basehref", "/"}, cmd[len(cmd)-4:])
+
+	// the root path set by the user with the --name=value form is kept
+	a.Spec.Server.ExtraCommandArgs = []string{"--rootpath=/cd", "--basehref=/cd"}
+	cmd = getArgoServerCommand(a, false)
+	assert.Equal(t, []string{"--rootpath=/cd", "--basehref=/cd"}, cmd[len(cmd)-2:])
+	assert.NotContains(t, cmd, "--rootpath")
+	assert.NotContains(t, cmd, "--basehref")
+
+	// no root path is set when the server is exposed at the root of its hosts
+	a.Spec.Server.Ingress.Path = "/"
+	assert.NotContains(t, getArgoServerCommand(a, false), "--rootpath")
+}
+
 func TestReconcileServer_InitContainers(t *testing.T) {
 	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
 		a.Spec.Server.InitContainers = []corev1.Container{
@@ -1752,6 +1778,48 @@ func TestReconcileArgoCD_reconcileRedisDeployment_testImageUpgrade(t *testing.T)
 	assert.Equal(t, newRedis.Spec.Template.Spec.Containers[0].Image, "docker.io/redis/redis:latest")
 }
 
+func TestReconcileArgoCD_reconcileRedisDeployment_exporter(t *testing.T) {
+	cr := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+	})
+
+	resObjs := []client.Object{cr}
+	subresObjs := []client.Object{cr}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	getContainers := func() []corev1.Container {
+		d := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-redis", Namespace: cr.Namespace}, d))
+		return d.Spec.Template.Spec.Containers
+	}
+
+	assert.NoError(t, r.reconcileRedisDeployment(cr, false))
+	assert.Len(t, getContainers(), 1)
+
+	// the exporter is added to the existing deployment
+	cr.Spec.Monitoring.ServiceMonitor.RedisExporter.Enabled = true
+	assert.NoError(t, r.reconcileRedisDeployment(cr, true))
+	containers := getContainers()
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "redis-exporter", containers[1].Name)
+	assert.Equal(t, "quay.io/oliver006/redis_exporter:v1.58.0", containers[1].Image)
+	assert.Equal(t, int32(9121), containers[1].Ports[0].ContainerPort)
+	assert.Equal(t, corev1.EnvVar{Name: "REDIS_ADDR", Value: "rediss://localhost:6379"}, containers[1].Env[0])
+
+	// and updated
+	cr.Spec.Monitoring.ServiceMonitor.RedisExporter.Version = "v1.59.0"
+	assert.NoError(t, r.reconcileRedisDeployment(cr, true))
+	assert.Equal(t, "quay.io/oliver006/redis_exporter:v1.59.0", getContainers()[1].Image)
+
+	// and removed
+	cr.Spec.Prometheus.Enabled = false
+	assert.NoError(t, r.reconcileRedisDeployment(cr, true))
+	assert.Len(t, getContainers(), 1)
+}
+
 func TestReconcileArgoCD_reconcileRedisDeployment_with_error(t *testing.T) {
 	// tests reconciler hook for redis deployment
 	cr := makeTestArgoCD()
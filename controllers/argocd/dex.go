@@ -199,25 +199,44 @@ func (r *ReconcileArgoCD) reconcileDexServiceAccount(cr *argoproj.ArgoCD) error
 		return err
 	}
 
-	// Get the OAuth redirect URI that should be used.
-	uri := r.getDexOAuthRedirectURI(cr)
-	log.Info(fmt.Sprintf("URI: %s", uri))
+	// Get the OAuth redirect URIs that should be used, one for each URL the server is exposed under.
+	uris := r.getDexOAuthRedirectURIs(cr)
+	log.Info(fmt.Sprintf("URIs: %s", strings.Join(uris, ", ")))
 
-	// Get the current redirect URI
 	ann := sa.ObjectMeta.Annotations
-	currentURI, found := ann[common.ArgoCDKeyDexOAuthRedirectURI]
-	if found && currentURI == uri {
-		return nil // Redirect URI annotation found and correct, move along...
-	}
-
-	log.Info(fmt.Sprintf("current URI: %s is not correct, should be: %s", currentURI, uri))
 	if len(ann) <= 0 {
 		ann = make(map[string]string)
 	}
 
-	ann[common.ArgoCDKeyDexOAuthRedirectURI] = uri
-	sa.ObjectMeta.Annotations = ann
+	changed := false
+	desired := make(map[string]string)
+	for i, uri := range uris {
+		key := common.ArgoCDKeyDexOAuthRedirectURI
+		if i > 0 {
+			key = fmt.Sprintf("%s-%d", common.ArgoCDKeyDexOAuthRedirectURI, i)
+		}
+		desired[key] = uri
+
+		if currentURI, found := ann[key]; !found || currentURI != uri {
+			log.Info(fmt.Sprintf("current URI: %s is not correct, should be: %s", currentURI, uri))
+			ann[key] = uri
+			changed = true
+		}
+	}
+
+	// Remove the redirect URIs of the URLs the server is no longer exposed under
+	for key := range ann {
+		if strings.HasPrefix(key, common.ArgoCDKeyDexOAuthRedirectURI+"-") && desired[key] == "" {
+			delete(ann, key)
+			changed = true
+		}
+	}
 
+	if !changed {
+		return nil // Redirect URI annotations found and correct, move along...
+	}
+
+	sa.ObjectMeta.Annotations = ann
 	return r.Client.Update(context.TODO(), sa)
 }
 
@@ -401,6 +420,9 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 			log.Info("deleting the existing Dex service because dex uninstallation has been requested")
 			return r.Client.Delete(context.TODO(), svc)
 		}
+		if ensureServiceSpec(svc, getDexServiceSpec(cr)) {
+			return r.Client.Update(context.TODO(), svc)
+		}
 		return nil
 	}
 
@@ -426,6 +448,7 @@ func (r *ReconcileArgoCD) reconcileDexService(cr *argoproj.ArgoCD) error {
 			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexGRPCPort),
 		},
 	}
+	ensureServiceSpec(svc, getDexServiceSpec(cr))
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -464,6 +487,10 @@ func (r *ReconcileArgoCD) reconcileDexResources(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	if err := r.reconcileDexDeployment(cr); err != nil {
 		log.Error(err, "error reconciling dex deployment")
 	}
@@ -503,6 +530,10 @@ func (r *ReconcileArgoCD) deleteDexResources(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	// Reconcile dex config in argocd-cm (right after dex is disabled)
 	// this is required for a one time trigger of reconcileDexConfiguration directly in case of a dex deletion event,
 	// since reconcileArgoConfigMap won't call reconcileDexConfiguration once dex has been disabled (to avoid reconciling on
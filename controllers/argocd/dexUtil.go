@@ -57,6 +57,24 @@ func (r *ReconcileArgoCD) getDexOAuthRedirectURI(cr *argoproj.ArgoCD) string {
 	return uri + common.ArgoCDDefaultDexOAuthRedirectPath
 }
 
+// getDexOAuthRedirectURIs will return the OAuth redirect URIs for the Dex server, for each URL the ArgoCD server is
+// exposed under. The redirect URI of the URI of the ArgoCD server comes first.
+func (r *ReconcileArgoCD) getDexOAuthRedirectURIs(cr *argoproj.ArgoCD) []string {
+	uris := []string{r.getDexOAuthRedirectURI(cr)}
+	for _, url := range r.getArgoServerAdditionalURLs(cr) {
+		uris = append(uris, url+common.ArgoCDDefaultDexOAuthRedirectPath)
+	}
+	return uris
+}
+
+// getDexServiceSpec will return the options of the Dex Service for the given ArgoCD.
+func getDexServiceSpec(cr *argoproj.ArgoCD) argoproj.ArgoCDServiceSpec {
+	if cr.Spec.SSO != nil && cr.Spec.SSO.Dex != nil {
+		return cr.Spec.SSO.Dex.Service
+	}
+	return argoproj.ArgoCDServiceSpec{}
+}
+
 // getDexOAuthClientID will return the OAuth client ID for the given ArgoCD.
 func getDexOAuthClientID(cr *argoproj.ArgoCD) string {
 	return fmt.Sprintf("system:serviceaccount:%s:%s", cr.Namespace, fmt.Sprintf("%s-%s", cr.Name, common.ArgoCDDefaultDexServiceAccountName))
@@ -1012,3 +1012,48 @@ func TestReconcileArgoCD_reconcileRoleBinding_dex_disabled(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcileDexServiceAccount_additionalHosts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.internal"
+		a.Spec.Server.AdditionalHosts = []string{"argocd.example.com"}
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeDex,
+			Dex: &argoproj.ArgoCDDexSpec{
+				OpenShiftOAuth: true,
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	_, err := r.reconcileServiceAccount(common.ArgoCDDexServerComponent, a)
+	assert.NoError(t, err)
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+
+	sa := newServiceAccountWithName(common.ArgoCDDexServerComponent, a)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: testNamespace}, sa))
+	assert.Equal(t, "https://argocd.internal/api/dex/callback", sa.Annotations[common.ArgoCDKeyDexOAuthRedirectURI])
+	assert.Equal(t, "https://argocd.example.com/api/dex/callback", sa.Annotations[common.ArgoCDKeyDexOAuthRedirectURI+"-1"])
+
+	// the redirect URIs follow the canonical host
+	a.Spec.Server.CanonicalHost = "argocd.example.com"
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: testNamespace}, sa))
+	assert.Equal(t, "https://argocd.example.com/api/dex/callback", sa.Annotations[common.ArgoCDKeyDexOAuthRedirectURI])
+	assert.Equal(t, "https://argocd.internal/api/dex/callback", sa.Annotations[common.ArgoCDKeyDexOAuthRedirectURI+"-1"])
+
+	// the redirect URIs of removed hosts are removed
+	a.Spec.Server.AdditionalHosts = nil
+	a.Spec.Server.CanonicalHost = ""
+	assert.NoError(t, r.reconcileDexServiceAccount(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: sa.Name, Namespace: testNamespace}, sa))
+	assert.Equal(t, "https://argocd.internal/api/dex/callback", sa.Annotations[common.ArgoCDKeyDexOAuthRedirectURI])
+	assert.NotContains(t, sa.Annotations, common.ArgoCDKeyDexOAuthRedirectURI+"-1")
+}
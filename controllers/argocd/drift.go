@@ -0,0 +1,391 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// maxDriftedFields is the maximum number of field paths recorded for a single drifted resource.
+const maxDriftedFields = 10
+
+// driftIgnoredFields are the fields that are managed by the API server and never considered as drift.
+var driftIgnoredFields = []string{
+	"apiVersion",
+	"kind",
+	"status",
+	"metadata.creationTimestamp",
+	"metadata.generation",
+	"metadata.managedFields",
+	"metadata.resourceVersion",
+	"metadata.selfLink",
+	"metadata.uid",
+}
+
+// driftClient wraps the reconciler client and inspects every update and patch of an existing
+// operator-managed resource. The reconcilers only update a resource when its live state
+// differs from the desired state, so when the spec of the ArgoCD did not change since its
+// resources were last reconciled, each such update is recorded as drift. When running in
+// report only mode the drift is not reverted.
+type driftClient struct {
+	client.Client
+	scheme     *runtime.Scheme
+	reportOnly bool
+	// specChanged is true when the spec of the ArgoCD changed since its resources were last
+	// reconciled, the updates then apply the new desired state and are not drift.
+	specChanged bool
+	drifted     []argoproj.DriftedResource
+}
+
+func newDriftClient(c client.Client, scheme *runtime.Scheme, reportOnly, specChanged bool) *driftClient {
+	return &driftClient{
+		Client:      c,
+		scheme:      scheme,
+		reportOnly:  reportOnly,
+		specChanged: specChanged,
+	}
+}
+
+// Update records the fields of obj that differ from the live object before updating it.
+func (c *driftClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	skip, err := c.detectDrift(ctx, obj, func(live client.Object) (client.Object, error) { return obj, nil })
+	if err != nil || skip {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch records the fields of the live object changed by the patch before patching it.
+func (c *driftClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	skip, err := c.detectDrift(ctx, obj, func(live client.Object) (client.Object, error) { return applyPatch(live, obj, patch) })
+	if err != nil || skip {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// detectDrift records the fields of the live object of obj that differ from the desired object
+// returned by desired. It returns true if the change must not be sent to the API server.
+func (c *driftClient) detectDrift(ctx context.Context, obj client.Object, desired func(live client.Object) (client.Object, error)) (bool, error) {
+	if _, ok := obj.(*argoproj.ArgoCD); ok || c.specChanged {
+		// The ArgoCD instance itself is not a managed resource, and changes of its spec are not drift.
+		return false, nil
+	}
+
+	live, err := getLiveObject(ctx, c.Client, c.scheme, obj)
+	if err != nil || live == nil {
+		return false, err
+	}
+
+	want, err := desired(live)
+	if err != nil || want == nil {
+		return false, err
+	}
+	fields, err := diffObjects(live, want)
+	if err != nil {
+		return false, err
+	}
+	if len(fields) == 0 {
+		return false, nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return false, err
+	}
+	c.record(gvk.Kind, obj, fields)
+	if c.reportOnly {
+		log.Info(fmt.Sprintf("drift detected on %s %s/%s, not reverting as drift detection is in report mode", gvk.Kind, obj.GetNamespace(), obj.GetName()))
+		return true, nil
+	}
+	return false, nil
+}
+
+// getLiveObject returns the live state of the given object, or nil if it doesn't exist.
+func getLiveObject(ctx context.Context, c client.Client, scheme *runtime.Scheme, obj client.Object) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, err
+	}
+	ro, err := scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	live, ok := ro.(client.Object)
+	if !ok {
+		return nil, nil
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return live, nil
+}
+
+// applyPatch returns the live object with the given patch of obj applied, or nil if the result
+// of the patch can not be computed before sending it, as for server side apply.
+func applyPatch(live, obj client.Object, patch client.Patch) (client.Object, error) {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return nil, err
+	}
+	original, err := json.Marshal(live)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch patch.Type() {
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, data)
+	case types.JSONPatchType:
+		var p jsonpatch.Patch
+		if p, err = jsonpatch.DecodePatch(data); err == nil {
+			patched, err = p.Apply(original)
+		}
+	case types.StrategicMergePatchType:
+		patched, err = strategicpatch.StrategicMergePatch(original, data, live)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := live.DeepCopyObject().(client.Object)
+	if err := json.Unmarshal(patched, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// record adds the drifted fields of the given object to the drift report, merging them
+// with the fields recorded earlier for the same object.
+func (c *driftClient) record(kind string, obj client.Object, fields []string) {
+	for i := range c.drifted {
+		d := &c.drifted[i]
+		if d.Kind == kind && d.Namespace == obj.GetNamespace() && d.Name == obj.GetName() {
+			for _, f := range fields {
+				if !containsString(d.Fields, f) && len(d.Fields) < maxDriftedFields {
+					d.Fields = append(d.Fields, f)
+				}
+			}
+			sort.Strings(d.Fields)
+			return
+		}
+	}
+	if len(fields) > maxDriftedFields {
+		fields = fields[:maxDriftedFields]
+	}
+	c.drifted = append(c.drifted, argoproj.DriftedResource{
+		Kind:      kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Fields:    fields,
+		Reverted:  !c.reportOnly,
+	})
+}
+
+// isDriftDetectionEnabled returns true if drift detection is enabled for the given ArgoCD.
+func isDriftDetectionEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.DriftDetection != nil && cr.Spec.DriftDetection.Enabled
+}
+
+// reconcileResourcesDetectingDrift reconciles the resources of the given ArgoCD and, when drift
+// detection is enabled, records the resources that drifted from their desired state.
+func (r *ReconcileArgoCD) reconcileResourcesDetectingDrift(ctx context.Context, cr *argoproj.ArgoCD) error {
+	specHash, err := getDriftSpecHash(cr)
+	if err != nil {
+		return err
+	}
+
+	if !isDriftDetectionEnabled(cr) {
+		if err := r.reconcileResources(ctx, cr); err != nil {
+			return err
+		}
+		return r.reconcileStatusDrift(ctx, cr, nil, specHash)
+	}
+
+	// The resources are reconciled using the drift client, which records the resources that drifted.
+	var drift *driftClient
+	restore := r.pushClient(func(c client.Client) client.Client {
+		drift = newDriftClient(c, r.Scheme, cr.Spec.DriftDetection.IsReportOnly(), cr.Status.LastAppliedSpecHash != specHash)
+		return drift
+	})
+	err = r.reconcileResources(ctx, cr)
+	restore()
+	if err != nil {
+		return err
+	}
+	return r.reconcileStatusDrift(ctx, cr, drift.drifted, specHash)
+}
+
+// getDriftSpecHash returns the hash of the spec of the given ArgoCD, without its drift detection
+// options so that changing them doesn't apply the drift reported so far.
+func getDriftSpecHash(cr *argoproj.ArgoCD) (string, error) {
+	spec := cr.Spec.DeepCopy()
+	spec.DriftDetection = nil
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
+
+// reconcileStatusDrift will ensure that the drift status and metrics are updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusDrift(ctx context.Context, cr *argoproj.ArgoCD, drifted []argoproj.DriftedResource, specHash string) error {
+	sort.Slice(drifted, func(i, j int) bool {
+		a, b := drifted[i], drifted[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	DriftedResources.DeletePartialMatch(prometheus.Labels{"namespace": cr.Namespace})
+	if isDriftDetectionEnabled(cr) {
+		counts := make(map[string]int)
+		for _, d := range drifted {
+			counts[d.Kind]++
+		}
+		for kind, count := range counts {
+			DriftedResources.WithLabelValues(cr.Namespace, kind).Set(float64(count))
+		}
+	}
+
+	for _, d := range drifted {
+		if isDriftReported(cr.Status.DriftedResources, d) {
+			continue
+		}
+		action := "Reported"
+		if d.Reverted {
+			action = "Reverted"
+		}
+		message := fmt.Sprintf("%s %s drifted from its desired state: %s", d.Kind, d.Name, strings.Join(d.Fields, ", "))
+		if err := argoutil.CreateEvent(r.Client, "Warning", action, message, "ResourceDrifted", cr.ObjectMeta, cr.TypeMeta); err != nil {
+			log.Error(err, "failed to create drift event")
+		}
+	}
+
+	if reflect.DeepEqual(cr.Status.DriftedResources, drifted) && cr.Status.LastAppliedSpecHash == specHash {
+		return nil
+	}
+	return r.updateStatusWithContext(ctx, cr, func(status *argoproj.ArgoCDStatus) {
+		status.DriftedResources = drifted
+		status.LastAppliedSpecHash = specHash
+	})
+}
+
+// isDriftReported returns true if the given drifted resource is already part of the reported drift.
+func isDriftReported(reported []argoproj.DriftedResource, d argoproj.DriftedResource) bool {
+	for _, rd := range reported {
+		if rd.Kind == d.Kind && rd.Namespace == d.Namespace && rd.Name == d.Name && reflect.DeepEqual(rd.Fields, d.Fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffObjects returns the sorted paths of the fields that differ between the live and the desired object.
+func diffObjects(live, desired runtime.Object) ([]string, error) {
+	l, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, err
+	}
+	d, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range driftIgnoredFields {
+		path := strings.Split(f, ".")
+		unstructured.RemoveNestedField(l, path...)
+		unstructured.RemoveNestedField(d, path...)
+	}
+
+	fields := []string{}
+	diffValues("", l, d, &fields)
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// diffValues appends the paths of the values that differ between a and b to fields.
+func diffValues(path string, a, b interface{}, fields *[]string) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok {
+			*fields = append(*fields, path)
+			return
+		}
+		keys := make(map[string]bool)
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		for k := range keys {
+			diffValues(joinFieldPath(path, k), av[k], bv[k], fields)
+		}
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			*fields = append(*fields, path)
+			return
+		}
+		for i := range av {
+			diffValues(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(a, b) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// joinFieldPath appends key to the given field path. Keys containing dots, such as
+// label keys or argocd-cm keys, are wrapped in brackets to keep the path readable.
+func joinFieldPath(path, key string) string {
+	if strings.Contains(key, ".") {
+		return fmt.Sprintf("%s[%s]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
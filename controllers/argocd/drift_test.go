@@ -0,0 +1,172 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestDiffObjects(t *testing.T) {
+	live := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "argocd-cm",
+			Namespace:       testNamespace,
+			ResourceVersion: "42",
+			Labels: map[string]string{
+				common.ArgoCDKeyName: "argocd-cm",
+			},
+		},
+		Data: map[string]string{
+			common.ArgoCDKeyAdminEnabled: "false",
+			"foo":                        "bar",
+		},
+	}
+	desired := live.DeepCopy()
+	desired.ResourceVersion = ""
+	desired.Labels["extra"] = "label"
+	desired.Data[common.ArgoCDKeyAdminEnabled] = "true"
+
+	fields, err := diffObjects(live, desired)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"data[admin.enabled]", "metadata.labels.extra"}, fields)
+
+	fields, err = diffObjects(live, live.DeepCopy())
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+}
+
+func TestReconcileArgoCD_reconcileStatusDrift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the status is updated without replacing the spec the resources are reconciled from
+	a.Spec.DriftDetection = &argoproj.ArgoCDDriftDetectionSpec{Enabled: true}
+	drifted := []argoproj.DriftedResource{{Kind: "ConfigMap", Name: common.ArgoCDConfigMapName, Namespace: testNamespace}}
+	assert.NoError(t, r.reconcileStatusDrift(context.TODO(), a, drifted, "hash"))
+	assert.Equal(t, drifted, a.Status.DriftedResources)
+	assert.Equal(t, "hash", a.Status.LastAppliedSpecHash)
+	assert.NotNil(t, a.Spec.DriftDetection)
+
+	live := &argoproj.ArgoCD{}
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(a), live))
+	assert.Equal(t, drifted, live.Status.DriftedResources)
+	assert.Equal(t, "hash", live.Status.LastAppliedSpecHash)
+
+	// an unchanged status is not updated
+	assert.NoError(t, r.reconcileStatusDrift(context.TODO(), a, drifted, "hash"))
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(a), a))
+	assert.Equal(t, live.ResourceVersion, a.ResourceVersion)
+}
+
+func TestReconcileArgoCD_reconcileResourcesDetectingDrift(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.DriftDetection = &argoproj.ArgoCDDriftDetectionSpec{
+			Enabled: true,
+			Mode:    argoproj.DriftDetectionModeReport,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Empty(t, a.Status.DriftedResources)
+
+	// hand edit argocd-cm
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	cm.Data[common.ArgoCDKeyAdminEnabled] = "false"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+
+	// report mode records the drift but keeps the live state
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Equal(t, []argoproj.DriftedResource{{
+		Kind:      "ConfigMap",
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+		Fields:    []string{"data[admin.enabled]"},
+	}}, a.Status.DriftedResources)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "false", cm.Data[common.ArgoCDKeyAdminEnabled])
+
+	// spec changes are applied in report mode and not recorded as drift
+	a.Spec.StatusBadgeEnabled = true
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Empty(t, a.Status.DriftedResources)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyStatusBadgeEnabled])
+
+	// hand edit argocd-cm again
+	cm.Data[common.ArgoCDKeyAdminEnabled] = "false"
+	assert.NoError(t, r.Client.Update(context.TODO(), cm))
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Len(t, a.Status.DriftedResources, 1)
+
+	// reconcile mode records the drift and reverts it
+	a.Spec.DriftDetection.Mode = argoproj.DriftDetectionModeReconcile
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Len(t, a.Status.DriftedResources, 1)
+	assert.True(t, a.Status.DriftedResources[0].Reverted)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, "true", cm.Data[common.ArgoCDKeyAdminEnabled])
+
+	// no drift left after the revert
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	assert.Empty(t, a.Status.DriftedResources)
+}
+
+func TestDriftClient_Patch(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	cm := newConfigMapWithName(common.ArgoCDConfigMapName, makeTestArgoCD())
+	cm.Data = map[string]string{common.ArgoCDKeyAdminEnabled: "true"}
+
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, []client.Object{cm}, []client.Object{}, []runtime.Object{})
+
+	drift := newDriftClient(cl, sch, true, false)
+	patched := cm.DeepCopy()
+	patched.Data[common.ArgoCDKeyAdminEnabled] = "false"
+	assert.NoError(t, drift.Patch(context.TODO(), patched, client.MergeFrom(cm)))
+	assert.Equal(t, []argoproj.DriftedResource{{
+		Kind:      "ConfigMap",
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+		Fields:    []string{"data[admin.enabled]"},
+	}}, drift.drifted)
+
+	live := &corev1.ConfigMap{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cm), live))
+	assert.Equal(t, "true", live.Data[common.ArgoCDKeyAdminEnabled])
+
+	// patches applying a changed spec are not drift
+	drift = newDriftClient(cl, sch, true, true)
+	assert.NoError(t, drift.Patch(context.TODO(), patched, client.MergeFrom(cm)))
+	assert.Empty(t, drift.drifted)
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(cm), live))
+	assert.Equal(t, "false", live.Data[common.ArgoCDKeyAdminEnabled])
+}
@@ -0,0 +1,337 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+var (
+	gatewayAPIFound   = false
+	grpcRouteAPIFound = false
+)
+
+// IsGatewayAPIAvailable returns true if the Gateway API, and its HTTPRoute resource, is present.
+func IsGatewayAPIAvailable() bool {
+	return gatewayAPIFound
+}
+
+// IsGRPCRouteAPIAvailable returns true if the GRPCRoute resource of the Gateway API is present.
+func IsGRPCRouteAPIAvailable() bool {
+	return gatewayAPIFound && grpcRouteAPIFound
+}
+
+// isGatewayAPIAvailable returns true if the Gateway API is available to the reconciler.
+func (r *ReconcileArgoCD) isGatewayAPIAvailable() bool {
+	if r.apis != nil {
+		return r.apis.gateway
+	}
+	return IsGatewayAPIAvailable()
+}
+
+// isGRPCRouteAPIAvailable returns true if the GRPCRoute resource of the Gateway API is available to the reconciler.
+func (r *ReconcileArgoCD) isGRPCRouteAPIAvailable() bool {
+	if r.apis != nil {
+		return r.apis.gateway && r.apis.grpcRoute
+	}
+	return IsGRPCRouteAPIAvailable()
+}
+
+// verifyGatewayAPI will verify that the Gateway API is present.
+func verifyGatewayAPI() error {
+	found, err := argoutil.VerifyAPI(gatewayv1.GroupName, gatewayv1.GroupVersion.Version)
+	if err != nil {
+		return err
+	}
+	gatewayAPIFound = found
+
+	// GRPCRoute is only served by the experimental channel of the Gateway API.
+	found, err = argoutil.VerifyAPI(gatewayv1alpha2.GroupName, gatewayv1alpha2.GroupVersion.Version)
+	if err != nil {
+		return err
+	}
+	grpcRouteAPIFound = found
+	return nil
+}
+
+// newHTTPRouteWithSuffix returns a new HTTPRoute with the given name suffix for the ArgoCD.
+func newHTTPRouteWithSuffix(suffix string, cr *argoproj.ArgoCD) *gatewayv1.HTTPRoute {
+	return newHTTPRouteWithName(nameWithSuffix(suffix, cr), cr)
+}
+
+// newHTTPRouteWithName returns a new HTTPRoute with the given name for the ArgoCD.
+func newHTTPRouteWithName(name string, cr *argoproj.ArgoCD) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: newGatewayRouteObjectMeta(name, cr),
+	}
+}
+
+// newGRPCRouteWithSuffix returns a new GRPCRoute with the given name suffix for the ArgoCD.
+func newGRPCRouteWithSuffix(suffix string, cr *argoproj.ArgoCD) *gatewayv1alpha2.GRPCRoute {
+	return &gatewayv1alpha2.GRPCRoute{
+		ObjectMeta: newGatewayRouteObjectMeta(nameWithSuffix(suffix, cr), cr),
+	}
+}
+
+func newGatewayRouteObjectMeta(name string, cr *argoproj.ArgoCD) metav1.ObjectMeta {
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: cr.Namespace,
+		Labels:    lbls,
+	}
+}
+
+// setGatewayRouteMetadata adds the annotations and labels of the given spec to the route metadata.
+func setGatewayRouteMetadata(spec argoproj.ArgoCDGatewaySpec, objectMeta *metav1.ObjectMeta) {
+	if len(spec.Annotations) > 0 {
+		objectMeta.Annotations = spec.Annotations
+	}
+	for key, val := range spec.Labels {
+		objectMeta.Labels[key] = val
+	}
+}
+
+// getGatewayParentRefs returns the parent references of the given spec, with the defaults applied by the
+// API server set so that the desired and live routes can be compared.
+func getGatewayParentRefs(spec argoproj.ArgoCDGatewaySpec) []gatewayv1.ParentReference {
+	refs := make([]gatewayv1.ParentReference, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		ref := *ref.DeepCopy()
+		if ref.Group == nil {
+			group := gatewayv1.Group(gatewayv1.GroupName)
+			ref.Group = &group
+		}
+		if ref.Kind == nil {
+			kind := gatewayv1.Kind("Gateway")
+			ref.Kind = &kind
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// getGatewayHostnames returns the hostnames matched by a route for the given hosts.
+func getGatewayHostnames(hosts ...string) []gatewayv1.Hostname {
+	var hostnames []gatewayv1.Hostname
+	for _, host := range hosts {
+		if host != "" {
+			hostnames = append(hostnames, gatewayv1.Hostname(host))
+		}
+	}
+	return hostnames
+}
+
+// getGatewayBackendRef returns the reference to the given port of the given Service.
+func getGatewayBackendRef(service string, port int32) gatewayv1.BackendRef {
+	group := gatewayv1.Group("")
+	kind := gatewayv1.Kind("Service")
+	portNumber := gatewayv1.PortNumber(port)
+	weight := int32(1)
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gatewayv1.ObjectName(service),
+			Port:  &portNumber,
+		},
+		Weight: &weight,
+	}
+}
+
+// getHTTPRouteSpec returns the spec of an HTTPRoute forwarding the requests for the given hosts to the given
+// port of the given Service.
+func getHTTPRouteSpec(spec argoproj.ArgoCDGatewaySpec, hosts []string, service string, port int32) gatewayv1.HTTPRouteSpec {
+	pathType := gatewayv1.PathMatchPathPrefix
+	path := "/"
+	if spec.Path != "" {
+		path = spec.Path
+	}
+	return gatewayv1.HTTPRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: getGatewayParentRefs(spec),
+		},
+		Hostnames: getGatewayHostnames(hosts...),
+		Rules: []gatewayv1.HTTPRouteRule{{
+			Matches: []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  &pathType,
+					Value: &path,
+				},
+			}},
+			BackendRefs: []gatewayv1.HTTPBackendRef{{
+				BackendRef: getGatewayBackendRef(service, port),
+			}},
+		}},
+	}
+}
+
+// reconcileGatewayRoutes will ensure that all ArgoCD Gateway API routes are present.
+func (r *ReconcileArgoCD) reconcileGatewayRoutes(cr *argoproj.ArgoCD) error {
+	if err := r.reconcileServerHTTPRoute(cr); err != nil {
+		return err
+	}
+
+	if r.isGRPCRouteAPIAvailable() {
+		if err := r.reconcileServerGRPCRoute(cr); err != nil {
+			return err
+		}
+	} else if cr.Spec.Server.GRPC.Gateway.Enabled {
+		log.Info("GRPCRoute API not available, skipping the argocd-server GRPCRoute")
+	}
+
+	if err := r.reconcileApplicationSetControllerWebhookHTTPRoute(cr); err != nil {
+		return err
+	}
+
+	return r.reconcileKeycloakHTTPRoute(cr)
+}
+
+// reconcileServerHTTPRoute will ensure that the ArgoCD Server HTTPRoute is present.
+func (r *ReconcileArgoCD) reconcileServerHTTPRoute(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Server.Gateway
+	route := newHTTPRouteWithSuffix("server", cr)
+	setGatewayRouteMetadata(spec, &route.ObjectMeta)
+	route.Spec = getHTTPRouteSpec(spec, append([]string{cr.Spec.Server.Host}, cr.Spec.Server.AdditionalHosts...), nameWithSuffix("server", cr), 80)
+	return r.reconcileHTTPRoute(cr, route, spec.Enabled && cr.Spec.Server.IsEnabled())
+}
+
+// reconcileServerGRPCRoute will ensure that the ArgoCD Server GRPCRoute used by the CLI is present.
+func (r *ReconcileArgoCD) reconcileServerGRPCRoute(cr *argoproj.ArgoCD) error {
+	spec := cr.Spec.Server.GRPC.Gateway
+	route := newGRPCRouteWithSuffix("grpc", cr)
+	setGatewayRouteMetadata(spec, &route.ObjectMeta)
+	route.Spec = gatewayv1alpha2.GRPCRouteSpec{
+		CommonRouteSpec: gatewayv1.CommonRouteSpec{
+			ParentRefs: getGatewayParentRefs(spec),
+		},
+		Hostnames: getGatewayHostnames(cr.Spec.Server.GRPC.Host),
+		Rules: []gatewayv1alpha2.GRPCRouteRule{{
+			BackendRefs: []gatewayv1alpha2.GRPCBackendRef{{
+				BackendRef: getGatewayBackendRef(nameWithSuffix("server", cr), 80),
+			}},
+		}},
+	}
+
+	existing := newGRPCRouteWithSuffix("grpc", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+	if !spec.Enabled || !cr.Spec.Server.IsEnabled() {
+		if found {
+			// GRPCRoute exists but enabled flag has been set to false, delete the GRPCRoute
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		return nil // GRPCRoute not enabled, move along...
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Creating GRPCRoute %s", route.Name))
+		return r.Client.Create(context.TODO(), route)
+	}
+
+	if reflect.DeepEqual(existing.Spec, route.Spec) && reflect.DeepEqual(existing.Labels, route.Labels) &&
+		reflect.DeepEqual(existing.Annotations, route.Annotations) {
+		return nil
+	}
+	existing.Spec = route.Spec
+	existing.Labels = route.Labels
+	existing.Annotations = route.Annotations
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// reconcileApplicationSetControllerWebhookHTTPRoute will ensure that the ApplicationSet webhook HTTPRoute is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetControllerWebhookHTTPRoute(cr *argoproj.ArgoCD) error {
+	name := fmt.Sprintf("%s-%s", common.ApplicationSetServiceNameSuffix, "webhook")
+	route := newHTTPRouteWithSuffix(name, cr)
+	if cr.Spec.ApplicationSet == nil {
+		return r.reconcileHTTPRoute(cr, route, false)
+	}
+
+	spec := cr.Spec.ApplicationSet.WebhookServer.Gateway
+	setGatewayRouteMetadata(spec, &route.ObjectMeta)
+	route.Spec = getHTTPRouteSpec(spec, []string{cr.Spec.ApplicationSet.WebhookServer.Host},
+		nameWithSuffix(common.ApplicationSetServiceNameSuffix, cr), 7000)
+	return r.reconcileHTTPRoute(cr, route, spec.Enabled)
+}
+
+// reconcileKeycloakHTTPRoute will ensure that the Keycloak HTTPRoute is present when Keycloak is not
+// installed using the OpenShift template.
+func (r *ReconcileArgoCD) reconcileKeycloakHTTPRoute(cr *argoproj.ArgoCD) error {
+	route := newHTTPRouteWithName(defaultKeycloakIdentifier, cr)
+	if cr.Spec.SSO == nil || cr.Spec.SSO.Provider.ToLower() != argoproj.SSOProviderTypeKeycloak ||
+		cr.Spec.SSO.Keycloak == nil || CanUseKeycloakWithTemplate() {
+		return r.reconcileHTTPRoute(cr, route, false)
+	}
+
+	spec := cr.Spec.SSO.Keycloak.Gateway
+	setGatewayRouteMetadata(spec, &route.ObjectMeta)
+	route.Spec = getHTTPRouteSpec(spec, []string{getKeycloakIngressHost(cr.Spec.SSO.Keycloak)}, defaultKeycloakIdentifier, httpPort)
+	return r.reconcileHTTPRoute(cr, route, spec.Enabled)
+}
+
+// reconcileHTTPRoute will ensure that the given HTTPRoute is present when enabled, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileHTTPRoute(cr *argoproj.ArgoCD, route *gatewayv1.HTTPRoute, enabled bool) error {
+	existing := newHTTPRouteWithName(route.Name, cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+	if !enabled {
+		if found {
+			// HTTPRoute exists but enabled flag has been set to false, delete the HTTPRoute
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		return nil // HTTPRoute not enabled, move along...
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+			return err
+		}
+		log.Info(fmt.Sprintf("Creating HTTPRoute %s", route.Name))
+		return r.Client.Create(context.TODO(), route)
+	}
+
+	if reflect.DeepEqual(existing.Spec, route.Spec) && reflect.DeepEqual(existing.Labels, route.Labels) &&
+		reflect.DeepEqual(existing.Annotations, route.Annotations) {
+		return nil
+	}
+	existing.Spec = route.Spec
+	existing.Labels = route.Labels
+	existing.Annotations = route.Annotations
+	return r.Client.Update(context.TODO(), existing)
+}
+
+// isHTTPRouteAccepted returns true if one of the parents of the given HTTPRoute accepted it.
+func isHTTPRouteAccepted(route *gatewayv1.HTTPRoute) bool {
+	for _, parent := range route.Status.Parents {
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			return true
+		}
+	}
+	return false
+}
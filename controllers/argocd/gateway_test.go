@@ -0,0 +1,156 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestGatewaySpec() argoproj.ArgoCDGatewaySpec {
+	namespace := gatewayv1.Namespace("gateways")
+	return argoproj.ArgoCDGatewaySpec{
+		Enabled:    true,
+		ParentRefs: []gatewayv1.ParentReference{{Name: "public", Namespace: &namespace}},
+	}
+}
+
+func TestReconcileArgoCD_reconcileGatewayRoutes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	gatewayAPIFound, grpcRouteAPIFound = true, true
+	defer func() {
+		gatewayAPIFound, grpcRouteAPIFound = false, false
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.example.com"
+		a.Spec.Server.Gateway = makeTestGatewaySpec()
+		a.Spec.Server.Gateway.Labels = map[string]string{"exposure": "public"}
+		a.Spec.Server.GRPC.Host = "grpc.argocd.example.com"
+		a.Spec.Server.GRPC.Gateway = makeTestGatewaySpec()
+		a.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{
+			WebhookServer: argoproj.WebhookServerSpec{
+				Host:    "webhook.argocd.example.com",
+				Gateway: makeTestGatewaySpec(),
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install, gatewayv1alpha2.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+
+	server := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, server))
+	assert.Equal(t, "public", server.Labels["exposure"])
+	assert.Equal(t, []gatewayv1.Hostname{"argocd.example.com"}, server.Spec.Hostnames)
+	assert.Len(t, server.Spec.ParentRefs, 1)
+	assert.Equal(t, gatewayv1.ObjectName("public"), server.Spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayv1.Kind("Gateway"), *server.Spec.ParentRefs[0].Kind)
+	assert.Len(t, server.Spec.Rules, 1)
+	assert.Equal(t, "/", *server.Spec.Rules[0].Matches[0].Path.Value)
+	backend := server.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName("argocd-server"), backend.Name)
+	assert.Equal(t, gatewayv1.PortNumber(80), *backend.Port)
+
+	grpc := &gatewayv1alpha2.GRPCRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc", Namespace: testNamespace}, grpc))
+	assert.Equal(t, []gatewayv1.Hostname{"grpc.argocd.example.com"}, grpc.Spec.Hostnames)
+	assert.Equal(t, gatewayv1.ObjectName("argocd-server"), grpc.Spec.Rules[0].BackendRefs[0].Name)
+
+	webhook := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller-webhook", Namespace: testNamespace}, webhook))
+	assert.Equal(t, []gatewayv1.Hostname{"webhook.argocd.example.com"}, webhook.Spec.Hostnames)
+	backend = webhook.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName("argocd-applicationset-controller"), backend.Name)
+	assert.Equal(t, gatewayv1.PortNumber(7000), *backend.Port)
+
+	// an unchanged route is not updated
+	resourceVersion := server.ResourceVersion
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, server))
+	assert.Equal(t, resourceVersion, server.ResourceVersion)
+
+	// the path of the route is updated
+	a.Spec.Server.Gateway.Path = "/argocd"
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, server))
+	assert.Equal(t, "/argocd", *server.Spec.Rules[0].Matches[0].Path.Value)
+
+	// disabled routes are removed
+	a.Spec.Server.Gateway.Enabled = false
+	a.Spec.Server.GRPC.Gateway.Enabled = false
+	a.Spec.ApplicationSet = nil
+	assert.NoError(t, r.reconcileGatewayRoutes(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, server)
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc", Namespace: testNamespace}, grpc)
+	assert.True(t, errors.IsNotFound(err))
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller-webhook", Namespace: testNamespace}, webhook)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGetArgoServerInsecure_gateway(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.False(t, getArgoServerInsecure(a))
+	assert.NotContains(t, getArgoServerCommand(a, false), "--insecure")
+
+	// the routes send plaintext to the server once the Gateway terminated TLS
+	a.Spec.Server.Gateway = makeTestGatewaySpec()
+	assert.True(t, getArgoServerInsecure(a))
+	assert.Contains(t, getArgoServerCommand(a, false), "--insecure")
+
+	a = makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.GRPC.Gateway = makeTestGatewaySpec()
+	})
+	assert.True(t, getArgoServerInsecure(a))
+}
+
+func TestReconcileArgoCD_reconcileKeycloakHTTPRoute(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+			Keycloak: &argoproj.ArgoCDKeycloakSpec{
+				Host:    "keycloak.example.com",
+				Gateway: makeTestGatewaySpec(),
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, gatewayv1.Install)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileKeycloakHTTPRoute(a))
+
+	route := &gatewayv1.HTTPRoute{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "keycloak", Namespace: testNamespace}, route))
+	assert.Equal(t, []gatewayv1.Hostname{"keycloak.example.com"}, route.Spec.Hostnames)
+	backend := route.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayv1.ObjectName("keycloak"), backend.Name)
+	assert.Equal(t, gatewayv1.PortNumber(httpPort), *backend.Port)
+
+	// the route is removed with the Keycloak configuration
+	a.Spec.SSO = nil
+	assert.NoError(t, r.reconcileKeycloakHTTPRoute(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "keycloak", Namespace: testNamespace}, route)
+	assert.True(t, errors.IsNotFound(err))
+}
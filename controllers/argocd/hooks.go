@@ -1,34 +1,323 @@
 package argocd
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
 )
 
 var (
-	mutex sync.RWMutex
-	hooks = []Hook{}
+	hooksMutex sync.RWMutex
+	hooks      = []ReconcilerHook{}
 )
 
 // Hook changes resources as they are created or updated by the reconciler.
+//
+// Deprecated: use RegisterHook with a ReconcilerHook, which is invoked with every resource of the reconciler.
 type Hook func(*argoproj.ArgoCD, interface{}, string) error
 
+// HookPhase is the phase of the reconciliation of an ArgoCD a ReconcilerHook is invoked in.
+type HookPhase string
+
+const (
+	// HookPhasePreReconcile hooks are invoked with the ArgoCD before its resources are reconciled. An error stops
+	// the reconciliation. Changes made to the ArgoCD are not persisted and may be reverted by the status updates of
+	// the reconciliation, resource hooks should be used to change the resources instead.
+	HookPhasePreReconcile HookPhase = "PreReconcile"
+	// HookPhaseResource hooks are invoked with every resource built by the reconciler, before it is created, updated
+	// or patched. As they are invoked on each update, they must be idempotent.
+	HookPhaseResource HookPhase = "Resource"
+	// HookPhasePostReconcile hooks are invoked with the ArgoCD after its resources are reconciled, including when the
+	// reconciliation failed.
+	HookPhasePostReconcile HookPhase = "PostReconcile"
+)
+
+// HookContext describes an invocation of a ReconcilerHook.
+type HookContext struct {
+	// Phase is the phase of the invocation.
+	Phase HookPhase
+	// Kind is the kind of the resource, such as Deployment, or ArgoCD in the pre and post reconcile phases.
+	Kind string
+	// Component is the app.kubernetes.io/component label of the resource, such as server.
+	Component string
+	// Err is the error of the reconciliation, in the post reconcile phase.
+	Err error
+}
+
+// ReconcilerHook changes the ArgoCD or its resources during their reconciliation.
+type ReconcilerHook struct {
+	// Name identifies the hook. It is used to unregister the hook and to disable it for an ArgoCD with the
+	// argocd.argoproj.io/disable-reconciler-hooks annotation.
+	Name string
+	// Phase is the phase the hook is invoked in, HookPhaseResource by default.
+	Phase HookPhase
+	// Priority orders the hooks of a phase, the lower first. Hooks of the same priority are invoked in the order they
+	// were registered.
+	Priority int
+	// Kinds restricts the hook to the resources of the given kinds, such as Deployment. The hook is invoked with the
+	// resources of every kind when empty.
+	Kinds []string
+	// Components restricts the hook to the resources of the given components, such as server. The hook is invoked
+	// with the resources of every component when empty.
+	Components []string
+	// Enabled restricts the hook to the ArgoCDs it returns true for. The hook is enabled for every ArgoCD when nil.
+	Enabled func(cr *argoproj.ArgoCD) bool
+	// Func changes the given resource of the given ArgoCD, or the ArgoCD itself in the pre and post reconcile phases.
+	// An error stops the invocation of the following hooks and fails the reconciliation.
+	Func func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error
+
+	// legacy is the hook registered with Register, only invoked with the resources of the builders calling
+	// applyReconcilerHook.
+	legacy Hook
+}
+
 // Register adds a modifier for updating resources during reconciliation.
+//
+// Deprecated: use RegisterHook.
 func Register(h ...Hook) {
-	mutex.Lock()
-	defer mutex.Unlock()
-	hooks = append(hooks, h...)
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+	for _, v := range h {
+		hooks = insertHook(hooks, ReconcilerHook{Phase: HookPhaseResource, legacy: v})
+	}
+}
+
+// RegisterHook adds the given hooks to the reconciler. It fails if a hook has no name or function, or if a hook of
+// the same name is already registered.
+func RegisterHook(h ...ReconcilerHook) error {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+	registered := hooks
+	for _, v := range h {
+		if v.Name == "" || v.Func == nil {
+			return fmt.Errorf("reconciler hook %q must have a name and a function", v.Name)
+		}
+		if v.Phase == "" {
+			v.Phase = HookPhaseResource
+		}
+		switch v.Phase {
+		case HookPhasePreReconcile, HookPhaseResource, HookPhasePostReconcile:
+		default:
+			return fmt.Errorf("reconciler hook %q has an unknown phase %q", v.Name, v.Phase)
+		}
+		for _, r := range registered {
+			if r.Name == v.Name {
+				return fmt.Errorf("reconciler hook %q is already registered", v.Name)
+			}
+		}
+		registered = insertHook(registered, v)
+	}
+	hooks = registered
+	return nil
+}
+
+// UnregisterHook removes the hook of the given name from the reconciler. It returns false if no such hook is
+// registered.
+func UnregisterHook(name string) bool {
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+	for i, v := range hooks {
+		if v.Name == name && v.legacy == nil {
+			hooks = append(hooks[:i:i], hooks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// insertHook returns a copy of the given hooks ordered by priority with the given hook added after the hooks of the
+// same priority. The registered hooks are copied so that the invocations in progress are not affected.
+func insertHook(registered []ReconcilerHook, h ReconcilerHook) []ReconcilerHook {
+	result := make([]ReconcilerHook, 0, len(registered)+1)
+	result = append(result, registered...)
+	i := sort.Search(len(result), func(i int) bool { return result[i].Priority > h.Priority })
+	result = append(result, ReconcilerHook{})
+	copy(result[i+1:], result[i:])
+	result[i] = h
+	return result
+}
+
+// hookObjectKind returns the kind of the given resource.
+func hookObjectKind(obj client.Object) string {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.GetKind()
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
 }
 
+// isHookDisabled returns true if the hook of the given name is disabled for the given ArgoCD by its
+// argocd.argoproj.io/disable-reconciler-hooks annotation.
+func isHookDisabled(cr *argoproj.ArgoCD, name string) bool {
+	for _, disabled := range strings.Split(cr.Annotations[common.ArgoCDDisableReconcilerHooksAnnotation], ",") {
+		if strings.TrimSpace(disabled) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the hook applies to the given invocation.
+func (h ReconcilerHook) matches(cr *argoproj.ArgoCD, hc HookContext) bool {
+	if h.Phase != hc.Phase {
+		return false
+	}
+	if len(h.Kinds) > 0 && !containsString(h.Kinds, hc.Kind) {
+		return false
+	}
+	if len(h.Components) > 0 && !containsString(h.Components, hc.Component) {
+		return false
+	}
+	if h.Name != "" && isHookDisabled(cr, h.Name) {
+		return false
+	}
+	return h.Enabled == nil || h.Enabled(cr)
+}
+
+// runHooks invokes the registered hooks matching the given invocation, in order. The hooks registered with Register
+// are not invoked, see applyReconcilerHook.
+func runHooks(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+	hooksMutex.RLock()
+	registered := hooks
+	hooksMutex.RUnlock()
+
+	for _, h := range registered {
+		if h.legacy != nil || !h.matches(cr, hc) {
+			continue
+		}
+		if err := h.Func(cr, obj, hc); err != nil {
+			return fmt.Errorf("reconciler hook %s failed: %w", h.Name, err)
+		}
+	}
+	return nil
+}
+
+// runResourceHooks invokes the resource hooks with the given resource of the given ArgoCD.
+func runResourceHooks(cr *argoproj.ArgoCD, obj client.Object) error {
+	return runHooks(cr, obj, HookContext{
+		Phase:     HookPhaseResource,
+		Kind:      hookObjectKind(obj),
+		Component: obj.GetLabels()[common.ArgoCDKeyComponent],
+	})
+}
+
+// runPhaseHooks invokes the pre or post reconcile hooks with the given ArgoCD.
+func runPhaseHooks(phase HookPhase, cr *argoproj.ArgoCD, reconcileErr error) error {
+	return runHooks(cr, cr, HookContext{Phase: phase, Kind: "ArgoCD", Err: reconcileErr})
+}
+
+// applyReconcilerHook invokes the hooks registered with Register with the given resource of the given ArgoCD. The
+// hooks registered with RegisterHook are invoked by the hookClient when the resource is written, so only once per
+// write.
 // nolint:unparam
-func applyReconcilerHook(cr *argoproj.ArgoCD, i interface{}, hint string) error {
-	mutex.Lock()
-	defer mutex.Unlock()
-	for _, v := range hooks {
-		if err := v(cr, i, hint); err != nil {
+func applyReconcilerHook(cr *argoproj.ArgoCD, obj client.Object, hint string) error {
+	hooksMutex.RLock()
+	registered := hooks
+	hooksMutex.RUnlock()
+
+	for _, h := range registered {
+		if h.legacy == nil {
+			continue
+		}
+		if err := h.legacy(cr, obj, hint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hookClient wraps the reconciler client and invokes the resource hooks with every resource created, updated or
+// patched during the reconciliation of an ArgoCD. The reconcilers compare the live resources with their desired
+// state, built without the changes of the hooks, so an update or patch is only sent when the hooked resource differs
+// from the live one.
+type hookClient struct {
+	client.Client
+	scheme *runtime.Scheme
+	cr     *argoproj.ArgoCD
+}
+
+func newHookClient(c client.Client, scheme *runtime.Scheme, cr *argoproj.ArgoCD) *hookClient {
+	return &hookClient{Client: c, scheme: scheme, cr: cr}
+}
+
+// Create invokes the resource hooks with obj before creating it.
+func (c *hookClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if _, ok := obj.(*argoproj.ArgoCD); !ok {
+		if err := runResourceHooks(c.cr, obj); err != nil {
+			return err
+		}
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Update invokes the resource hooks with obj before updating it, unless the hooked obj doesn't differ from the live
+// resource.
+func (c *hookClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, ok := obj.(*argoproj.ArgoCD); ok {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	if err := runResourceHooks(c.cr, obj); err != nil {
+		return err
+	}
+
+	live, err := getLiveObject(ctx, c.Client, c.scheme, obj)
+	if err != nil {
+		return err
+	}
+	if live != nil {
+		fields, err := diffObjects(live, obj)
+		if err != nil || len(fields) == 0 {
+			return err
+		}
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch invokes the resource hooks with the live resource the patch is applied to, and patches the live resource
+// with the hooked result unless it doesn't differ. Patches whose result can't be computed beforehand, as server side
+// apply, are sent without invoking the hooks.
+func (c *hookClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*argoproj.ArgoCD); ok {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	live, err := getLiveObject(ctx, c.Client, c.scheme, obj)
+	if err != nil {
+		return err
+	}
+	if live == nil || reflect.TypeOf(live) != reflect.TypeOf(obj) {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	desired, err := applyPatch(live, obj, patch)
+	if err != nil {
+		return err
+	}
+	if desired == nil {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	if err := runResourceHooks(c.cr, desired); err != nil {
+		return err
+	}
+
+	fields, err := diffObjects(live, desired)
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		if err := c.Client.Patch(ctx, desired, client.MergeFrom(live), opts...); err != nil {
 			return err
 		}
 	}
+	// obj is left with the patched resource, as it would be by the client
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(desired).Elem())
 	return nil
 }
@@ -1,15 +1,24 @@
 package argocd
 
 import (
+	"context"
 	"errors"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
 var errMsg = errors.New("this is a test error")
@@ -98,3 +107,296 @@ func resetHooks() func() {
 		hooks = origDefaultHooksFunc
 	}
 }
+
+// annotateHook returns an idempotent resource hook appending its name to the hooks annotation of the resources.
+func annotateHook(name string, priority int) ReconcilerHook {
+	return ReconcilerHook{
+		Name:     name,
+		Priority: priority,
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			if !strings.Contains(annotations["hooks"], name+";") {
+				annotations["hooks"] += name + ";"
+			}
+			obj.SetAnnotations(annotations)
+			return nil
+		},
+	}
+}
+
+func TestRegisterHook_Ordering(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	Register(testDeploymentHook)
+	assert.NoError(t, RegisterHook(annotateHook("late", 10), annotateHook("first", -10), annotateHook("default", 0)))
+	assert.NoError(t, RegisterHook(annotateHook("default-2", 0)))
+
+	deployment := makeTestDeployment()
+	assert.NoError(t, runResourceHooks(a, deployment))
+	assert.Equal(t, "first;default;default-2;late;", deployment.Annotations["hooks"])
+	var defaultReplicas int32 = 1
+	assert.Equal(t, &defaultReplicas, deployment.Spec.Replicas)
+
+	// the legacy hooks are only invoked by the builders calling applyReconcilerHook, which doesn't invoke the others
+	deployment = makeTestDeployment()
+	assert.NoError(t, applyReconcilerHook(a, deployment, ""))
+	assert.Empty(t, deployment.Annotations["hooks"])
+	var expectedReplicas int32 = 3
+	assert.Equal(t, &expectedReplicas, deployment.Spec.Replicas)
+}
+
+func TestRegisterHook_Invalid(t *testing.T) {
+	defer resetHooks()()
+
+	assert.NoError(t, RegisterHook(annotateHook("hook", 0)))
+	assert.Error(t, RegisterHook(annotateHook("hook", 1)))
+	assert.Error(t, RegisterHook(ReconcilerHook{Name: "no-func"}))
+	invalid := annotateHook("invalid-phase", 0)
+	invalid.Phase = "Later"
+	assert.Error(t, RegisterHook(invalid))
+
+	// a failed registration doesn't register any of the given hooks
+	assert.Error(t, RegisterHook(annotateHook("valid", 0), annotateHook("hook", 0)))
+	assert.False(t, UnregisterHook("valid"))
+}
+
+func TestRegisterHook_Filters(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	services := annotateHook("services", 0)
+	services.Kinds = []string{"Service"}
+	server := annotateHook("server", 0)
+	server.Components = []string{"server"}
+	ha := annotateHook("ha", 0)
+	ha.Enabled = func(cr *argoproj.ArgoCD) bool { return cr.Spec.HA.Enabled }
+	assert.NoError(t, RegisterHook(services, server, ha, annotateHook("disabled", 0)))
+
+	svc := newServiceWithSuffix("server", "server", a)
+	assert.NoError(t, runResourceHooks(a, svc))
+	assert.Equal(t, "services;server;disabled;", svc.Annotations["hooks"])
+
+	cm := newConfigMapWithName("argocd-cm", a)
+	assert.NoError(t, runResourceHooks(a, cm))
+	assert.Equal(t, "disabled;", cm.Annotations["hooks"])
+
+	// hooks are enabled per instance
+	a.Spec.HA.Enabled = true
+	a.Annotations = map[string]string{common.ArgoCDDisableReconcilerHooksAnnotation: "services, disabled"}
+	svc = newServiceWithSuffix("server", "server", a)
+	assert.NoError(t, runResourceHooks(a, svc))
+	assert.Equal(t, "server;ha;", svc.Annotations["hooks"])
+
+	// and removed
+	assert.True(t, UnregisterHook("server"))
+	assert.False(t, UnregisterHook("server"))
+	svc = newServiceWithSuffix("server", "server", a)
+	assert.NoError(t, runResourceHooks(a, svc))
+	assert.Equal(t, "ha;", svc.Annotations["hooks"])
+}
+
+func TestHookClient(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+
+	secrets := ReconcilerHook{
+		Name:  "secrets",
+		Kinds: []string{"Secret"},
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			obj.(*corev1.Secret).StringData = map[string]string{"hooked": "true"}
+			return nil
+		},
+	}
+	failing := ReconcilerHook{
+		Name:       "failing",
+		Components: []string{"failing"},
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			return errMsg
+		},
+	}
+	assert.NoError(t, RegisterHook(secrets, failing))
+
+	hooked := newHookClient(cl, sch, a)
+	secret := argoutil.NewSecretWithName(a, "argocd-secret")
+	assert.NoError(t, hooked.Create(context.TODO(), secret))
+	assert.Equal(t, "true", secret.StringData["hooked"])
+
+	secret.StringData = nil
+	assert.NoError(t, hooked.Update(context.TODO(), secret))
+	assert.Equal(t, "true", secret.StringData["hooked"])
+
+	// a patch is applied with the changes of the hooks, and skipped when it doesn't change the live resource
+	patched := secret.DeepCopy()
+	patched.StringData = map[string]string{"hooked": "false"}
+	patched.Labels["patched"] = "true"
+	assert.NoError(t, hooked.Patch(context.TODO(), patched, client.MergeFrom(secret)))
+	assert.Equal(t, "true", patched.StringData["hooked"])
+	live := &corev1.Secret{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(secret), live))
+	assert.Equal(t, "true", live.StringData["hooked"])
+	assert.Equal(t, "true", live.Labels["patched"])
+
+	unchanged := live.DeepCopy()
+	unchanged.StringData["hooked"] = "false"
+	assert.NoError(t, hooked.Patch(context.TODO(), unchanged, client.MergeFrom(live)))
+	assert.Equal(t, "true", unchanged.StringData["hooked"])
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(secret), unchanged))
+	assert.Equal(t, live.ResourceVersion, unchanged.ResourceVersion)
+
+	// a failing hook fails the creation
+	svc := newServiceWithSuffix("failing", "failing", a)
+	assert.ErrorIs(t, hooked.Create(context.TODO(), svc), errMsg)
+	assert.Error(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(svc), &corev1.Service{}))
+}
+
+func TestHookClient_invokesHooksOncePerWrite(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+
+	invocations := 0
+	assert.NoError(t, RegisterHook(ReconcilerHook{
+		Name:  "count",
+		Kinds: []string{"Role"},
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			invocations++
+			obj.SetAnnotations(map[string]string{"invocations": strconv.Itoa(invocations)})
+			return nil
+		},
+	}))
+
+	// the builders invoke applyReconcilerHook before writing the resource with the hooked client
+	hooked := newHookClient(cl, sch, a)
+	role := newRole("test", policyRuleForApplicationController(), a)
+	assert.NoError(t, applyReconcilerHook(a, role, ""))
+	assert.NoError(t, hooked.Create(context.TODO(), role))
+	assert.Equal(t, 1, invocations)
+
+	assert.NoError(t, applyReconcilerHook(a, role, ""))
+	assert.NoError(t, hooked.Update(context.TODO(), role))
+	assert.Equal(t, 2, invocations)
+
+	patched := role.DeepCopy()
+	patched.Labels["patched"] = "true"
+	assert.NoError(t, hooked.Patch(context.TODO(), patched, client.MergeFrom(role)))
+	assert.Equal(t, 3, invocations)
+}
+
+func TestReconcileArgoCD_reconcileResources_mutatingHook(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.DriftDetection = &argoproj.ArgoCDDriftDetectionSpec{
+			Enabled: true,
+			Mode:    argoproj.DriftDetectionModeReport,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	// the hook changes the data the argocd-cm builder compares with its desired state
+	assert.NoError(t, RegisterHook(ReconcilerHook{
+		Name:  "argocd-cm",
+		Kinds: []string{"ConfigMap"},
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			if obj.GetName() == common.ArgoCDConfigMapName {
+				obj.(*corev1.ConfigMap).Data["hooked"] = "true"
+			}
+			return nil
+		},
+	}))
+
+	assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "true", cm.Data["hooked"])
+
+	// the hooked resource isn't updated again nor reported as drifted
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, r.reconcileResourcesDetectingDrift(context.TODO(), a))
+		assert.Empty(t, a.Status.DriftedResources)
+		reconciled := &corev1.ConfigMap{}
+		assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(cm), reconciled))
+		assert.Equal(t, cm.ResourceVersion, reconciled.ResourceVersion)
+		assert.Equal(t, "true", reconciled.Data["hooked"])
+	}
+}
+
+func TestReconcileArgoCD_Reconcile_phaseHooks(t *testing.T) {
+	defer resetHooks()()
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+
+	phases := []HookPhase{}
+	record := func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+		assert.Equal(t, "ArgoCD", hc.Kind)
+		assert.NoError(t, hc.Err)
+		phases = append(phases, hc.Phase)
+		return nil
+	}
+	assert.NoError(t, RegisterHook(
+		ReconcilerHook{Name: "post", Phase: HookPhasePostReconcile, Func: record},
+		ReconcilerHook{Name: "pre", Phase: HookPhasePreReconcile, Func: record},
+		annotateHook("resources", 0),
+	))
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, []HookPhase{HookPhasePreReconcile, HookPhasePostReconcile}, phases)
+
+	// the resource hooks are invoked with every resource
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ServiceAccount{}, &v1.Role{}} {
+		name := a.Name + "-server"
+		if _, ok := obj.(*corev1.ServiceAccount); ok {
+			name = a.Name + "-argocd-server"
+		} else if _, ok := obj.(*v1.Role); ok {
+			name = a.Name + "-argocd-server"
+		}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: a.Namespace}, obj))
+		assert.Equal(t, "resources;", obj.GetAnnotations()["hooks"], name)
+	}
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: a.Namespace}, cm))
+	assert.Equal(t, "resources;", cm.Annotations["hooks"])
+
+	// a failing pre reconcile hook stops the reconciliation
+	assert.NoError(t, RegisterHook(ReconcilerHook{
+		Name:  "failing",
+		Phase: HookPhasePreReconcile,
+		Func: func(cr *argoproj.ArgoCD, obj client.Object, hc HookContext) error {
+			return errMsg
+		},
+	}))
+	phases = []HookPhase{}
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.ErrorIs(t, err, errMsg)
+	assert.Equal(t, []HookPhase{HookPhasePreReconcile}, phases)
+}
@@ -28,6 +28,110 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+// ingressBackend is the protocol used between an ingress controller and the backend of an Ingress.
+type ingressBackend string
+
+const (
+	// ingressBackendHTTP is a plain HTTP backend.
+	ingressBackendHTTP ingressBackend = "http"
+
+	// ingressBackendGRPC is a GRPC backend using TLS.
+	ingressBackendGRPC ingressBackend = "grpc"
+
+	// ingressBackendPassthrough forwards the TLS connections to the backend, allowing it to serve both
+	// HTTP and GRPC on the same host.
+	ingressBackendPassthrough ingressBackend = "passthrough"
+)
+
+// ingressControllerProfiles are the default Ingress annotations of each supported ingress controller, for
+// each backend protocol. A profile without a GRPC entry has no default annotations for the GRPC Ingress, as the
+// controller reads the backend protocol from the Service, and a profile without a passthrough entry does not
+// support GRPC multiplexing.
+var ingressControllerProfiles = map[argoproj.IngressControllerProfile]map[ingressBackend]map[string]string{
+	argoproj.IngressControllerProfileNginx: {
+		ingressBackendHTTP: {
+			common.ArgoCDKeyIngressSSLRedirect:     "true",
+			common.ArgoCDKeyIngressBackendProtocol: "HTTP",
+		},
+		ingressBackendGRPC: {
+			common.ArgoCDKeyIngressBackendProtocol: "GRPC",
+		},
+		ingressBackendPassthrough: {
+			common.ArgoCDKeyIngressSSLRedirect:    "true",
+			common.ArgoCDKeyIngressSSLPassthrough: "true",
+		},
+	},
+	argoproj.IngressControllerProfileHAProxy: {
+		ingressBackendHTTP: {
+			"haproxy.org/ssl-redirect": "true",
+		},
+		ingressBackendGRPC: {
+			"haproxy.org/server-ssl":   "true",
+			"haproxy.org/server-proto": "h2",
+		},
+		ingressBackendPassthrough: {
+			"haproxy.org/ssl-passthrough": "true",
+		},
+	},
+	argoproj.IngressControllerProfileTraefik: {
+		ingressBackendHTTP: {
+			"traefik.ingress.kubernetes.io/router.entrypoints": "websecure",
+			"traefik.ingress.kubernetes.io/router.tls":         "true",
+		},
+	},
+	argoproj.IngressControllerProfileContour: {
+		ingressBackendHTTP: {
+			"ingress.kubernetes.io/force-ssl-redirect": "true",
+		},
+	},
+	argoproj.IngressControllerProfileALB: {
+		ingressBackendHTTP: {
+			"alb.ingress.kubernetes.io/backend-protocol": "HTTP",
+			"alb.ingress.kubernetes.io/listen-ports":     `[{"HTTP": 80}, {"HTTPS": 443}]`,
+			"alb.ingress.kubernetes.io/ssl-redirect":     "443",
+			"alb.ingress.kubernetes.io/target-type":      "ip",
+		},
+	},
+}
+
+// supportsGRPCMultiplexing returns true if the controller profile of the given Ingress spec can serve HTTP and
+// GRPC on the same host.
+func supportsGRPCMultiplexing(spec argoproj.ArgoCDIngressSpec) bool {
+	_, ok := ingressControllerProfiles[getIngressControllerProfile(spec)][ingressBackendPassthrough]
+	return ok
+}
+
+// getIngressControllerProfile returns the controller profile of the given Ingress spec, nginx by default.
+func getIngressControllerProfile(spec argoproj.ArgoCDIngressSpec) argoproj.IngressControllerProfile {
+	if spec.ControllerProfile == "" {
+		return argoproj.IngressControllerProfileNginx
+	}
+	return spec.ControllerProfile
+}
+
+// getIngressAnnotations returns the annotations of an Ingress using the given backend protocol, the user
+// annotations merged on top of the defaults of the controller profile. Without a controller profile, the user
+// annotations replace the nginx defaults as they did before the profiles were introduced, except for the
+// passthrough annotations GRPC multiplexing requires.
+func getIngressAnnotations(spec argoproj.ArgoCDIngressSpec, backend ingressBackend) map[string]string {
+	if spec.ControllerProfile == "" && len(spec.Annotations) > 0 && backend != ingressBackendPassthrough {
+		atns := make(map[string]string, len(spec.Annotations))
+		for key, val := range spec.Annotations {
+			atns[key] = val
+		}
+		return atns
+	}
+
+	atns := make(map[string]string)
+	for key, val := range ingressControllerProfiles[getIngressControllerProfile(spec)][backend] {
+		atns[key] = val
+	}
+	for key, val := range spec.Annotations {
+		atns[key] = val
+	}
+	return atns
+}
+
 // getArgoServerPath will return the Ingress Path for the Argo CD component.
 func getPathOrDefault(path string) string {
 	result := common.ArgoCDDefaultIngressPath
@@ -104,25 +208,28 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 		return nil // Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Server.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.Ingress.Annotations
+	// GRPC multiplexing forwards the TLS connections to the HTTPS port of argocd-server
+	backend, port := ingressBackendHTTP, "http"
+	if cr.Spec.Server.Ingress.GRPCMultiplexing {
+		if supportsGRPCMultiplexing(cr.Spec.Server.Ingress) {
+			backend, port = ingressBackendPassthrough, "https"
+		} else {
+			log.Info(fmt.Sprintf("GRPC multiplexing is not supported by the %s ingress controller profile, ignoring it",
+				getIngressControllerProfile(cr.Spec.Server.Ingress)))
+		}
 	}
 
-	ingress.ObjectMeta.Annotations = atns
+	ingress.ObjectMeta.Annotations = getIngressAnnotations(cr.Spec.Server.Ingress, backend)
 
 	ingress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
 
 	pathType := networkingv1.PathTypeImplementationSpecific
-	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerHost(cr),
+	// Add a rule for each host
+	hosts := getArgoServerHosts(cr)
+	ingress.Spec.Rules = make([]networkingv1.IngressRule, 0, len(hosts))
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host: host,
 			IngressRuleValue: networkingv1.IngressRuleValue{
 				HTTP: &networkingv1.HTTPIngressRuleValue{
 					Paths: []networkingv1.HTTPIngressPath{
@@ -132,7 +239,7 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 								Service: &networkingv1.IngressServiceBackend{
 									Name: nameWithSuffix("server", cr),
 									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
+										Name: port,
 									},
 								},
 							},
@@ -141,15 +248,13 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 					},
 				},
 			},
-		},
+		})
 	}
 
 	// Add default TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerHost(cr),
-			},
+			Hosts:      hosts,
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
@@ -165,9 +270,9 @@ func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoproj.ArgoCD) error
 			changed = true
 			existingIngress.Spec.IngressClassName = cr.Spec.Server.Ingress.IngressClassName
 		}
-		if !reflect.DeepEqual(cr.Spec.Server.Ingress.Annotations, existingIngress.ObjectMeta.Annotations) {
+		if !reflect.DeepEqual(ingress.ObjectMeta.Annotations, existingIngress.ObjectMeta.Annotations) {
 			changed = true
-			existingIngress.ObjectMeta.Annotations = cr.Spec.Server.Ingress.Annotations
+			existingIngress.ObjectMeta.Annotations = ingress.ObjectMeta.Annotations
 		}
 		if !reflect.DeepEqual(ingress.Spec.Rules, existingIngress.Spec.Rules) {
 			changed = true
@@ -203,16 +308,11 @@ func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoproj.ArgoCD) er
 		return nil // Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "GRPC"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Server.GRPC.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.GRPC.Ingress.Annotations
+	if _, ok := ingressControllerProfiles[getIngressControllerProfile(cr.Spec.Server.GRPC.Ingress)][ingressBackendGRPC]; !ok {
+		log.Info(fmt.Sprintf("The %s ingress controller profile has no GRPC defaults, the GRPC backend protocol must be configured with the Ingress annotations",
+			getIngressControllerProfile(cr.Spec.Server.GRPC.Ingress)))
 	}
-
-	ingress.ObjectMeta.Annotations = atns
+	ingress.ObjectMeta.Annotations = getIngressAnnotations(cr.Spec.Server.GRPC.Ingress, ingressBackendGRPC)
 
 	ingress.Spec.IngressClassName = cr.Spec.Server.GRPC.Ingress.IngressClassName
 
@@ -301,17 +401,7 @@ func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoproj.ArgoCD) error
 		return nil // Prometheus itself or Ingress not enabled, move along...
 	}
 
-	// Add default annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Prometheus.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Prometheus.Ingress.Annotations
-	}
-
-	ingress.ObjectMeta.Annotations = atns
+	ingress.ObjectMeta.Annotations = getIngressAnnotations(cr.Spec.Prometheus.Ingress, ingressBackendHTTP)
 
 	ingress.Spec.IngressClassName = cr.Spec.Prometheus.Ingress.IngressClassName
 
@@ -375,17 +465,7 @@ func (r *ReconcileArgoCD) reconcileApplicationSetControllerIngress(cr *argoproj.
 		return nil // Ingress not enabled, move along...
 	}
 
-	// Add annotations
-	atns := make(map[string]string)
-	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
-	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.ApplicationSet.WebhookServer.Ingress.Annotations) > 0 {
-		atns = cr.Spec.ApplicationSet.WebhookServer.Ingress.Annotations
-	}
-
-	ingress.ObjectMeta.Annotations = atns
+	ingress.ObjectMeta.Annotations = getIngressAnnotations(cr.Spec.ApplicationSet.WebhookServer.Ingress, ingressBackendHTTP)
 
 	pathType := networkingv1.PathTypeImplementationSpecific
 	httpServerHost, err := getApplicationSetHTTPServerHost(cr)
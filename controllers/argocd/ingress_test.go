@@ -124,6 +124,38 @@ func TestReconcileArgoCD_reconcile_ServerIngress_serverHost(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_reconcile_ServerIngress_additionalHosts(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Host = "argocd.internal"
+		a.Spec.Server.AdditionalHosts = []string{"argocd.example.com"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+	ingress := &networkingv1.Ingress{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "argocd.internal", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, "argocd.example.com", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, []string{"argocd.internal", "argocd.example.com"}, ingress.Spec.TLS[0].Hosts)
+
+	// removed hosts are removed from the Ingress
+	a.Spec.Server.AdditionalHosts = nil
+	assert.NoError(t, r.reconcileArgoServerIngress(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, []string{"argocd.internal"}, ingress.Spec.TLS[0].Hosts)
+}
+
 func TestReconcileArgoCD_reconcile_ServerIngress_ingressClassName_update(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 
@@ -286,3 +318,167 @@ func TestReconcileApplicationSetService_Ingress(t *testing.T) {
 	assert.NoError(t, r.reconcileApplicationSetControllerIngress(a))
 	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}, ingress))
 }
+
+func TestGetIngressAnnotations(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    argoproj.ArgoCDIngressSpec
+		backend ingressBackend
+		want    map[string]string
+	}{
+		{
+			name:    "nginx defaults without controller profile",
+			backend: ingressBackendHTTP,
+			want: map[string]string{
+				common.ArgoCDKeyIngressSSLRedirect:     "true",
+				common.ArgoCDKeyIngressBackendProtocol: "HTTP",
+			},
+		},
+		{
+			name:    "user annotations replace the defaults without controller profile",
+			spec:    argoproj.ArgoCDIngressSpec{Annotations: map[string]string{"foo": "bar"}},
+			backend: ingressBackendHTTP,
+			want:    map[string]string{"foo": "bar"},
+		},
+		{
+			name:    "user annotations replace the GRPC defaults without controller profile",
+			spec:    argoproj.ArgoCDIngressSpec{Annotations: map[string]string{"foo": "bar"}},
+			backend: ingressBackendGRPC,
+			want:    map[string]string{"foo": "bar"},
+		},
+		{
+			name:    "user annotations are merged on top of the passthrough defaults without controller profile",
+			spec:    argoproj.ArgoCDIngressSpec{Annotations: map[string]string{"foo": "bar"}},
+			backend: ingressBackendPassthrough,
+			want: map[string]string{
+				"foo":                                 "bar",
+				common.ArgoCDKeyIngressSSLRedirect:    "true",
+				common.ArgoCDKeyIngressSSLPassthrough: "true",
+			},
+		},
+		{
+			name: "user annotations are merged on top of the nginx defaults with the nginx controller profile",
+			spec: argoproj.ArgoCDIngressSpec{
+				ControllerProfile: argoproj.IngressControllerProfileNginx,
+				Annotations:       map[string]string{"foo": "bar", common.ArgoCDKeyIngressSSLRedirect: "false"},
+			},
+			backend: ingressBackendHTTP,
+			want: map[string]string{
+				"foo":                                  "bar",
+				common.ArgoCDKeyIngressSSLRedirect:     "false",
+				common.ArgoCDKeyIngressBackendProtocol: "HTTP",
+			},
+		},
+		{
+			name: "user annotations are merged on top of the controller profile",
+			spec: argoproj.ArgoCDIngressSpec{
+				ControllerProfile: argoproj.IngressControllerProfileHAProxy,
+				Annotations:       map[string]string{"foo": "bar", "haproxy.org/server-proto": "h2c"},
+			},
+			backend: ingressBackendGRPC,
+			want: map[string]string{
+				"foo":                      "bar",
+				"haproxy.org/server-ssl":   "true",
+				"haproxy.org/server-proto": "h2c",
+			},
+		},
+		{
+			name: "only user annotations for a GRPC backend of a profile without GRPC defaults",
+			spec: argoproj.ArgoCDIngressSpec{
+				ControllerProfile: argoproj.IngressControllerProfileALB,
+				Annotations:       map[string]string{"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC"},
+			},
+			backend: ingressBackendGRPC,
+			want:    map[string]string{"alb.ingress.kubernetes.io/backend-protocol-version": "GRPC"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, getIngressAnnotations(test.spec, test.backend))
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcile_ServerIngress_grpcMultiplexing(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	tests := []struct {
+		name        string
+		profile     argoproj.IngressControllerProfile
+		wantPort    string
+		wantAtnsKey string
+	}{
+		{
+			name:        "nginx passthrough",
+			profile:     argoproj.IngressControllerProfileNginx,
+			wantPort:    "https",
+			wantAtnsKey: common.ArgoCDKeyIngressSSLPassthrough,
+		},
+		{
+			name:        "haproxy passthrough",
+			profile:     argoproj.IngressControllerProfileHAProxy,
+			wantPort:    "https",
+			wantAtnsKey: "haproxy.org/ssl-passthrough",
+		},
+		{
+			name:        "traefik does not support multiplexing",
+			profile:     argoproj.IngressControllerProfileTraefik,
+			wantPort:    "http",
+			wantAtnsKey: "traefik.ingress.kubernetes.io/router.tls",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Enabled = true
+				a.Spec.Server.Ingress.ControllerProfile = test.profile
+				a.Spec.Server.Ingress.GRPCMultiplexing = true
+			})
+
+			resObjs := []client.Object{a}
+			subresObjs := []client.Object{a}
+			runtimeObjs := []runtime.Object{}
+			sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+			cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+			r := makeTestReconciler(cl, sch)
+
+			assert.NoError(t, r.reconcileArgoServerIngress(a))
+
+			ingress := &networkingv1.Ingress{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, ingress))
+			assert.Equal(t, test.wantPort, ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+			assert.Contains(t, ingress.Annotations, test.wantAtnsKey)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcile_Ingresses_annotationsWithoutControllerProfile(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	// the annotations of instances created before the controller profiles are kept on upgrade
+	annotations := map[string]string{"kubernetes.io/ingress.class": "custom"}
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+		a.Spec.Server.Ingress.Annotations = annotations
+		a.Spec.Server.GRPC.Ingress.Enabled = true
+		a.Spec.Server.GRPC.Ingress.Annotations = annotations
+		a.Spec.Prometheus.Enabled = true
+		a.Spec.Prometheus.Ingress.Enabled = true
+		a.Spec.Prometheus.Ingress.Annotations = annotations
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileIngresses(a))
+	for _, name := range []string{"argocd-server", "argocd-grpc", "argocd-prometheus"} {
+		ingress := &networkingv1.Ingress{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ingress))
+		assert.Equal(t, annotations, ingress.Annotations, name)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// componentWorkload is the workload deploying a component of an instance.
+type componentWorkload struct {
+	component   string
+	suffix      string
+	statefulSet bool
+}
+
+// getComponentWorkloads returns the workloads deploying the components of the given instance.
+func getComponentWorkloads(cr *argoproj.ArgoCD) []componentWorkload {
+	redis := componentWorkload{component: "redis", suffix: "redis"}
+	if cr.Spec.HA.Enabled {
+		redis = componentWorkload{component: "redis", suffix: "redis-ha-server", statefulSet: true}
+	}
+	return []componentWorkload{
+		{component: "application-controller", suffix: "application-controller", statefulSet: true},
+		{component: "applicationset-controller", suffix: "applicationset-controller"},
+		{component: "dex", suffix: "dex-server"},
+		{component: "notifications-controller", suffix: "notifications-controller"},
+		redis,
+		{component: "repo-server", suffix: "repo-server", statefulSet: useRepoCache(cr)},
+		{component: "server", suffix: "server"},
+	}
+}
+
+// getComponentStatuses returns the status of the components of the given instance.
+func getComponentStatuses(cr *argoproj.ArgoCD) map[string]string {
+	return map[string]string{
+		"application-controller":    cr.Status.ApplicationController,
+		"applicationset-controller": cr.Status.ApplicationSetController,
+		"notifications-controller":  cr.Status.NotificationsController,
+		"redis":                     cr.Status.Redis,
+		"repo-server":               cr.Status.Repo,
+		"server":                    cr.Status.Server,
+		"sso":                       cr.Status.SSO,
+	}
+}
+
+// getTLSSecretNames returns the names of the secrets holding the TLS certificates of the given instance.
+func getTLSSecretNames(cr *argoproj.ArgoCD) []string {
+	return []string{
+		nameWithSuffix(common.ArgoCDCASuffix, cr),
+		common.ArgoCDServerTLSSecretName,
+		common.ArgoCDRepoServerTLSSecretName,
+		common.ArgoCDRedisServerTLSSecretName,
+	}
+}
+
+// updateInstanceMetrics updates the metrics describing the given instance after its reconciliation.
+func (r *ReconcileArgoCD) updateInstanceMetrics(cr *argoproj.ArgoCD) {
+	instance := prometheus.Labels{"namespace": cr.Namespace, "name": cr.Name}
+
+	for component, status := range getComponentStatuses(cr) {
+		if status == "" {
+			ComponentReady.DeleteLabelValues(cr.Namespace, cr.Name, component)
+			continue
+		}
+		ready := 0.0
+		if status == "Running" {
+			ready = 1
+		}
+		ComponentReady.WithLabelValues(cr.Namespace, cr.Name, component).Set(ready)
+	}
+
+	for _, workload := range getComponentWorkloads(cr) {
+		// a single image is reported per component
+		ComponentInfo.DeletePartialMatch(prometheus.Labels{"namespace": cr.Namespace, "name": cr.Name, "component": workload.component})
+		if image := r.getDeployedImage(cr, workload); image != "" {
+			ComponentInfo.WithLabelValues(cr.Namespace, cr.Name, workload.component, image).Set(1)
+		}
+	}
+
+	if r.ManagedNamespaces != nil {
+		ManagedNamespaces.With(instance).Set(float64(len(r.ManagedNamespaces.Items)))
+	}
+	SourceNamespaces.With(instance).Set(float64(len(r.ManagedSourceNamespaces)))
+
+	if clusterSecrets, err := r.getClusterSecrets(cr); err != nil {
+		log.Error(err, "failed to list the cluster secrets for metrics", "namespace", cr.Namespace, "name", cr.Name)
+	} else {
+		ClusterSecrets.With(instance).Set(float64(len(clusterSecrets.Items)))
+	}
+	ControllerShards.With(instance).Set(float64(r.getApplicationControllerReplicaCount(cr)))
+
+	for _, name := range getTLSSecretNames(cr) {
+		secret := &corev1.Secret{}
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, name, secret) {
+			CertificateExpiry.DeleteLabelValues(cr.Namespace, cr.Name, name)
+			continue
+		}
+		cert, err := argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			CertificateExpiry.DeleteLabelValues(cr.Namespace, cr.Name, name)
+			continue
+		}
+		CertificateExpiry.WithLabelValues(cr.Namespace, cr.Name, name).Set(float64(cert.NotAfter.Unix()))
+	}
+}
+
+// getDeployedImage returns the image of the first container of the given workload of the given instance, or an
+// empty string if the workload isn't deployed.
+func (r *ReconcileArgoCD) getDeployedImage(cr *argoproj.ArgoCD, workload componentWorkload) string {
+	var obj client.Object
+	var podSpec *corev1.PodSpec
+	if workload.statefulSet {
+		ss := &appsv1.StatefulSet{}
+		obj, podSpec = ss, &ss.Spec.Template.Spec
+	} else {
+		deploy := &appsv1.Deployment{}
+		obj, podSpec = deploy, &deploy.Spec.Template.Spec
+	}
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, nameWithSuffix(workload.suffix, cr), obj) || len(podSpec.Containers) == 0 {
+		return ""
+	}
+	return podSpec.Containers[0].Image
+}
+
+// deleteInstanceMetrics removes the metrics describing the given instance.
+func deleteInstanceMetrics(cr *argoproj.ArgoCD) {
+	for _, m := range instanceMetrics {
+		m.DeletePartialMatch(prometheus.Labels{"namespace": cr.Namespace, "name": cr.Name})
+	}
+}
@@ -0,0 +1,99 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestReconcileStep_CountsErrors(t *testing.T) {
+	a := makeTestArgoCD()
+	defer deleteInstanceMetrics(a)
+
+	r := makeTestReconciler(makeTestReconcilerClient(makeTestReconcilerScheme(argoproj.AddToScheme), []client.Object{a}, []client.Object{a}, []runtime.Object{}), makeTestReconcilerScheme(argoproj.AddToScheme))
+	deleteInstanceMetrics(a)
+	assert.NoError(t, r.reconcileStep(context.TODO(), a, "roles", func() error { return nil }))
+	assert.False(t, ReconcileErrors.DeleteLabelValues(a.Namespace, a.Name, "roles"))
+
+	assert.Error(t, r.reconcileStep(context.TODO(), a, "roles", func() error { return errors.New("failed") }))
+	assert.Error(t, r.reconcileStep(context.TODO(), a, "roles", func() error { return errors.New("failed") }))
+	assert.Equal(t, 2.0, testutil.ToFloat64(ReconcileErrors.WithLabelValues(a.Namespace, a.Name, "roles")))
+}
+
+func TestReconcileArgoCD_updateInstanceMetrics(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, r.setManagedNamespaces(a))
+	assert.NoError(t, r.setManagedSourceNamespaces(a))
+	assert.NoError(t, r.reconcileResources(context.TODO(), a))
+
+	a.Status.Server = "Running"
+	a.Status.Repo = "Pending"
+	r.updateInstanceMetrics(a)
+	assert.Equal(t, 1.0, testutil.ToFloat64(ComponentReady.WithLabelValues(a.Namespace, a.Name, "server")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(ComponentReady.WithLabelValues(a.Namespace, a.Name, "repo-server")))
+
+	image := getArgoContainerImage(a)
+	assert.Equal(t, 1.0, testutil.ToFloat64(ComponentInfo.WithLabelValues(a.Namespace, a.Name, "server", image)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ManagedNamespaces.WithLabelValues(a.Namespace, a.Name)))
+	assert.Equal(t, 0.0, testutil.ToFloat64(SourceNamespaces.WithLabelValues(a.Namespace, a.Name)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(ControllerShards.WithLabelValues(a.Namespace, a.Name)))
+
+	caName := nameWithSuffix(common.ArgoCDCASuffix, a)
+	assert.Greater(t, testutil.ToFloat64(CertificateExpiry.WithLabelValues(a.Namespace, a.Name, caName)), 0.0)
+
+	// a changed image replaces the reported one
+	deploy := newDeploymentWithSuffix("server", "server", a)
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(deploy), deploy))
+	deploy.Spec.Template.Spec.Containers[0].Image = "quay.io/example/argocd:v2.99.0"
+	assert.NoError(t, r.Client.Update(context.TODO(), deploy))
+	r.updateInstanceMetrics(a)
+	assert.Equal(t, 1.0, testutil.ToFloat64(ComponentInfo.WithLabelValues(a.Namespace, a.Name, "server", "quay.io/example/argocd:v2.99.0")))
+	assert.False(t, ComponentInfo.DeleteLabelValues(a.Namespace, a.Name, "server", image))
+
+	// the metrics of a deleted instance are removed
+	deleteInstanceMetrics(a)
+	assert.False(t, ComponentReady.DeleteLabelValues(a.Namespace, a.Name, "server"))
+	assert.False(t, ComponentInfo.DeleteLabelValues(a.Namespace, a.Name, "server", "quay.io/example/argocd:v2.99.0"))
+	assert.False(t, CertificateExpiry.DeleteLabelValues(a.Namespace, a.Name, caName))
+	assert.False(t, ManagedNamespaces.DeleteLabelValues(a.Namespace, a.Name))
+}
+
+func TestReconcileArgoCD_updateInstanceMetrics_repoCache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{Enabled: true}
+	})
+	defer deleteInstanceMetrics(a)
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	// the repo server runs as a StatefulSet when the cache is enabled
+	r.updateInstanceMetrics(a)
+	assert.Equal(t, 1.0, testutil.ToFloat64(ComponentInfo.WithLabelValues(a.Namespace, a.Name, "repo-server", getRepoServerContainerImage(a))))
+}
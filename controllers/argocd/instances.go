@@ -0,0 +1,101 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// activeInstance returns the ArgoCD managing the namespace of the given ArgoCDs, or nil if there are none. As the
+// resources of an Argo CD instance, such as argocd-cm, have fixed names, only the oldest ArgoCD of a namespace is
+// reconciled. The ArgoCDs not matching the label selector of the reconciler, left to another operator, and the
+// ArgoCDs being deleted don't manage the namespace, except the reconciled ArgoCD of the given name so that an active
+// ArgoCD being deleted removes its resources.
+func (r *ReconcileArgoCD) activeInstance(argocds []argoproj.ArgoCD, reconciled string) *argoproj.ArgoCD {
+	// an invalid label selector fails every reconciliation
+	selector, err := labels.Parse(r.LabelSelector)
+	if err != nil {
+		return nil
+	}
+
+	var active *argoproj.ArgoCD
+	for i := range argocds {
+		a := &argocds[i]
+		if !selector.Matches(labels.Set(a.Labels)) || (a.GetDeletionTimestamp() != nil && a.Name != reconciled) {
+			continue
+		}
+		if active == nil || a.CreationTimestamp.Before(&active.CreationTimestamp) ||
+			(a.CreationTimestamp.Equal(&active.CreationTimestamp) && a.Name < active.Name) {
+			active = a
+		}
+	}
+	return active
+}
+
+// getActiveInstance returns the ArgoCD managing the namespace of the given ArgoCD, or nil if there is none.
+func (r *ReconcileArgoCD) getActiveInstance(cr *argoproj.ArgoCD) (*argoproj.ArgoCD, error) {
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+		return nil, fmt.Errorf("failed to list the ArgoCDs of namespace %s: %w", cr.Namespace, err)
+	}
+	return r.activeInstance(argocds.Items, cr.Name), nil
+}
+
+// reconcileConflictedCondition sets the Conflicted condition of the given ArgoCD when another ArgoCD is managing its
+// namespace, and removes it otherwise. It returns true if the ArgoCD is conflicted.
+func (r *ReconcileArgoCD) reconcileConflictedCondition(cr *argoproj.ArgoCD) (bool, error) {
+	active, err := r.getActiveInstance(cr)
+	if err != nil {
+		return false, err
+	}
+
+	if active == nil || active.Name == cr.Name {
+		if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionConflicted) == nil {
+			return false, nil
+		}
+		log.Info(fmt.Sprintf("ArgoCD %s is no longer conflicted in namespace %s", cr.Name, cr.Namespace))
+		return false, r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+			meta.RemoveStatusCondition(&status.Conditions, argoproj.ArgoCDConditionConflicted)
+		})
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionConflicted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MultipleInstances",
+		Message:            fmt.Sprintf("ArgoCD %s is already managing namespace %s, only one ArgoCD is supported per namespace", active.Name, cr.Namespace),
+		ObservedGeneration: cr.Generation,
+	}
+	if existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return true, nil
+	}
+
+	if err := argoutil.CreateEvent(r.Client, "Warning", "Conflicted", condition.Message, "MultipleInstances", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event for conflicted ArgoCD", "name", cr.Name, "namespace", cr.Namespace)
+	}
+	return true, r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		meta.SetStatusCondition(&status.Conditions, condition)
+	})
+}
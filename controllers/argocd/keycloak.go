@@ -20,8 +20,10 @@ import (
 	b64 "encoding/base64"
 	json "encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
+	"strings"
 
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -47,6 +49,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -66,6 +69,8 @@ const (
 	keycloakClient = "argocd"
 	// Keycloak realm for Argo CD.
 	keycloakRealm = "argocd"
+	// Annotation recording the redirect URIs registered for the Argo CD client of the keycloak realm.
+	keycloakRedirectURIsAnnotation = "argocd.argoproj.io/realm-redirect-uris"
 	// Identifier for Keycloak.
 	defaultKeycloakIdentifier = "keycloak"
 	// Identifier for TemplateInstance and Template.
@@ -87,6 +92,9 @@ var (
 	portTLS           int32 = 8443
 	httpPort          int32 = 8080
 	controllerRef     bool  = true
+
+	// updateKeycloakRealmClient updates the Argo CD client of the keycloak realm, replaced in tests.
+	updateKeycloakRealmClient = updateRealmClient
 )
 
 // getKeycloakContainerImage will return the container image for the Keycloak.
@@ -740,7 +748,7 @@ func (r *ReconcileArgoCD) prepareKeycloakConfig(cr *argoproj.ArgoCD) (*keycloakC
 	if err != nil {
 		return nil, err
 	}
-	aRouteURL := fmt.Sprintf("https://%s", existingArgoCDRoute.Spec.Host)
+	aRouteURL := getArgoServerExternalURL(cr, existingArgoCDRoute.Spec.Host)
 
 	// Get keycloak Secret for credentials. credentials are required to authenticate with keycloak.
 	existingSecret := &corev1.Secret{
@@ -773,14 +781,15 @@ func (r *ReconcileArgoCD) prepareKeycloakConfig(cr *argoproj.ArgoCD) (*keycloakC
 	}
 
 	cfg := &keycloakConfig{
-		ArgoName:           cr.Name,
-		ArgoNamespace:      cr.Namespace,
-		Username:           string(username),
-		Password:           string(password),
-		KeycloakURL:        kRouteURL,
-		ArgoCDURL:          aRouteURL,
-		KeycloakServerCert: serverCert,
-		VerifyTLS:          tlsVerification,
+		ArgoName:             cr.Name,
+		ArgoNamespace:        cr.Namespace,
+		Username:             string(username),
+		Password:             string(password),
+		KeycloakURL:          kRouteURL,
+		ArgoCDURL:            aRouteURL,
+		ArgoCDAdditionalURLs: r.getArgoServerAdditionalURLs(cr),
+		KeycloakServerCert:   serverCert,
+		VerifyTLS:            tlsVerification,
 	}
 
 	return cfg, nil
@@ -817,21 +826,88 @@ func (r *ReconcileArgoCD) prepareKeycloakConfigForK8s(cr *argoproj.ArgoCD) (*key
 	if err != nil {
 		return nil, err
 	}
-	aIngURL := fmt.Sprintf("https://%s", existingArgoCDIng.Spec.Rules[0].Host)
+	aIngURL := getArgoServerExternalURL(cr, existingArgoCDIng.Spec.Rules[0].Host)
 
 	cfg := &keycloakConfig{
-		ArgoName:      cr.Name,
-		ArgoNamespace: cr.Namespace,
-		Username:      defaultKeycloakAdminUser,
-		Password:      defaultKeycloakAdminPassword,
-		KeycloakURL:   kIngURL,
-		ArgoCDURL:     aIngURL,
-		VerifyTLS:     false,
+		ArgoName:             cr.Name,
+		ArgoNamespace:        cr.Namespace,
+		Username:             defaultKeycloakAdminUser,
+		Password:             defaultKeycloakAdminPassword,
+		KeycloakURL:          kIngURL,
+		ArgoCDURL:            aIngURL,
+		ArgoCDAdditionalURLs: r.getArgoServerAdditionalURLs(cr),
+		VerifyTLS:            false,
 	}
 
 	return cfg, nil
 }
 
+// getKeycloakClientURLs returns the URLs Argo CD is exposed under, starting with its URL.
+func getKeycloakClientURLs(cfg *keycloakConfig) []string {
+	urls := []string{cfg.ArgoCDURL}
+	for _, u := range cfg.ArgoCDAdditionalURLs {
+		if !contains(urls, u) {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// getKeycloakClientRedirectURIs returns the redirect URIs of the Argo CD keycloak client, one for each URL Argo CD is
+// exposed under.
+func getKeycloakClientRedirectURIs(cfg *keycloakConfig) []string {
+	uris := make([]string, 0)
+	for _, u := range getKeycloakClientURLs(cfg) {
+		uris = append(uris, fmt.Sprintf("%s/%s", u, "auth/callback"))
+	}
+	return uris
+}
+
+// getKeycloakClientWebOrigins returns the web origins of the Argo CD keycloak client.
+func getKeycloakClientWebOrigins(cfg *keycloakConfig) []string {
+	origins := make([]string, 0)
+	for _, u := range getKeycloakClientURLs(cfg) {
+		if origin := getURLOrigin(u); !contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// getURLOrigin returns the scheme and host of the given URL, as expected in the web origins of a keycloak client.
+func getURLOrigin(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+// reconcileKeycloakClientRedirectURIs will ensure that the redirect URIs of the Argo CD client of the keycloak realm
+// are in sync with the URLs Argo CD is exposed under. The registered redirect URIs are recorded in an annotation of
+// the given keycloak workload, to only update the realm when they change.
+func (r *ReconcileArgoCD) reconcileKeycloakClientRedirectURIs(cfg *keycloakConfig, workload client.Object) error {
+	annotations := workload.GetAnnotations()
+	if annotations["argocd.argoproj.io/realm-created"] != "true" {
+		return nil // The realm is created with the redirect URIs, move along...
+	}
+
+	uris := strings.Join(getKeycloakClientRedirectURIs(cfg), ",")
+	if annotations[keycloakRedirectURIsAnnotation] == uris {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Updating the redirect URIs of the keycloak client for ArgoCD %s in namespace %s",
+		cfg.ArgoName, cfg.ArgoNamespace))
+	if err := updateKeycloakRealmClient(cfg); err != nil {
+		return err
+	}
+
+	annotations[keycloakRedirectURIsAnnotation] = uris
+	workload.SetAnnotations(annotations)
+	return r.Client.Update(context.TODO(), workload)
+}
+
 // creates a keycloak realm configuration which when posted to keycloak using http client creates a keycloak realm.
 func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 
@@ -847,9 +923,8 @@ func createRealmConfig(cfg *keycloakConfig) ([]byte, error) {
 				AdminURL:                cfg.ArgoCDURL,
 				ClientAuthenticatorType: "client-secret",
 				Secret:                  oAuthClientSecret,
-				RedirectUris: []string{fmt.Sprintf("%s/%s",
-					cfg.ArgoCDURL, "auth/callback")},
-				WebOrigins: []string{cfg.ArgoCDURL},
+				RedirectUris:            getKeycloakClientRedirectURIs(cfg),
+				WebOrigins:              getKeycloakClientWebOrigins(cfg),
 				DefaultClientScopes: []string{
 					"web-origins",
 					"role_list",
@@ -1398,6 +1473,7 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 				}
 
 				existingDC.Annotations["argocd.argoproj.io/realm-created"] = "true"
+				existingDC.Annotations[keycloakRedirectURIsAnnotation] = strings.Join(getKeycloakClientRedirectURIs(cfg), ",")
 				err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 					return r.Client.Update(context.TODO(), existingDC)
 				})
@@ -1409,6 +1485,11 @@ func (r *ReconcileArgoCD) reconcileKeycloakForOpenShift(cr *argoproj.ArgoCD) err
 			}
 		}
 
+		// Registers the URLs Argo CD is exposed under when they change after the realm is created.
+		if err := r.reconcileKeycloakClientRedirectURIs(cfg, existingDC); err != nil {
+			return err
+		}
+
 		// Updates OIDC Configuration in the argocd-cm when Keycloak is initially configured
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, keycloakRouteURL)
@@ -1495,6 +1576,7 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 
 				// Update Realm creation. This will avoid posting of realm configuration on further reconciliations.
 				existingDeployment.Annotations["argocd.argoproj.io/realm-created"] = "true"
+				existingDeployment.Annotations[keycloakRedirectURIsAnnotation] = strings.Join(getKeycloakClientRedirectURIs(cfg), ",")
 				err = r.Client.Update(context.TODO(), existingDeployment)
 				if err != nil {
 					return err
@@ -1502,6 +1584,11 @@ func (r *ReconcileArgoCD) reconcileKeycloak(cr *argoproj.ArgoCD) error {
 			}
 		}
 
+		// Registers the URLs Argo CD is exposed under when they change after the realm is created.
+		if err := r.reconcileKeycloakClientRedirectURIs(cfg, existingDeployment); err != nil {
+			return err
+		}
+
 		// Updates OIDC Configuration in the argocd-cm when Keycloak is initially configured
 		// or when user requests to update the OIDC configuration through `.spec.sso.keycloak.rootCA`.
 		err = r.updateArgoCDConfiguration(cr, kIngURL)
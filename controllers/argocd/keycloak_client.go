@@ -24,12 +24,12 @@ type httpclient struct {
 	token     string
 }
 
-// Creates a new realm for Keycloak.
-func createRealm(cfg *keycloakConfig) (string, error) {
+// Creates a new http client logged in to Keycloak.
+func newKeycloakHTTPClient(cfg *keycloakConfig) (*httpclient, error) {
 
 	req, err := defaultRequester(cfg.KeycloakServerCert, cfg.VerifyTLS)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// create a new http client.
@@ -47,11 +47,22 @@ func createRealm(cfg *keycloakConfig) (string, error) {
 	// login request updates the auth token for httpclient.
 	err = h.login(cfg.Username, cfg.Password)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	log.Info(fmt.Sprintf("Access Token for keycloak of ArgoCD %s in namespace %s generated successfully",
 		cfg.ArgoName, cfg.ArgoNamespace))
 
+	return h, nil
+}
+
+// Creates a new realm for Keycloak.
+func createRealm(cfg *keycloakConfig) (string, error) {
+
+	h, err := newKeycloakHTTPClient(cfg)
+	if err != nil {
+		return "", err
+	}
+
 	realmConfig, err := createRealmConfig(cfg)
 	if err != nil {
 		return "", err
@@ -128,6 +139,72 @@ func (h *httpclient) post(realmConfig []byte) (string, error) {
 	return response.Status, nil
 }
 
+// Updates the redirect URIs and web origins of the Argo CD client of the Keycloak realm.
+func updateRealmClient(cfg *keycloakConfig) error {
+
+	h, err := newKeycloakHTTPClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	return h.updateClient(getKeycloakClientRedirectURIs(cfg), getKeycloakClientWebOrigins(cfg))
+}
+
+// Update the redirect URIs and web origins of the Argo CD client using the client API of the realm.
+func (h *httpclient) updateClient(redirectURIs, webOrigins []string) error {
+	clientsURL := fmt.Sprintf("%s%s/%s/clients", h.URL, realmURL, keycloakRealm)
+
+	request, err := http.NewRequest("GET", fmt.Sprintf("%s?clientId=%s", clientsURL, url.QueryEscape(keycloakClient)), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.token))
+
+	response, err := h.requester.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get client %s of keycloak realm %s: %s", keycloakClient, keycloakRealm, response.Status)
+	}
+
+	// the client is updated from its current representation to keep the settings not managed by the operator.
+	clients := []map[string]interface{}{}
+	if err := json.NewDecoder(response.Body).Decode(&clients); err != nil {
+		return err
+	}
+	if len(clients) == 0 {
+		return fmt.Errorf("client %s not found in keycloak realm %s", keycloakClient, keycloakRealm)
+	}
+	client := clients[0]
+	client["redirectUris"] = redirectURIs
+	client["webOrigins"] = webOrigins
+
+	body, err := json.Marshal(client)
+	if err != nil {
+		return err
+	}
+	request, err = http.NewRequest("PUT", fmt.Sprintf("%s/%s", clientsURL, client["id"]), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", h.token))
+
+	response, err = h.requester.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to update client %s of keycloak realm %s: %s", keycloakClient, keycloakRealm, response.Status)
+	}
+	return nil
+}
+
 // defaultRequester returns a default client for requesting http endpoints.
 func defaultRequester(serverCert []byte, verifyTLS bool) (requester, error) {
 	tlsConfig, err := createTLSConfig(serverCert, verifyTLS)
@@ -1,6 +1,8 @@
 package argocd
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -106,3 +108,34 @@ func TestClient_useKeycloakServerCertificate(t *testing.T) {
 	assert.Equal(t, resp.StatusCode, 200)
 
 }
+
+func TestKeycloak_testUpdateClient(t *testing.T) {
+	clientsPath := fmt.Sprintf("%s/%s/clients", realmURL, keycloakRealm)
+	updated := map[string]interface{}{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			assert.Equal(t, clientsPath, req.URL.Path)
+			assert.Equal(t, keycloakClient, req.URL.Query().Get("clientId"))
+			_, _ = w.Write([]byte(`[{"id":"1234","clientId":"argocd","redirectUris":["https://old/auth/callback"],"publicClient":false}]`))
+		case http.MethodPut:
+			assert.Equal(t, clientsPath+"/1234", req.URL.Path)
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&updated))
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	h := &httpclient{
+		requester: server.Client(),
+		URL:       server.URL,
+		token:     "dummy",
+	}
+
+	assert.NoError(t, h.updateClient([]string{"https://argocd.example.com/auth/callback"}, []string{"https://argocd.example.com"}))
+	assert.Equal(t, []interface{}{"https://argocd.example.com/auth/callback"}, updated["redirectUris"])
+	assert.Equal(t, []interface{}{"https://argocd.example.com"}, updated["webOrigins"])
+	// the settings not managed by the operator are kept
+	assert.Equal(t, false, updated["publicClient"])
+}
@@ -532,3 +532,61 @@ func removeTemplateAPI() {
 	templateAPIFound = false
 	deploymentConfigAPIFound = false
 }
+
+func TestKeycloak_testRealmConfigRedirectURIs(t *testing.T) {
+	cfg := &keycloakConfig{
+		ArgoCDURL:            "https://argocd.example.com/argocd",
+		ArgoCDAdditionalURLs: []string{"https://argocd.internal/argocd"},
+	}
+
+	assert.Equal(t, []string{
+		"https://argocd.example.com/argocd/auth/callback",
+		"https://argocd.internal/argocd/auth/callback",
+	}, getKeycloakClientRedirectURIs(cfg))
+	assert.Equal(t, []string{"https://argocd.example.com", "https://argocd.internal"}, getKeycloakClientWebOrigins(cfg))
+}
+
+func TestReconcileArgoCD_reconcileKeycloakClientRedirectURIs(t *testing.T) {
+	updates := 0
+	updateKeycloakRealmClient = func(cfg *keycloakConfig) error {
+		updates++
+		return nil
+	}
+	defer func() {
+		updateKeycloakRealmClient = updateRealmClient
+	}()
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.SSO = &argoproj.ArgoCDSSOSpec{
+			Provider: argoproj.SSOProviderTypeKeycloak,
+		}
+	})
+	deployment := newKeycloakDeployment(a)
+	deployment.Annotations["argocd.argoproj.io/realm-created"] = "true"
+
+	resObjs := []client.Object{a, deployment}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	cfg := &keycloakConfig{
+		ArgoCDURL:            "https://argocd.example.com",
+		ArgoCDAdditionalURLs: []string{"https://argocd.internal"},
+	}
+	assert.NoError(t, r.reconcileKeycloakClientRedirectURIs(cfg, deployment))
+	assert.Equal(t, 1, updates)
+	assert.Equal(t, "https://argocd.example.com/auth/callback,https://argocd.internal/auth/callback",
+		deployment.Annotations[keycloakRedirectURIsAnnotation])
+
+	// the realm is not updated when the URLs are unchanged
+	assert.NoError(t, r.reconcileKeycloakClientRedirectURIs(cfg, deployment))
+	assert.Equal(t, 1, updates)
+
+	// the realm is updated when a host is removed
+	cfg.ArgoCDAdditionalURLs = nil
+	assert.NoError(t, r.reconcileKeycloakClientRedirectURIs(cfg, deployment))
+	assert.Equal(t, 2, updates)
+	assert.Equal(t, "https://argocd.example.com/auth/callback", deployment.Annotations[keycloakRedirectURIsAnnotation])
+}
@@ -96,14 +96,16 @@ type TokenResponse struct {
 
 // KeycloakPostData defines the values required to update Keycloak Realm.
 type keycloakConfig struct {
-	ArgoName           string
-	ArgoNamespace      string
-	Username           string
-	Password           string
-	KeycloakURL        string
-	ArgoCDURL          string
-	KeycloakServerCert []byte
-	VerifyTLS          bool
+	ArgoName      string
+	ArgoNamespace string
+	Username      string
+	Password      string
+	KeycloakURL   string
+	ArgoCDURL     string
+	// ArgoCDAdditionalURLs are the URLs, besides ArgoCDURL, Argo CD is exposed under.
+	ArgoCDAdditionalURLs []string
+	KeycloakServerCert   []byte
+	VerifyTLS            bool
 }
 
 type oidcConfig struct {
@@ -0,0 +1,418 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// localUserAdmin is the name of the built-in admin user, which is not a local user.
+	localUserAdmin = "admin"
+
+	// localUserTokenIssuer is the issuer of the API tokens, Argo CD only accepts tokens it issued itself.
+	localUserTokenIssuer = "argocd"
+
+	// localUserTokenHeader is the JOSE header of the API tokens, signed with HS256 like the tokens issued by Argo CD.
+	localUserTokenHeader = `{"alg":"HS256","typ":"JWT"}`
+)
+
+// localUserToken is an entry of the `accounts.<name>.tokens` list in the argocd-secret Secret.
+type localUserToken struct {
+	ID        string `json:"id"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// localUserKey returns the argocd-cm or argocd-secret key with the given suffix for the given user.
+func localUserKey(name, suffix string) string {
+	return fmt.Sprintf("%s%s.%s", common.ArgoCDKeyAccountsPrefix, name, suffix)
+}
+
+// newLocalUserSecret returns the Secret holding the API token of the given user.
+func newLocalUserSecret(cr *argoproj.ArgoCD, name string) *corev1.Secret {
+	secret := argoutil.NewSecretWithSuffix(cr, "local-user-"+name)
+	secret.Labels[common.ArgoCDLocalUserLabel] = name
+	return secret
+}
+
+// validateLocalUsers returns an error if the local users of the given ArgoCD are invalid.
+func validateLocalUsers(cr *argoproj.ArgoCD) error {
+	names := make(map[string]bool)
+	for _, u := range cr.Spec.LocalUsers {
+		if u.Name == localUserAdmin {
+			return fmt.Errorf("local user %s is not allowed, the admin user is configured through .spec.disableAdmin", u.Name)
+		}
+		if names[u.Name] {
+			return fmt.Errorf("local user %s is defined more than once", u.Name)
+		}
+		names[u.Name] = true
+
+		if !u.IsAPITokenEnabled() {
+			continue
+		}
+		if !u.HasCapability(argoproj.LocalUserCapabilityAPIKey) {
+			return fmt.Errorf("local user %s requires the %s capability to generate an API token", u.Name, argoproj.LocalUserCapabilityAPIKey)
+		}
+		if ttl := u.APIToken.TTL; ttl != nil && u.APIToken.GetRenewBefore() >= ttl.Duration {
+			return fmt.Errorf("the API token renewal of local user %s must happen before its expiry", u.Name)
+		}
+	}
+	return nil
+}
+
+// reconcileLocalUsers will ensure that the passwords and API tokens of the local users are present
+// in the argocd-secret Secret, and that removed users are cleaned up.
+func (r *ReconcileArgoCD) reconcileLocalUsers(cr *argoproj.ArgoCD) error {
+	if err := validateLocalUsers(cr); err != nil {
+		return err
+	}
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		log.Info(fmt.Sprintf("argo secret [%s] not found, waiting to reconcile local users", secret.Name))
+		return nil
+	}
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	changed := false
+	names := []string{}
+	for _, u := range cr.Spec.LocalUsers {
+		names = append(names, u.Name)
+
+		pwChanged, err := r.reconcileLocalUserPassword(cr, u, secret)
+		if err != nil {
+			return err
+		}
+		tokenChanged, err := r.reconcileLocalUserToken(cr, u, secret)
+		if err != nil {
+			return err
+		}
+		changed = changed || pwChanged || tokenChanged
+	}
+	sort.Strings(names)
+
+	// Only the users previously managed by the operator are removed, users created through
+	// ExtraConfig and `argocd account` are left untouched.
+	for _, name := range strings.Split(secret.Annotations[common.AnnotationLocalUsers], ",") {
+		if name == "" || containsString(names, name) {
+			continue
+		}
+		log.Info(fmt.Sprintf("removing local user %s", name))
+		for _, suffix := range []string{"password", "passwordMtime", "tokens"} {
+			delete(secret.Data, localUserKey(name, suffix))
+		}
+		changed = true
+	}
+
+	if managed := strings.Join(names, ","); secret.Annotations[common.AnnotationLocalUsers] != managed {
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[common.AnnotationLocalUsers] = managed
+		if managed == "" {
+			delete(secret.Annotations, common.AnnotationLocalUsers)
+		}
+		changed = true
+	}
+
+	if changed {
+		log.Info("updating local users in argo secret")
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+
+	return r.deleteStaleLocalUserSecrets(cr)
+}
+
+// reconcileLocalUserPassword sets the password hash of the given user in the argocd-secret Secret
+// from the Secret it references. Returns true if the argocd-secret Secret was changed.
+func (r *ReconcileArgoCD) reconcileLocalUserPassword(cr *argoproj.ArgoCD, u argoproj.LocalUserSpec, secret *corev1.Secret) (bool, error) {
+	if u.PasswordSecret == nil {
+		return false, nil
+	}
+
+	ref := argoutil.NewSecretWithName(cr, u.PasswordSecret.Name)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, ref.Name, ref) {
+		log.Info(fmt.Sprintf("password secret [%s] of local user %s not found, waiting to reconcile its password", ref.Name, u.Name))
+		return false, nil
+	}
+
+	hash := strings.TrimSpace(string(ref.Data[u.PasswordSecret.Key]))
+	if !isBcryptHash(hash) {
+		return false, fmt.Errorf("key %s of secret %s does not hold a bcrypt password hash for local user %s", u.PasswordSecret.Key, ref.Name, u.Name)
+	}
+	if string(secret.Data[localUserKey(u.Name, "password")]) == hash {
+		return false, nil
+	}
+
+	log.Info(fmt.Sprintf("updating password of local user %s", u.Name))
+	secret.Data[localUserKey(u.Name, "password")] = []byte(hash)
+	secret.Data[localUserKey(u.Name, "passwordMtime")] = nowBytes()
+	return true, nil
+}
+
+// reconcileLocalUserToken ensures that a valid API token is present in the Secret of the given user
+// and registered in the argocd-secret Secret, renewing it ahead of its expiry. Returns true if the
+// argocd-secret Secret was changed.
+func (r *ReconcileArgoCD) reconcileLocalUserToken(cr *argoproj.ArgoCD, u argoproj.LocalUserSpec, secret *corev1.Secret) (bool, error) {
+	tokensKey := localUserKey(u.Name, "tokens")
+	tokens := []localUserToken{}
+	if data := secret.Data[tokensKey]; len(data) > 0 {
+		if err := json.Unmarshal(data, &tokens); err != nil {
+			return false, fmt.Errorf("failed to parse the API tokens of local user %s: %w", u.Name, err)
+		}
+	}
+
+	userSecret := newLocalUserSecret(cr, u.Name)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, userSecret.Name, userSecret)
+	previousID := userSecret.Annotations[common.AnnotationLocalUserTokenID]
+
+	if !u.IsAPITokenEnabled() {
+		// The Secret of the user is deleted with the stale ones, revoke its token.
+		if !found || !containsLocalUserToken(tokens, previousID) {
+			return false, nil
+		}
+		return true, setLocalUserTokens(secret, tokensKey, removeLocalUserToken(tokens, previousID))
+	}
+
+	key := secret.Data[common.ArgoCDKeyServerSecretKey]
+	if len(key) == 0 {
+		log.Info(fmt.Sprintf("server secret key not found, waiting to generate the API token of local user %s", u.Name))
+		return false, nil
+	}
+	now := time.Now().UTC()
+	if found && !localUserTokenNeedsRenewal(u, userSecret, tokens, key, now) {
+		return false, nil
+	}
+
+	token := localUserToken{
+		ID:       string(uuid.NewUUID()),
+		IssuedAt: now.Unix(),
+	}
+	if u.APIToken.TTL != nil {
+		token.ExpiresAt = now.Add(u.APIToken.TTL.Duration).Unix()
+	}
+	jwt, err := signLocalUserToken(u.Name, token, key)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info(fmt.Sprintf("generating API token for local user %s", u.Name))
+	userSecret.Data = map[string][]byte{
+		common.ArgoCDKeyAPIToken: []byte(jwt),
+	}
+	if userSecret.Annotations == nil {
+		userSecret.Annotations = make(map[string]string)
+	}
+	userSecret.Annotations[common.AnnotationLocalUserTokenID] = token.ID
+	delete(userSecret.Annotations, common.AnnotationLocalUserTokenExpiresAt)
+	if token.ExpiresAt > 0 {
+		userSecret.Annotations[common.AnnotationLocalUserTokenExpiresAt] = time.Unix(token.ExpiresAt, 0).UTC().Format(time.RFC3339)
+	}
+
+	if found {
+		if err := r.Client.Update(context.TODO(), userSecret); err != nil {
+			return false, err
+		}
+	} else {
+		if err := controllerutil.SetControllerReference(cr, userSecret, r.Scheme); err != nil {
+			return false, err
+		}
+		if err := r.Client.Create(context.TODO(), userSecret); err != nil {
+			return false, err
+		}
+	}
+
+	tokens = append(removeLocalUserToken(tokens, previousID), token)
+	return true, setLocalUserTokens(secret, tokensKey, tokens)
+}
+
+// localUserTokenNeedsRenewal returns true if the API token in the given Secret of a local user is not
+// registered in Argo CD, was signed with another key, no longer matches the spec or is about to expire.
+func localUserTokenNeedsRenewal(u argoproj.LocalUserSpec, userSecret *corev1.Secret, tokens []localUserToken, key []byte, now time.Time) bool {
+	id := userSecret.Annotations[common.AnnotationLocalUserTokenID]
+	if !containsLocalUserToken(tokens, id) || !verifyLocalUserToken(string(userSecret.Data[common.ArgoCDKeyAPIToken]), key) {
+		return true
+	}
+
+	expiresAt, hasExpiry := userSecret.Annotations[common.AnnotationLocalUserTokenExpiresAt]
+	if (u.APIToken.TTL != nil) != hasExpiry {
+		return true
+	}
+	if !hasExpiry {
+		return false
+	}
+	exp, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(exp.Add(-u.APIToken.GetRenewBefore()))
+}
+
+// deleteStaleLocalUserSecrets deletes the Secrets of the local users that were removed or no longer have an API token.
+func (r *ReconcileArgoCD) deleteStaleLocalUserSecrets(cr *argoproj.ArgoCD) error {
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace), client.HasLabels{common.ArgoCDLocalUserLabel}); err != nil {
+		return err
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+		name := secret.Labels[common.ArgoCDLocalUserLabel]
+		if u := getLocalUser(cr, name); u != nil && u.IsAPITokenEnabled() {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting API token secret %s of local user %s", secret.Name, name))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLocalUserTokenRenewal returns the delay after which the next API token of the local users of
+// the given ArgoCD has to be renewed, 0 if none of the tokens expire.
+func (r *ReconcileArgoCD) getLocalUserTokenRenewal(cr *argoproj.ArgoCD) time.Duration {
+	var renewal time.Duration
+	for _, u := range cr.Spec.LocalUsers {
+		if !u.IsAPITokenEnabled() || u.APIToken.TTL == nil {
+			continue
+		}
+		userSecret := newLocalUserSecret(cr, u.Name)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, userSecret.Name, userSecret) {
+			continue
+		}
+		exp, err := time.Parse(time.RFC3339, userSecret.Annotations[common.AnnotationLocalUserTokenExpiresAt])
+		if err != nil {
+			continue
+		}
+		d := time.Until(exp.Add(-u.APIToken.GetRenewBefore()))
+		if d < minScheduledRequeue {
+			d = minScheduledRequeue
+		}
+		if renewal == 0 || d < renewal {
+			renewal = d
+		}
+	}
+	return renewal
+}
+
+// getLocalUser returns the local user of the given ArgoCD with the given name, nil if not found.
+func getLocalUser(cr *argoproj.ArgoCD, name string) *argoproj.LocalUserSpec {
+	for i := range cr.Spec.LocalUsers {
+		if cr.Spec.LocalUsers[i].Name == name {
+			return &cr.Spec.LocalUsers[i]
+		}
+	}
+	return nil
+}
+
+// signLocalUserToken returns the JWT of the given API token for the given user, signed with the server secret key.
+func signLocalUserToken(name string, token localUserToken, key []byte) (string, error) {
+	claims := map[string]interface{}{
+		"iss": localUserTokenIssuer,
+		"sub": fmt.Sprintf("%s:%s", name, argoproj.LocalUserCapabilityAPIKey),
+		"jti": token.ID,
+		"iat": token.IssuedAt,
+		"nbf": token.IssuedAt,
+	}
+	if token.ExpiresAt > 0 {
+		claims["exp"] = token.ExpiresAt
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(localUserTokenHeader)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + localUserTokenSignature(unsigned, key), nil
+}
+
+// verifyLocalUserToken returns true if the given JWT was signed with the given key.
+func verifyLocalUserToken(jwt string, key []byte) bool {
+	i := strings.LastIndex(jwt, ".")
+	if i < 0 {
+		return false
+	}
+	return hmac.Equal([]byte(jwt[i+1:]), []byte(localUserTokenSignature(jwt[:i], key)))
+}
+
+// localUserTokenSignature returns the encoded HS256 signature of the given unsigned JWT.
+func localUserTokenSignature(unsigned string, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// setLocalUserTokens sets the given API tokens under the given key of the argocd-secret Secret.
+func setLocalUserTokens(secret *corev1.Secret, key string, tokens []localUserToken) error {
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	secret.Data[key] = data
+	return nil
+}
+
+// containsLocalUserToken returns true if a token with the given ID is part of the given tokens.
+func containsLocalUserToken(tokens []localUserToken, id string) bool {
+	for _, t := range tokens {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLocalUserToken returns the given tokens without the token with the given ID.
+func removeLocalUserToken(tokens []localUserToken, id string) []localUserToken {
+	result := []localUserToken{}
+	for _, t := range tokens {
+		if t.ID != id {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// isBcryptHash returns true if the given value looks like a bcrypt hash, the only password hash supported by Argo CD.
+func isBcryptHash(value string) bool {
+	return len(value) == 60 && (strings.HasPrefix(value, "$2a$") || strings.HasPrefix(value, "$2b$") || strings.HasPrefix(value, "$2y$"))
+}
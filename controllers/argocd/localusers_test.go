@@ -0,0 +1,193 @@
+package argocd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/v2/util/password"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestValidateLocalUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		users   []argoproj.LocalUserSpec
+		wantErr bool
+	}{
+		{
+			name:  "valid users",
+			users: []argoproj.LocalUserSpec{{Name: "alice"}, {Name: "bob", Capabilities: []argoproj.LocalUserCapability{"apiKey"}, APIToken: &argoproj.LocalUserAPITokenSpec{Enabled: true}}},
+		},
+		{
+			name:    "admin user",
+			users:   []argoproj.LocalUserSpec{{Name: "admin"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate user",
+			users:   []argoproj.LocalUserSpec{{Name: "alice"}, {Name: "alice"}},
+			wantErr: true,
+		},
+		{
+			name:    "API token without apiKey capability",
+			users:   []argoproj.LocalUserSpec{{Name: "alice", APIToken: &argoproj.LocalUserAPITokenSpec{Enabled: true}}},
+			wantErr: true,
+		},
+		{
+			name: "API token renewed after expiry",
+			users: []argoproj.LocalUserSpec{{
+				Name:         "alice",
+				Capabilities: []argoproj.LocalUserCapability{"apiKey"},
+				APIToken: &argoproj.LocalUserAPITokenSpec{
+					Enabled:     true,
+					TTL:         &metav1.Duration{Duration: time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.LocalUsers = test.users
+			})
+			err := validateLocalUsers(a)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileLocalUsers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	hash, err := argopass.HashPassword("s3cr3t")
+	assert.NoError(t, err)
+
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.LocalUsers = []argoproj.LocalUserSpec{{
+			Name:         "alice",
+			Capabilities: []argoproj.LocalUserCapability{"login", "apiKey"},
+			PasswordSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "alice-password"},
+				Key:                  "hash",
+			},
+			APIToken: &argoproj.LocalUserAPITokenSpec{
+				Enabled: true,
+				TTL:     &metav1.Duration{Duration: time.Hour},
+			},
+		}}
+	})
+	argoSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDSecretName, Namespace: testNamespace},
+		Data: map[string][]byte{
+			common.ArgoCDKeyServerSecretKey: []byte("session-key"),
+			// token created with `argocd account generate-token`
+			"accounts.alice.tokens": []byte(`[{"id":"manual","iat":1700000000}]`),
+		},
+	}
+	passwordSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-password", Namespace: testNamespace},
+		Data:       map[string][]byte{"hash": []byte(hash)},
+	}
+
+	resObjs := []client.Object{a, argoSecret, passwordSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileLocalUsers(a))
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: testNamespace}, secret))
+	assert.Equal(t, hash, string(secret.Data["accounts.alice.password"]))
+	assert.NotEmpty(t, secret.Data["accounts.alice.passwordMtime"])
+	assert.Equal(t, "alice", secret.Annotations[common.AnnotationLocalUsers])
+
+	userSecret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-alice", Namespace: testNamespace}, userSecret))
+	id := userSecret.Annotations[common.AnnotationLocalUserTokenID]
+	assert.NotEmpty(t, id)
+	assert.NotEmpty(t, userSecret.Annotations[common.AnnotationLocalUserTokenExpiresAt])
+
+	tokens := []localUserToken{}
+	assert.NoError(t, json.Unmarshal(secret.Data["accounts.alice.tokens"], &tokens))
+	assert.Len(t, tokens, 2)
+	assert.True(t, containsLocalUserToken(tokens, "manual"))
+	assert.True(t, containsLocalUserToken(tokens, id))
+
+	// the token is signed with the server secret key for the apiKey capability of the user
+	jwt := string(userSecret.Data[common.ArgoCDKeyAPIToken])
+	assert.True(t, verifyLocalUserToken(jwt, []byte("session-key")))
+	assert.False(t, verifyLocalUserToken(jwt, []byte("another-key")))
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(jwt, ".")[1])
+	assert.NoError(t, err)
+	claims := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "alice:apiKey", claims["sub"])
+	assert.Equal(t, "argocd", claims["iss"])
+	assert.Equal(t, id, claims["jti"])
+
+	// a valid token is kept
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-alice", Namespace: testNamespace}, userSecret))
+	assert.Equal(t, id, userSecret.Annotations[common.AnnotationLocalUserTokenID])
+	assert.Greater(t, r.getLocalUserTokenRenewal(a), 30*time.Minute)
+
+	// a token close to its expiry is renewed and the previous one revoked
+	userSecret.Annotations[common.AnnotationLocalUserTokenExpiresAt] = time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	assert.NoError(t, r.Client.Update(context.TODO(), userSecret))
+	assert.Equal(t, minScheduledRequeue, r.getLocalUserTokenRenewal(a))
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-alice", Namespace: testNamespace}, userSecret))
+	assert.NotEqual(t, id, userSecret.Annotations[common.AnnotationLocalUserTokenID])
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: testNamespace}, secret))
+	assert.NoError(t, json.Unmarshal(secret.Data["accounts.alice.tokens"], &tokens))
+	assert.Len(t, tokens, 2)
+	assert.False(t, containsLocalUserToken(tokens, id))
+
+	// removed users are cleaned up
+	a.Spec.LocalUsers = nil
+	assert.NoError(t, r.reconcileLocalUsers(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDSecretName, Namespace: testNamespace}, secret))
+	assert.NotContains(t, secret.Data, "accounts.alice.password")
+	assert.NotContains(t, secret.Data, "accounts.alice.passwordMtime")
+	assert.NotContains(t, secret.Data, "accounts.alice.tokens")
+	assert.NotContains(t, secret.Annotations, common.AnnotationLocalUsers)
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-alice", Namespace: testNamespace}, userSecret)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGetArgoConfigSettings_withLocalUsers(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.LocalUsers = []argoproj.LocalUserSpec{
+			{Name: "alice"},
+			{Name: "bob", Enabled: boolPtr(false), Capabilities: []argoproj.LocalUserCapability{"login", "apiKey"}},
+		}
+	})
+
+	settings := getArgoConfigSettings(a)
+	assert.Equal(t, "login", settings["accounts.alice"])
+	assert.Equal(t, "true", settings["accounts.alice.enabled"])
+	assert.Equal(t, "login,apiKey", settings["accounts.bob"])
+	assert.Equal(t, "false", settings["accounts.bob.enabled"])
+}
@@ -0,0 +1,291 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// selectedNamespace is a namespace selected by a managedNamespaces selection of an ArgoCD.
+type selectedNamespace struct {
+	namespace corev1.Namespace
+	selection argoproj.ManagedNamespacesSpec
+}
+
+// matchManagedNamespaces returns the first of the given managedNamespaces selections matching the given namespace
+// labels.
+func matchManagedNamespaces(selections []argoproj.ManagedNamespacesSpec, namespaceLabels map[string]string) (argoproj.ManagedNamespacesSpec, bool, error) {
+	for _, selection := range selections {
+		selector, err := metav1.LabelSelectorAsSelector(&selection.Selector)
+		if err != nil {
+			return selection, false, fmt.Errorf("invalid selector of managed namespaces %s: %w", selection.Name, err)
+		}
+		// an empty selector would select every namespace of the cluster
+		if selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(namespaceLabels)) {
+			return selection, true, nil
+		}
+	}
+	return argoproj.ManagedNamespacesSpec{}, false, nil
+}
+
+// managedNamespacesSelections holds the managedNamespaces selections of the reconciled ArgoCDs, keyed by instance,
+// so that the namespace watch filters the label changes without listing the ArgoCDs on each event.
+type managedNamespacesSelections struct {
+	mu         sync.RWMutex
+	selections map[types.NamespacedName][]argoproj.ManagedNamespacesSpec
+}
+
+// recordManagedNamespacesSelections records the managedNamespaces selections of the given ArgoCD, or forgets them when
+// it selects no namespace.
+func (r *ReconcileArgoCD) recordManagedNamespacesSelections(cr *argoproj.ArgoCD) {
+	if len(cr.Spec.ManagedNamespaces) == 0 || cr.GetDeletionTimestamp() != nil {
+		r.forgetManagedNamespacesSelections(client.ObjectKeyFromObject(cr))
+		return
+	}
+	r.managedNamespacesSelections.mu.Lock()
+	defer r.managedNamespacesSelections.mu.Unlock()
+	if r.managedNamespacesSelections.selections == nil {
+		r.managedNamespacesSelections.selections = map[types.NamespacedName][]argoproj.ManagedNamespacesSpec{}
+	}
+	selections := make([]argoproj.ManagedNamespacesSpec, len(cr.Spec.ManagedNamespaces))
+	for i := range cr.Spec.ManagedNamespaces {
+		cr.Spec.ManagedNamespaces[i].DeepCopyInto(&selections[i])
+	}
+	r.managedNamespacesSelections.selections[client.ObjectKeyFromObject(cr)] = selections
+}
+
+// forgetManagedNamespacesSelections forgets the managedNamespaces selections of the given ArgoCD.
+func (r *ReconcileArgoCD) forgetManagedNamespacesSelections(key types.NamespacedName) {
+	r.managedNamespacesSelections.mu.Lock()
+	defer r.managedNamespacesSelections.mu.Unlock()
+	delete(r.managedNamespacesSelections.selections, key)
+}
+
+// isSelectedByManagedNamespaces returns true if a namespace with any of the given labels is selected by the
+// managedNamespaces of a reconciled ArgoCD. The selections are recorded when the instances are reconciled, so a
+// selection added to an instance is applied to the existing namespaces by the reconciliation of the instance.
+func (r *ReconcileArgoCD) isSelectedByManagedNamespaces(namespaceLabels ...map[string]string) bool {
+	r.managedNamespacesSelections.mu.RLock()
+	defer r.managedNamespacesSelections.mu.RUnlock()
+	for _, selections := range r.managedNamespacesSelections.selections {
+		for _, l := range namespaceLabels {
+			if _, selected, _ := matchManagedNamespaces(selections, l); selected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getSelectedNamespaces returns the namespaces selected by the managedNamespaces of the given ArgoCD, sorted by name.
+// The namespaces managed by another instance, hosting an ArgoCD or terminating are not selected.
+func (r *ReconcileArgoCD) getSelectedNamespaces(cr *argoproj.ArgoCD) ([]selectedNamespace, error) {
+	selected := []selectedNamespace{}
+	if len(cr.Spec.ManagedNamespaces) == 0 || cr.GetDeletionTimestamp() != nil {
+		return selected, nil
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.Client.List(context.TODO(), namespaces); err != nil {
+		return nil, err
+	}
+	argocds := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds); err != nil {
+		return nil, err
+	}
+	instanceNamespaces := map[string]bool{}
+	for _, argocd := range argocds.Items {
+		instanceNamespaces[argocd.Namespace] = true
+	}
+
+	for _, namespace := range namespaces.Items {
+		if namespace.Name == cr.Namespace || instanceNamespaces[namespace.Name] || namespace.DeletionTimestamp != nil {
+			continue
+		}
+		selection, ok, err := matchManagedNamespaces(cr.Spec.ManagedNamespaces, namespace.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if value, ok := namespace.Labels[common.ArgoCDManagedByLabel]; ok && value != cr.Namespace {
+			log.Info(fmt.Sprintf("namespace %s selected by managed namespaces %s is already managed by namespace %s, skipping", namespace.Name, selection.Name, value))
+			continue
+		}
+		selected = append(selected, selectedNamespace{namespace: namespace, selection: selection})
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].namespace.Name < selected[j].namespace.Name })
+	return selected, nil
+}
+
+func isNamespaceSelected(selected []selectedNamespace, name string) bool {
+	for _, s := range selected {
+		if s.namespace.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsNamespace(namespaces []corev1.Namespace, name string) bool {
+	for _, namespace := range namespaces {
+		if namespace.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getManagedNamespaceRBACProfile returns the RBAC profile of the given managed namespace, admin unless the namespace
+// is selected with another profile.
+func (r *ReconcileArgoCD) getManagedNamespaceRBACProfile(namespace string) argoproj.ManagedNamespacesRBACProfile {
+	if selection, ok := r.ManagedNamespaceSelections[namespace]; ok && selection.RBACProfile != "" {
+		return selection.RBACProfile
+	}
+	return argoproj.ManagedNamespacesRBACProfileAdmin
+}
+
+// policyRulesForManagedNamespace returns the policy rules of the role of the given component in the given managed
+// namespace. The application controller and the server may only read the resources of read-only namespaces.
+func (r *ReconcileArgoCD) policyRulesForManagedNamespace(name, namespace string, rules []v1.PolicyRule) []v1.PolicyRule {
+	if r.getManagedNamespaceRBACProfile(namespace) != argoproj.ManagedNamespacesRBACProfileReadOnly {
+		return rules
+	}
+	switch name {
+	case common.ArgoCDApplicationControllerComponent, common.ArgoCDServerComponent:
+		return policyRuleForManagedNamespaceReadOnly()
+	}
+	return rules
+}
+
+// reconcileManagedNamespaces labels the namespaces selected by the managedNamespaces of the given ArgoCD as managed
+// by it, removes the label from the namespaces no longer selected and reports the managed namespaces in the status.
+func (r *ReconcileArgoCD) reconcileManagedNamespaces(cr *argoproj.ArgoCD) error {
+	for name, selection := range r.ManagedNamespaceSelections {
+		namespace := &corev1.Namespace{}
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name}, namespace); err != nil {
+			return err
+		}
+
+		if namespace.Labels[common.ArgoCDManagedByLabel] == cr.Namespace {
+			// keep track of the selection of the namespaces labelled by the operator only
+			if value, ok := namespace.Annotations[common.ArgoCDManagedBySelectorAnnotation]; !ok || value == selection.Name {
+				continue
+			}
+		} else {
+			if namespace.Labels == nil {
+				namespace.Labels = make(map[string]string)
+			}
+			namespace.Labels[common.ArgoCDManagedByLabel] = cr.Namespace
+		}
+		if namespace.Annotations == nil {
+			namespace.Annotations = make(map[string]string)
+		}
+		namespace.Annotations[common.ArgoCDManagedBySelectorAnnotation] = selection.Name
+
+		log.Info(fmt.Sprintf("labelling namespace %s selected by managed namespaces %s as managed by namespace %s", name, selection.Name, cr.Namespace))
+		if err := r.Client.Update(context.TODO(), namespace); err != nil {
+			return err
+		}
+	}
+
+	if err := r.releaseSelectedNamespaces(cr); err != nil {
+		return err
+	}
+
+	managed := r.getManagedNamespacesStatus(cr)
+	if reflect.DeepEqual(cr.Status.ManagedNamespaces, managed) {
+		return nil
+	}
+	return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		status.ManagedNamespaces = managed
+	})
+}
+
+// releaseSelectedNamespaces removes the managed-by label set by the operator from the namespaces no longer selected
+// by the managedNamespaces of the given ArgoCD, which triggers the removal of their RBACs.
+func (r *ReconcileArgoCD) releaseSelectedNamespaces(cr *argoproj.ArgoCD) error {
+	namespaces := &corev1.NamespaceList{}
+	if err := r.Client.List(context.TODO(), namespaces, client.MatchingLabels{common.ArgoCDManagedByLabel: cr.Namespace}); err != nil {
+		return err
+	}
+
+	for _, namespace := range namespaces.Items {
+		if _, ok := namespace.Annotations[common.ArgoCDManagedBySelectorAnnotation]; !ok {
+			continue
+		}
+		if _, ok := r.ManagedNamespaceSelections[namespace.Name]; ok && cr.GetDeletionTimestamp() == nil {
+			continue
+		}
+
+		delete(namespace.Labels, common.ArgoCDManagedByLabel)
+		delete(namespace.Annotations, common.ArgoCDManagedBySelectorAnnotation)
+		log.Info(fmt.Sprintf("removing the managed-by label of namespace %s, no longer selected by the managed namespaces of ArgoCD %s", namespace.Name, cr.Name))
+		if err := r.Client.Update(context.TODO(), &namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getManagedNamespacesStatus returns the namespaces managed by the given ArgoCD and why, sorted by name.
+func (r *ReconcileArgoCD) getManagedNamespacesStatus(cr *argoproj.ArgoCD) []argoproj.ManagedNamespace {
+	managed := []argoproj.ManagedNamespace{}
+	if r.ManagedNamespaces == nil {
+		return managed
+	}
+	seen := map[string]bool{}
+	for _, namespace := range r.ManagedNamespaces.Items {
+		if seen[namespace.Name] || namespace.DeletionTimestamp != nil {
+			continue
+		}
+		seen[namespace.Name] = true
+
+		status := argoproj.ManagedNamespace{
+			Name:        namespace.Name,
+			Reason:      argoproj.ManagedNamespaceReasonLabel,
+			RBACProfile: r.getManagedNamespaceRBACProfile(namespace.Name),
+		}
+		if namespace.Name == cr.Namespace {
+			status.Reason = argoproj.ManagedNamespaceReasonInstance
+		} else if selection, ok := r.ManagedNamespaceSelections[namespace.Name]; ok {
+			status.Reason = argoproj.ManagedNamespaceReasonSelector
+			status.Selector = selection.Name
+		}
+		managed = append(managed, status)
+	}
+
+	sort.Slice(managed, func(i, j int) bool { return managed[i].Name < managed[j].Name })
+	return managed
+}
@@ -0,0 +1,154 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestNamespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestReconcileManagedNamespaces(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ManagedNamespaces = []argoproj.ManagedNamespacesSpec{
+			{
+				Name:        "payments",
+				Selector:    metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				RBACProfile: argoproj.ManagedNamespacesRBACProfileReadOnly,
+			},
+			{
+				Name:     "empty",
+				Selector: metav1.LabelSelector{},
+			},
+		}
+	})
+
+	resObjs := []client.Object{
+		a,
+		makeTestNamespace(a.Namespace, nil),
+		makeTestNamespace("payments-dev", map[string]string{"team": "payments"}),
+		makeTestNamespace("labelled", map[string]string{common.ArgoCDManagedByLabel: a.Namespace}),
+		makeTestNamespace("payments-other", map[string]string{"team": "payments", common.ArgoCDManagedByLabel: "other"}),
+		makeTestNamespace("billing", map[string]string{"team": "billing"}),
+	}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	reconcileNamespaces := func() {
+		assert.NoError(t, r.setManagedNamespaces(a))
+		assert.NoError(t, r.reconcileManagedNamespaces(a))
+		assert.NoError(t, r.reconcileRoles(a))
+	}
+	reconcileNamespaces()
+
+	// the selected namespace is labelled as managed by the instance
+	ns := &corev1.Namespace{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments-dev"}, ns))
+	assert.Equal(t, a.Namespace, ns.Labels[common.ArgoCDManagedByLabel])
+	assert.Equal(t, "payments", ns.Annotations[common.ArgoCDManagedBySelectorAnnotation])
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments-other"}, ns))
+	assert.Equal(t, "other", ns.Labels[common.ArgoCDManagedByLabel])
+
+	assert.Equal(t, []argoproj.ManagedNamespace{
+		{Name: a.Namespace, Reason: argoproj.ManagedNamespaceReasonInstance, RBACProfile: argoproj.ManagedNamespacesRBACProfileAdmin},
+		{Name: "labelled", Reason: argoproj.ManagedNamespaceReasonLabel, RBACProfile: argoproj.ManagedNamespacesRBACProfileAdmin},
+		{Name: "payments-dev", Reason: argoproj.ManagedNamespaceReasonSelector, Selector: "payments", RBACProfile: argoproj.ManagedNamespacesRBACProfileReadOnly},
+	}, a.Status.ManagedNamespaces)
+
+	// the RBAC profile defines the permissions of the application controller in the namespace
+	roleName := types.NamespacedName{Name: a.Name + "-" + common.ArgoCDApplicationControllerComponent}
+	role := &v1.Role{}
+	roleName.Namespace = "payments-dev"
+	assert.NoError(t, r.Client.Get(context.TODO(), roleName, role))
+	assert.Equal(t, policyRuleForManagedNamespaceReadOnly(), role.Rules)
+	roleName.Namespace = "labelled"
+	assert.NoError(t, r.Client.Get(context.TODO(), roleName, role))
+	assert.Equal(t, policyRuleForApplicationController(), role.Rules)
+
+	// the namespace is released once no longer selected
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments-dev"}, ns))
+	delete(ns.Labels, "team")
+	assert.NoError(t, r.Client.Update(context.TODO(), ns))
+	reconcileNamespaces()
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "payments-dev"}, ns))
+	assert.NotContains(t, ns.Labels, common.ArgoCDManagedByLabel)
+	assert.NotContains(t, ns.Annotations, common.ArgoCDManagedBySelectorAnnotation)
+	assert.Len(t, a.Status.ManagedNamespaces, 2)
+}
+
+func TestReconcileArgoCD_namespaceResourceMapperWithManagedNamespacesSelector(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ManagedNamespaces = []argoproj.ManagedNamespacesSpec{
+			{
+				Name:     "payments",
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}}
+	assert.Equal(t, want, r.namespaceResourceMapper(context.TODO(), makeTestNamespace("payments-dev", map[string]string{"team": "payments"})))
+	assert.Empty(t, r.namespaceResourceMapper(context.TODO(), makeTestNamespace("billing", map[string]string{"team": "billing"})))
+}
+
+func TestReconcileArgoCD_namespaceFilterPredicateWithManagedNamespacesSelector(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.ManagedNamespaces = []argoproj.ManagedNamespacesSpec{
+			{
+				Name:     "payments",
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	pred := r.namespaceFilterPredicate()
+
+	unselected := makeTestNamespace("billing", map[string]string{"team": "billing"})
+	selected := makeTestNamespace("billing", map[string]string{"team": "payments"})
+	relabelled := makeTestNamespace("billing", map[string]string{"team": "billing", "env": "dev"})
+
+	// the selections are recorded when the instance is reconciled
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: selected}))
+	assert.NoError(t, r.setManagedNamespaces(a))
+
+	// only the label changes selecting or deselecting a namespace are handled
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: selected}))
+	assert.True(t, pred.Update(event.UpdateEvent{ObjectOld: selected, ObjectNew: unselected}))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: relabelled}))
+
+	// the selections are forgotten once the instance no longer selects namespaces
+	a.Spec.ManagedNamespaces = nil
+	assert.NoError(t, r.setManagedNamespaces(a))
+	assert.False(t, pred.Update(event.UpdateEvent{ObjectOld: unselected, ObjectNew: selected}))
+}
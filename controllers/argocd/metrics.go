@@ -36,8 +36,105 @@ var (
 		Help:    "Length of time per reconciliation per instance",
 		Buckets: []float64{0.05, 0.075, 0.1, 0.15, 0.2, 0.22, 0.24, 0.26, 0.28, 0.3, 0.32, 0.34, 0.37, 0.4, 0.42, 0.44, 0.48, 0.5, 0.55, 0.6, 0.75, 0.9, 1.00},
 	}, []string{"namespace"})
+
+	// DriftedResources is a prometheus metric which keeps track of the number of operator-managed
+	// resources found drifted from their desired state during the last reconciliation of a given instance
+	DriftedResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_drifted_resources",
+			Help: "Number of operator-managed resources drifted from their desired state per instance and kind",
+		},
+		[]string{"namespace", "kind"},
+	)
+
+	// ComponentReady is a prometheus metric which reports whether each component of a given instance is running
+	ComponentReady = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_component_ready",
+			Help: "Whether the component of the instance is running (1) or not (0)",
+		},
+		[]string{"namespace", "name", "component"},
+	)
+
+	// ComponentInfo is a prometheus metric which reports the image deployed for each component of a given instance
+	ComponentInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_component_info",
+			Help: "Image deployed for the component of the instance",
+		},
+		[]string{"namespace", "name", "component", "image"},
+	)
+
+	// ReconcileErrors is a prometheus metric which counts the errors of each sub-reconciler for a given instance
+	ReconcileErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "argocd_instance_reconcile_errors_total",
+			Help: "Number of errors returned by the sub-reconciler of the instance",
+		},
+		[]string{"namespace", "name", "reconciler"},
+	)
+
+	// ManagedNamespaces is a prometheus metric which reports the number of namespaces managed by a given instance
+	ManagedNamespaces = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_managed_namespaces",
+			Help: "Number of namespaces managed by the instance, including its own namespace",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// SourceNamespaces is a prometheus metric which reports the number of application source namespaces of a given
+	// instance
+	SourceNamespaces = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_source_namespaces",
+			Help: "Number of application source namespaces of the instance",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ClusterSecrets is a prometheus metric which reports the number of cluster secrets of a given instance
+	ClusterSecrets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_cluster_secrets",
+			Help: "Number of cluster secrets of the instance",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// ControllerShards is a prometheus metric which reports the number of application controller shards of a given
+	// instance
+	ControllerShards = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_application_controller_shards",
+			Help: "Number of application controller shards of the instance",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// CertificateExpiry is a prometheus metric which reports the expiry of the TLS certificates of a given instance
+	CertificateExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "argocd_instance_tls_certificate_expiry_timestamp_seconds",
+			Help: "Expiry of the TLS certificate of the secret of the instance, as a unix timestamp",
+		},
+		[]string{"namespace", "name", "secret"},
+	)
 )
 
+// instanceMetrics are the metrics labelled by the namespace and the name of an instance.
+var instanceMetrics = []*prometheus.MetricVec{
+	ComponentReady.MetricVec,
+	ComponentInfo.MetricVec,
+	ReconcileErrors.MetricVec,
+	ManagedNamespaces.MetricVec,
+	SourceNamespaces.MetricVec,
+	ClusterSecrets.MetricVec,
+	ControllerShards.MetricVec,
+	CertificateExpiry.MetricVec,
+}
+
 func init() {
-	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime)
+	metrics.Registry.MustRegister(ActiveInstancesTotal, ActiveInstancesByPhase, ActiveInstanceReconciliationCount, ReconcileTime, DriftedResources)
+	metrics.Registry.MustRegister(ComponentReady, ComponentInfo, ReconcileErrors, ManagedNamespaces, SourceNamespaces, ClusterSecrets, ControllerShards, CertificateExpiry)
 }
@@ -52,6 +52,35 @@ func TestRedisNetworkPolicy(t *testing.T) {
 	assert.Equal(t, intstr.FromInt(6379), *np.Spec.Ingress[0].Ports[0].Port)
 }
 
+func TestRedisNetworkPolicy_redisExporter(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Prometheus.Enabled = true
+		a.Spec.Monitoring.ServiceMonitor.RedisExporter.Enabled = true
+	})
+	r := makeTestReconciler(makeTestReconcilerClient(makeTestReconcilerScheme(argoproj.AddToScheme), []client.Object{a}, []client.Object{a}, []runtime.Object{}), makeTestReconcilerScheme(argoproj.AddToScheme))
+
+	err := r.ReconcileRedisNetworkPolicy(a)
+	assert.NoError(t, err)
+
+	// the exporter port is open to any source
+	np := &networkingv1.NetworkPolicy{}
+	err = r.Get(context.TODO(), client.ObjectKey{Name: fmt.Sprintf("%s-%s", a.Name, RedisNetworkPolicy), Namespace: a.Namespace}, np)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(np.Spec.Ingress))
+	assert.Empty(t, np.Spec.Ingress[1].From)
+	assert.Equal(t, intstr.FromInt(9121), *np.Spec.Ingress[1].Ports[0].Port)
+
+	// the rule is removed once the exporter is disabled
+	a.Spec.Monitoring.ServiceMonitor.RedisExporter.Enabled = false
+	err = r.ReconcileRedisNetworkPolicy(a)
+	assert.NoError(t, err)
+
+	err = r.Get(context.TODO(), client.ObjectKey{Name: fmt.Sprintf("%s-%s", a.Name, RedisNetworkPolicy), Namespace: a.Namespace}, np)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(np.Spec.Ingress))
+	assert.Equal(t, intstr.FromInt(6379), *np.Spec.Ingress[0].Ports[0].Port)
+}
+
 func TestRedisHANetworkPolicy(t *testing.T) {
 	a := makeTestArgoCD()
 	r := makeTestReconciler(makeTestReconcilerClient(makeTestReconcilerScheme(argoproj.AddToScheme), []client.Object{a}, []client.Object{a}, []runtime.Object{}), makeTestReconcilerScheme(argoproj.AddToScheme))
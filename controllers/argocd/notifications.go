@@ -23,7 +23,7 @@ import (
 )
 
 const (
-	DefaultNotificationsConfigurationInstanceName = "default-notifications-configuration"
+	DefaultNotificationsConfigurationInstanceName = common.ArgoCDDefaultNotificationsConfigurationName
 )
 
 func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoproj.ArgoCD) error {
@@ -65,7 +65,7 @@ func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoproj.ArgoCD)
 		return err
 	}
 
-	if prometheusAPIFound {
+	if r.isPrometheusAPIAvailable() {
 		log.Info("reconciling notifications metrics service monitor")
 		if err := r.reconcileNotificationsServiceMonitor(cr); err != nil {
 			return err
@@ -544,29 +544,8 @@ func (r *ReconcileArgoCD) reconcileNotificationsMetricsService(cr *argoproj.Argo
 
 // reconcileNotificationsServiceMonitor will ensure that the ServiceMonitor for the Notifications controller metrics is present.
 func (r *ReconcileArgoCD) reconcileNotificationsServiceMonitor(cr *argoproj.ArgoCD) error {
-
-	name := fmt.Sprintf("%s-%s", cr.Name, "notifications-controller-metrics")
-	serviceMonitor := newServiceMonitorWithName(name, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, serviceMonitor.Name, serviceMonitor) {
-		// Service found, do nothing
-		return nil
-	}
-
-	serviceMonitor.Spec.Selector = v1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: name,
-		},
-	}
-
-	serviceMonitor.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port:     "metrics",
-			Scheme:   "http",
-			Interval: "30s",
-		},
-	}
-
-	return r.Client.Create(context.TODO(), serviceMonitor)
+	return r.reconcileServiceMonitor(cr, "notifications-controller-metrics", nameWithSuffix("notifications-controller-metrics", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics, Scheme: "http", Interval: "30s"}, cr.Spec.Notifications.Enabled)
 }
 
 // reconcileNotificationsSecret only creates/deletes the argocd-notifications-secret based on whether notifications is enabled/disabled in the CR
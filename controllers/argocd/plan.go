@@ -0,0 +1,209 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// PlanRedactedValue replaces the values of the Secrets rendered by Plan.
+const PlanRedactedValue = "<redacted>"
+
+// PlanOptions defines the cluster capabilities assumed when rendering the resources of an ArgoCD instance.
+type PlanOptions struct {
+	// RouteAPIAvailable renders OpenShift Routes as if the Route API was present.
+	RouteAPIAvailable bool
+
+	// GatewayAPIAvailable renders HTTPRoutes as if the Gateway API was present.
+	GatewayAPIAvailable bool
+
+	// GRPCRouteAPIAvailable renders GRPCRoutes as if the experimental GRPCRoute resource of the Gateway API was present.
+	GRPCRouteAPIAvailable bool
+
+	// PrometheusAPIAvailable renders Prometheus, PrometheusRules and ServiceMonitors as if the Prometheus API was present.
+	PrometheusAPIAvailable bool
+
+	// ManagedNamespaces are the namespaces labelled as managed by the instance.
+	ManagedNamespaces []string
+}
+
+// Plan renders every resource the operator would create for the given ArgoCD, without applying
+// anything to a cluster. The instance is reconciled against a fake client and the resulting objects
+// are returned sorted by kind, namespace and name. The data of rendered Secrets is redacted.
+func Plan(cr *argoproj.ArgoCD, opts PlanOptions) ([]client.Object, error) {
+	if cr.Namespace == "" {
+		return nil, fmt.Errorf("ArgoCD %s must have a namespace", cr.Name)
+	}
+
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme, argoprojv1alpha1.AddToScheme, argoproj.AddToScheme, monitoringv1.AddToScheme, routev1.Install,
+		gatewayv1.Install, gatewayv1alpha2.Install,
+	} {
+		if err := add(s); err != nil {
+			return nil, err
+		}
+	}
+
+	cr = cr.DeepCopy()
+	objs := []client.Object{cr, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}}}
+	for _, ns := range opts.ManagedNamespaces {
+		objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   ns,
+			Labels: map[string]string{common.ArgoCDManagedByLabel: cr.Namespace},
+		}})
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithStatusSubresource(cr).Build()
+	r := &ReconcileArgoCD{
+		Client: cl,
+		Scheme: s,
+		apis: &apiAvailability{
+			route:      opts.RouteAPIAvailable,
+			gateway:    opts.GatewayAPIAvailable,
+			grpcRoute:  opts.GRPCRouteAPIAvailable,
+			prometheus: opts.PrometheusAPIAvailable,
+		},
+	}
+
+	if err := r.setManagedNamespaces(cr); err != nil {
+		return nil, err
+	}
+	if err := r.setManagedSourceNamespaces(cr); err != nil {
+		return nil, err
+	}
+	if err := r.setManagedApplicationSetSourceNamespaces(cr); err != nil {
+		return nil, err
+	}
+
+	// Some resources are only created once the resources they depend on exist,
+	// a second pass renders them.
+	for i := 0; i < 2; i++ {
+		if err := r.reconcileResources(context.TODO(), cr); err != nil {
+			return nil, fmt.Errorf("failed to render resources: %w", err)
+		}
+	}
+
+	return listPlannedObjects(cl, s, opts)
+}
+
+// listPlannedObjects returns the objects rendered in the given client.
+func listPlannedObjects(cl client.Client, s *runtime.Scheme, opts PlanOptions) ([]client.Object, error) {
+	lists := []client.ObjectList{
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&corev1.ServiceList{},
+		&corev1.ServiceAccountList{},
+		&appsv1.DeploymentList{},
+		&appsv1.StatefulSetList{},
+		&autoscaling.HorizontalPodAutoscalerList{},
+		&rbacv1.RoleList{},
+		&rbacv1.RoleBindingList{},
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+		&networkingv1.IngressList{},
+		&networkingv1.NetworkPolicyList{},
+		&argoprojv1alpha1.NotificationsConfigurationList{},
+	}
+	if opts.RouteAPIAvailable {
+		lists = append(lists, &routev1.RouteList{})
+	}
+	if opts.GatewayAPIAvailable {
+		lists = append(lists, &gatewayv1.HTTPRouteList{})
+		if opts.GRPCRouteAPIAvailable {
+			lists = append(lists, &gatewayv1alpha2.GRPCRouteList{})
+		}
+	}
+	if opts.PrometheusAPIAvailable {
+		lists = append(lists, &monitoringv1.PrometheusList{}, &monitoringv1.PrometheusRuleList{}, &monitoringv1.ServiceMonitorList{})
+	}
+
+	planned := []client.Object{}
+	for _, list := range lists {
+		if err := cl.List(context.TODO(), list); err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			gvk, err := apiutil.GVKForObject(obj, s)
+			if err != nil {
+				return nil, err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			obj.SetResourceVersion("")
+			if secret, ok := obj.(*corev1.Secret); ok {
+				redactSecret(secret)
+			}
+			planned = append(planned, obj)
+		}
+	}
+
+	sort.SliceStable(planned, func(i, j int) bool {
+		a, b := planned[i], planned[j]
+		if ak, bk := a.GetObjectKind().GroupVersionKind().Kind, b.GetObjectKind().GroupVersionKind().Kind; ak != bk {
+			return ak < bk
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+	return planned, nil
+}
+
+// redactSecret replaces the values of the given Secret with PlanRedactedValue, keeping its keys.
+func redactSecret(secret *corev1.Secret) {
+	if len(secret.Data) == 0 && len(secret.StringData) == 0 {
+		return
+	}
+	redacted := make(map[string]string)
+	for k := range secret.Data {
+		redacted[k] = PlanRedactedValue
+	}
+	for k := range secret.StringData {
+		redacted[k] = PlanRedactedValue
+	}
+	secret.Data = nil
+	secret.StringData = redacted
+}
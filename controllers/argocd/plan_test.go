@@ -0,0 +1,83 @@
+package argocd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func findPlannedObject(objs []client.Object, kind, name string) client.Object {
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().Kind == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func TestPlan(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Route.Enabled = true
+	})
+
+	objs, err := Plan(a, PlanOptions{})
+	assert.NoError(t, err)
+
+	for _, want := range []struct{ kind, name string }{
+		{"Deployment", "argocd-server"},
+		{"Deployment", "argocd-repo-server"},
+		{"StatefulSet", "argocd-application-controller"},
+		{"ConfigMap", common.ArgoCDConfigMapName},
+		{"Service", "argocd-server"},
+		{"Role", "argocd-argocd-server"},
+		{"Secret", common.ArgoCDSecretName},
+	} {
+		assert.NotNil(t, findPlannedObject(objs, want.kind, want.name), "%s %s not rendered", want.kind, want.name)
+	}
+	assert.Nil(t, findPlannedObject(objs, "Route", "argocd-server"))
+	assert.Nil(t, findPlannedObject(objs, "ArgoCD", a.Name))
+
+	secret := findPlannedObject(objs, "Secret", common.ArgoCDSecretName).(*corev1.Secret)
+	assert.Empty(t, secret.Data)
+	assert.Equal(t, PlanRedactedValue, secret.StringData[common.ArgoCDKeyAdminPassword])
+
+	// the given instance is left untouched
+	assert.Empty(t, a.Status.Phase)
+}
+
+func TestPlan_withRouteAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Route.Enabled = true
+	})
+
+	objs, err := Plan(a, PlanOptions{RouteAPIAvailable: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, findPlannedObject(objs, "Route", "argocd-server"))
+	assert.False(t, IsRouteAPIAvailable())
+}
+
+func TestPlan_withGatewayAPI(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Gateway.Enabled = true
+		a.Spec.Server.GRPC.Gateway.Enabled = true
+	})
+
+	objs, err := Plan(a, PlanOptions{GatewayAPIAvailable: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, findPlannedObject(objs, "HTTPRoute", "argocd-server"))
+	assert.Nil(t, findPlannedObject(objs, "GRPCRoute", "argocd-grpc"))
+
+	objs, err = Plan(a, PlanOptions{GatewayAPIAvailable: true, GRPCRouteAPIAvailable: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, findPlannedObject(objs, "GRPCRoute", "argocd-grpc"))
+	assert.False(t, IsGatewayAPIAvailable())
+}
@@ -59,6 +59,27 @@ func policyRuleForApplicationControllerAdmin() []v1.PolicyRule {
 	return []v1.PolicyRule{}
 }
 
+// policyRuleForManagedNamespaceReadOnly returns the rules of the application controller and server in the managed
+// namespaces with the read-only RBAC profile. As RBAC rules can't exclude resources, and Argo CD compares the live
+// state of every resource of its applications, Secrets can be read as well.
+func policyRuleForManagedNamespaceReadOnly() []v1.PolicyRule {
+	return []v1.PolicyRule{
+		{
+			APIGroups: []string{
+				"*",
+			},
+			Resources: []string{
+				"*",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+	}
+}
+
 func policyRuleForRedis(client client.Client) []v1.PolicyRule {
 	rules := []v1.PolicyRule{
 		{
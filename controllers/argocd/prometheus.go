@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -55,6 +56,14 @@ func IsPrometheusAPIAvailable() bool {
 	return prometheusAPIFound
 }
 
+// isPrometheusAPIAvailable returns true if the Prometheus API is available to the reconciler.
+func (r *ReconcileArgoCD) isPrometheusAPIAvailable() bool {
+	if r.apis != nil {
+		return r.apis.prometheus
+	}
+	return IsPrometheusAPIAvailable()
+}
+
 // hasPrometheusSpecChanged will return true if the supported properties differs in the actual versus the desired state.
 func hasPrometheusSpecChanged(actual *monitoringv1.Prometheus, desired *argoproj.ArgoCD) bool {
 	// Replica count
@@ -126,34 +135,8 @@ func newServiceMonitorWithSuffix(suffix string, cr *argoproj.ArgoCD) *monitoring
 
 // reconcileMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD metrics Service.
 func (r *ReconcileArgoCD) reconcileMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix(common.ArgoCDKeyMetrics, cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
-
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
-
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix(common.ArgoCDKeyMetrics, cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
-	}
-
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), sm)
+	return r.reconcileServiceMonitor(cr, common.ArgoCDKeyMetrics, nameWithSuffix(common.ArgoCDKeyMetrics, cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, cr.Spec.Prometheus.Enabled)
 }
 
 // reconcilePrometheus will ensure that Prometheus is present for ArgoCD metrics.
@@ -187,66 +170,132 @@ func (r *ReconcileArgoCD) reconcilePrometheus(cr *argoproj.ArgoCD) error {
 
 // reconcileRepoServerServiceMonitor will ensure that the ServiceMonitor is present for the Repo Server metrics Service.
 func (r *ReconcileArgoCD) reconcileRepoServerServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("repo-server-metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
-		}
-		return nil // ServiceMonitor found, do nothing
-	}
+	return r.reconcileServiceMonitor(cr, "repo-server-metrics", nameWithSuffix("repo-server", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, cr.Spec.Prometheus.Enabled)
+}
 
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
-	}
+// reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
+func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileServiceMonitor(cr, "server-metrics", nameWithSuffix("server-metrics", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, cr.Spec.Prometheus.Enabled)
+}
 
-	sm.Spec.Selector = metav1.LabelSelector{
-		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("repo-server", cr),
-		},
-	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
-	}
+// reconcileDexServiceMonitor will ensure that the ServiceMonitor is present for the Dex metrics Service when Dex is used.
+func (r *ReconcileArgoCD) reconcileDexServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileServiceMonitor(cr, "dex-server-metrics", nameWithSuffix("dex-server-metrics", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, cr.Spec.Prometheus.Enabled && UseDex(cr))
+}
 
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), sm)
+// reconcileApplicationSetServiceMonitor will ensure that the ServiceMonitor is present for the ApplicationSet
+// controller Service when the controller is enabled.
+func (r *ReconcileArgoCD) reconcileApplicationSetServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileServiceMonitor(cr, "applicationset-controller-metrics", nameWithSuffix("applicationset-controller", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, cr.Spec.Prometheus.Enabled && cr.Spec.ApplicationSet != nil)
 }
 
-// reconcileServerMetricsServiceMonitor will ensure that the ServiceMonitor is present for the ArgoCD Server metrics Service.
-func (r *ReconcileArgoCD) reconcileServerMetricsServiceMonitor(cr *argoproj.ArgoCD) error {
-	sm := newServiceMonitorWithSuffix("server-metrics", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, sm.Name, sm) {
-		if !cr.Spec.Prometheus.Enabled {
-			// ServiceMonitor exists but enabled flag has been set to false, delete the ServiceMonitor
-			return r.Client.Delete(context.TODO(), sm)
+// reconcileRedisServiceMonitor will ensure that the ServiceMonitor is present for the Redis exporter Service when
+// the exporter is enabled.
+func (r *ReconcileArgoCD) reconcileRedisServiceMonitor(cr *argoproj.ArgoCD) error {
+	return r.reconcileServiceMonitor(cr, "redis-metrics", nameWithSuffix("redis-metrics", cr),
+		monitoringv1.Endpoint{Port: common.ArgoCDKeyMetrics}, isRedisExporterEnabled(cr))
+}
+
+// isRedisExporterEnabled returns true when the Redis exporter is deployed alongside the Redis of the given instance.
+// The exporter isn't supported by Redis HA.
+func isRedisExporterEnabled(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Prometheus.Enabled && cr.Spec.Monitoring.ServiceMonitor.RedisExporter.Enabled &&
+		!cr.Spec.HA.Enabled && cr.Spec.Redis.IsEnabled() && !cr.Spec.Redis.IsRemote()
+}
+
+// getServiceMonitorEndpoint returns the given default endpoint of a ServiceMonitor configured with the scrape
+// options of spec.monitoring.serviceMonitor.
+func getServiceMonitorEndpoint(cr *argoproj.ArgoCD, endpoint monitoringv1.Endpoint) monitoringv1.Endpoint {
+	spec := cr.Spec.Monitoring.ServiceMonitor
+	if spec.Interval != "" {
+		endpoint.Interval = spec.Interval
+	}
+	if spec.ScrapeTimeout != "" {
+		endpoint.ScrapeTimeout = spec.ScrapeTimeout
+	}
+	if spec.Scheme != "" {
+		endpoint.Scheme = spec.Scheme
+	}
+	if spec.TLSConfig != nil {
+		endpoint.TLSConfig = &monitoringv1.TLSConfig{
+			CA:                 monitoringv1.SecretOrConfigMap{Secret: spec.TLSConfig.CASecret},
+			Cert:               monitoringv1.SecretOrConfigMap{Secret: spec.TLSConfig.CertSecret},
+			KeySecret:          spec.TLSConfig.KeySecret,
+			ServerName:         spec.TLSConfig.ServerName,
+			InsecureSkipVerify: spec.TLSConfig.InsecureSkipVerify,
 		}
-		return nil // ServiceMonitor found, do nothing
 	}
-
-	if !cr.Spec.Prometheus.Enabled {
-		return nil // Prometheus not enabled, do nothing.
+	if spec.BearerTokenSecret != nil {
+		endpoint.BearerTokenSecret = *spec.BearerTokenSecret
 	}
+	for _, relabeling := range spec.MetricRelabelings {
+		endpoint.MetricRelabelConfigs = append(endpoint.MetricRelabelConfigs, &monitoringv1.RelabelConfig{
+			SourceLabels: relabeling.SourceLabels,
+			Separator:    relabeling.Separator,
+			TargetLabel:  relabeling.TargetLabel,
+			Regex:        relabeling.Regex,
+			Replacement:  relabeling.Replacement,
+			Action:       relabeling.Action,
+		})
+	}
+	return endpoint
+}
 
-	sm.Spec.Selector = metav1.LabelSelector{
+// reconcileServiceMonitor will ensure that the ServiceMonitor with the given suffix scrapes the given endpoint of the
+// Service with the given name when enabled, and that it is absent otherwise. The endpoint is configured with the
+// scrape options of spec.monitoring.serviceMonitor, whose changes are applied to the existing ServiceMonitor.
+func (r *ReconcileArgoCD) reconcileServiceMonitor(cr *argoproj.ArgoCD, suffix string, serviceName string, endpoint monitoringv1.Endpoint, enabled bool) error {
+	desired := newServiceMonitorWithSuffix(suffix, cr)
+	for key, value := range cr.Spec.Monitoring.ServiceMonitor.Labels {
+		if key != common.ArgoCDKeyName {
+			desired.Labels[key] = value
+		}
+	}
+	desired.Spec.Selector = metav1.LabelSelector{
 		MatchLabels: map[string]string{
-			common.ArgoCDKeyName: nameWithSuffix("server-metrics", cr),
+			common.ArgoCDKeyName: serviceName,
 		},
 	}
-	sm.Spec.Endpoints = []monitoringv1.Endpoint{
-		{
-			Port: common.ArgoCDKeyMetrics,
-		},
+	desired.Spec.Endpoints = []monitoringv1.Endpoint{getServiceMonitorEndpoint(cr, endpoint)}
+
+	existing := &monitoringv1.ServiceMonitor{}
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, desired.Name, existing) {
+		if !enabled {
+			// ServiceMonitor exists but should not, delete the ServiceMonitor
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		changed := false
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		for key, value := range desired.Labels {
+			if existing.Labels[key] != value {
+				existing.Labels[key] = value
+				changed = true
+			}
+		}
+		if !reflect.DeepEqual(existing.Spec, desired.Spec) {
+			existing.Spec = desired.Spec
+			changed = true
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // ServiceMonitor up to date, do nothing
 	}
 
-	if err := controllerutil.SetControllerReference(cr, sm, r.Scheme); err != nil {
+	if !enabled {
+		return nil // ServiceMonitor not enabled, do nothing.
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.Scheme); err != nil {
 		return err
 	}
-	return r.Client.Create(context.TODO(), sm)
+	return r.Client.Create(context.TODO(), desired)
 }
 
 // reconcilePrometheusRule reconciles the PrometheusRule that triggers alerts based on workload statuses
@@ -261,126 +310,186 @@ func (r *ReconcileArgoCD) reconcilePrometheusRule(cr *argoproj.ArgoCD) error {
 			log.Info("instance monitoring disabled, deleting component status tracking prometheusRule")
 			return r.Client.Delete(context.TODO(), promRule)
 		}
-		return nil // PrometheusRule found, do nothing
+
+		ruleGroups := getPrometheusRuleGroups(cr)
+		if reflect.DeepEqual(promRule.Spec.Groups, ruleGroups) {
+			return nil // PrometheusRule up to date, do nothing
+		}
+		promRule.Spec.Groups = ruleGroups
+		log.Info("updating component status tracking prometheusRule")
+		return r.Client.Update(context.TODO(), promRule)
 	}
 
 	if !cr.Spec.Monitoring.Enabled {
 		return nil // Monitoring not enabled, do nothing.
 	}
 
+	promRule.Spec.Groups = getPrometheusRuleGroups(cr)
+
+	if err := controllerutil.SetControllerReference(cr, promRule, r.Scheme); err != nil {
+		return err
+	}
+
+	log.Info("instance monitoring enabled, creating component status tracking prometheusRule")
+	return r.Client.Create(context.TODO(), promRule) // Create PrometheusRule
+}
+
+// getPrometheusRuleGroups returns the rule groups of the PrometheusRule of the given ArgoCD: the alerts on the
+// workload statuses, the alerts on the Applications, and the custom alerts of the ArgoCD.
+func getPrometheusRuleGroups(cr *argoproj.ArgoCD) []monitoringv1.RuleGroup {
+	alerts := cr.Spec.Monitoring.Alerts
+
 	ruleGroups := []monitoringv1.RuleGroup{
 		{
-			Name: "ArgoCDComponentStatus",
-			Rules: []monitoringv1.Rule{
-				{
-					Alert: "ApplicationControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("application controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_statefulset_status_replicas{statefulset=\"%s\", namespace=\"%s\"} != kube_statefulset_status_replicas_ready{statefulset=\"%s\", namespace=\"%s\"} ", cr.Name+"-application-controller", cr.Namespace, cr.Name+"-application-controller", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "ServerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("server deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-server", cr.Namespace, cr.Name+"-server", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "RepoServerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("repo server deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-repo-server", cr.Namespace, cr.Name+"-repo-server", cr.Namespace),
-					},
-					For: "1m",
-					Labels: map[string]string{
-						"severity": "critical",
-					},
-				},
-				{
-					Alert: "ApplicationSetControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("applicationSet controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-applicationset-controller", cr.Namespace, cr.Name+"-applicationset-controller", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "DexNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("dex deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-dex-server", cr.Namespace, cr.Name+"-dex-server", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "NotificationsControllerNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("notifications controller deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-notifications-controller", cr.Namespace, cr.Name+"-notifications-controller", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
-				{
-					Alert: "RedisNotReady",
-					Annotations: map[string]string{
-						"message": fmt.Sprintf("redis deployment for Argo CD instance in namespace %s is not running", cr.Namespace),
-					},
-					Expr: intstr.IntOrString{
-						Type:   intstr.String,
-						StrVal: fmt.Sprintf("kube_deployment_status_replicas{deployment=\"%s\", namespace=\"%s\"} != kube_deployment_status_replicas_ready{deployment=\"%s\", namespace=\"%s\"} ", cr.Name+"-redis", cr.Namespace, cr.Name+"-redis", cr.Namespace),
-					},
-					For: "5m",
-					Labels: map[string]string{
-						"severity": "warning",
-					},
-				},
+			Name:  "ArgoCDComponentStatus",
+			Rules: applyAlertOverrides(cr, getComponentStatusRules(cr)),
+		},
+	}
+
+	if !alerts.ApplicationAlertsDisabled {
+		ruleGroups = append(ruleGroups, monitoringv1.RuleGroup{
+			Name:  "ArgoCDApplicationStatus",
+			Rules: applyAlertOverrides(cr, getApplicationStatusRules(cr)),
+		})
+	}
+
+	if len(alerts.CustomRules) > 0 {
+		rules := []monitoringv1.Rule{}
+		for _, custom := range alerts.CustomRules {
+			labels := map[string]string{}
+			for k, v := range alerts.Labels {
+				labels[k] = v
+			}
+			for k, v := range custom.Labels {
+				labels[k] = v
+			}
+			rules = append(rules, monitoringv1.Rule{
+				Alert:       custom.Alert,
+				Annotations: custom.Annotations,
+				Expr:        intstr.FromString(custom.Expr),
+				For:         custom.For,
+				Labels:      labels,
+			})
+		}
+		ruleGroups = append(ruleGroups, monitoringv1.RuleGroup{
+			Name:  "ArgoCDCustom",
+			Rules: rules,
+		})
+	}
+
+	return ruleGroups
+}
+
+// getComponentStatusRules returns the alerts firing when a workload of the given ArgoCD isn't ready.
+func getComponentStatusRules(cr *argoproj.ArgoCD) []monitoringv1.Rule {
+	redisRule := newWorkloadNotReadyRule(cr, "RedisNotReady", "redis deployment", "deployment", nameWithSuffix("redis", cr), "5m", "warning")
+	if cr.Spec.HA.Enabled {
+		redisRule = newWorkloadNotReadyRule(cr, "RedisNotReady", "redis statefulset", "statefulset", nameWithSuffix("redis-ha-server", cr), "5m", "warning")
+	}
+	repoServerRule := newWorkloadNotReadyRule(cr, "RepoServerNotReady", "repo server deployment", "deployment", nameWithSuffix("repo-server", cr), "1m", "critical")
+	if useRepoCache(cr) {
+		repoServerRule = newWorkloadNotReadyRule(cr, "RepoServerNotReady", "repo server statefulset", "statefulset", nameWithSuffix("repo-server", cr), "1m", "critical")
+	}
+
+	rules := []monitoringv1.Rule{
+		newWorkloadNotReadyRule(cr, "ApplicationControllerNotReady", "application controller deployment", "statefulset", nameWithSuffix("application-controller", cr), "1m", "critical"),
+		newWorkloadNotReadyRule(cr, "ServerNotReady", "server deployment", "deployment", nameWithSuffix("server", cr), "1m", "critical"),
+		repoServerRule,
+		newWorkloadNotReadyRule(cr, "ApplicationSetControllerNotReady", "applicationSet controller deployment", "deployment", nameWithSuffix("applicationset-controller", cr), "5m", "warning"),
+		newWorkloadNotReadyRule(cr, "DexNotReady", "dex deployment", "deployment", nameWithSuffix("dex-server", cr), "5m", "warning"),
+		newWorkloadNotReadyRule(cr, "NotificationsControllerNotReady", "notifications controller deployment", "deployment", nameWithSuffix("notifications-controller", cr), "5m", "warning"),
+		redisRule,
+	}
+	if cr.Spec.HA.Enabled {
+		rules = append(rules, newWorkloadNotReadyRule(cr, "RedisHAProxyNotReady", "redis HA proxy deployment", "deployment", nameWithSuffix("redis-ha-haproxy", cr), "5m", "warning"))
+	}
+	return rules
+}
+
+// newWorkloadNotReadyRule returns an alert firing when the ready replicas of the given workload differ from its
+// replicas.
+func newWorkloadNotReadyRule(cr *argoproj.ArgoCD, alert, description, kind, name, duration, severity string) monitoringv1.Rule {
+	return monitoringv1.Rule{
+		Alert: alert,
+		Annotations: map[string]string{
+			"message": fmt.Sprintf("%s for Argo CD instance in namespace %s is not running", description, cr.Namespace),
+		},
+		Expr: intstr.IntOrString{
+			Type:   intstr.String,
+			StrVal: fmt.Sprintf("kube_%s_status_replicas{%s=\"%s\", namespace=\"%s\"} != kube_%s_status_replicas_ready{%s=\"%s\", namespace=\"%s\"} ", kind, kind, name, cr.Namespace, kind, kind, name, cr.Namespace),
+		},
+		For: duration,
+		Labels: map[string]string{
+			"severity": severity,
+		},
+	}
+}
+
+// getApplicationStatusRules returns the alerts firing when Applications of the given ArgoCD are out of sync,
+// degraded, or fail to sync, based on the metrics of the application controller.
+func getApplicationStatusRules(cr *argoproj.ArgoCD) []monitoringv1.Rule {
+	selector := fmt.Sprintf("namespace=\"%s\", job=\"%s\"", cr.Namespace, nameWithSuffix(common.ArgoCDKeyMetrics, cr))
+	return []monitoringv1.Rule{
+		{
+			Alert: "ApplicationsOutOfSync",
+			Annotations: map[string]string{
+				"message": fmt.Sprintf("{{ $value }} applications of Argo CD instance in namespace %s are out of sync", cr.Namespace),
+			},
+			Expr:   intstr.FromString(fmt.Sprintf("sum(argocd_app_info{%s, sync_status=\"OutOfSync\"}) > 0", selector)),
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+		},
+		{
+			Alert: "ApplicationsDegraded",
+			Annotations: map[string]string{
+				"message": fmt.Sprintf("{{ $value }} applications of Argo CD instance in namespace %s are degraded", cr.Namespace),
+			},
+			Expr:   intstr.FromString(fmt.Sprintf("sum(argocd_app_info{%s, health_status=\"Degraded\"}) > 0", selector)),
+			For:    "15m",
+			Labels: map[string]string{"severity": "warning"},
+		},
+		{
+			Alert: "ApplicationSyncFailed",
+			Annotations: map[string]string{
+				"message": fmt.Sprintf("{{ $value }} syncs of applications of Argo CD instance in namespace %s failed in the last 10 minutes", cr.Namespace),
 			},
+			Expr:   intstr.FromString(fmt.Sprintf("sum(increase(argocd_app_sync_total{%s, phase=~\"Error|Failed\"}[10m])) > 0", selector)),
+			For:    "1m",
+			Labels: map[string]string{"severity": "warning"},
 		},
 	}
-	promRule.Spec.Groups = ruleGroups
+}
 
-	if err := controllerutil.SetControllerReference(cr, promRule, r.Scheme); err != nil {
-		return err
+// applyAlertOverrides applies the labels and the overrides of the alerts of the given ArgoCD to the given rules, and
+// removes the disabled ones.
+func applyAlertOverrides(cr *argoproj.ArgoCD, rules []monitoringv1.Rule) []monitoringv1.Rule {
+	alerts := cr.Spec.Monitoring.Alerts
+	overrides := map[string]argoproj.ArgoCDAlertOverride{}
+	for _, override := range alerts.Overrides {
+		overrides[override.Alert] = override
 	}
 
-	log.Info("instance monitoring enabled, creating component status tracking prometheusRule")
-	return r.Client.Create(context.TODO(), promRule) // Create PrometheusRule
+	result := []monitoringv1.Rule{}
+	for _, rule := range rules {
+		override := overrides[rule.Alert]
+		if override.Disabled {
+			continue
+		}
+		for k, v := range alerts.Labels {
+			rule.Labels[k] = v
+		}
+		for k, v := range override.Labels {
+			rule.Labels[k] = v
+		}
+		if override.Severity != "" {
+			rule.Labels["severity"] = override.Severity
+		}
+		if override.For != "" {
+			rule.For = override.For
+		}
+		result = append(result, rule)
+	}
+	return result
 }
 
 // newPrometheusRule returns an empty PrometheusRule
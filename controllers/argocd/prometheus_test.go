@@ -7,6 +7,8 @@ import (
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -201,10 +203,155 @@ func TestReconcileWorkloadStatusAlertRule(t *testing.T) {
 				}
 
 				if !test.existingPromRule {
-					assert.Equal(t, desiredRuleGroup, testRule.Spec.Groups)
+					assert.Equal(t, desiredRuleGroup, testRule.Spec.Groups[:1])
+					assert.Equal(t, "ArgoCDApplicationStatus", testRule.Spec.Groups[1].Name)
 				}
 
 			}
 		})
 	}
 }
+
+func TestReconcileWorkloadStatusAlertRule_Customized(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Monitoring.Enabled = true
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcilePrometheusRule(a))
+
+	// the rule group is updated with the alerts configuration of the instance
+	a.Spec.HA.Enabled = true
+	a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{Enabled: true}
+	a.Spec.Monitoring.Alerts = argoproj.ArgoCDMonitoringAlertsSpec{
+		ApplicationAlertsDisabled: true,
+		Labels:                    map[string]string{"team": "platform"},
+		Overrides: []argoproj.ArgoCDAlertOverride{
+			{Alert: "ServerNotReady", For: "10m", Severity: "warning", Labels: map[string]string{"team": "web"}},
+			{Alert: "DexNotReady", Disabled: true},
+		},
+		CustomRules: []argoproj.ArgoCDAlertRule{
+			{Alert: "TooManyApplications", Expr: "count(argocd_app_info) > 1000", For: "1h", Labels: map[string]string{"severity": "info"}},
+		},
+	}
+	assert.NoError(t, r.reconcilePrometheusRule(a))
+
+	rule := &monitoringv1.PrometheusRule{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-component-status-alert", Namespace: a.Namespace}, rule))
+	assert.Len(t, rule.Spec.Groups, 2)
+
+	alerts := map[string]monitoringv1.Rule{}
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			alerts[r.Alert] = r
+		}
+	}
+	assert.NotContains(t, alerts, "DexNotReady")
+	assert.NotContains(t, alerts, "ApplicationsOutOfSync")
+	assert.Equal(t, "10m", alerts["ServerNotReady"].For)
+	assert.Equal(t, map[string]string{"severity": "warning", "team": "web"}, alerts["ServerNotReady"].Labels)
+	assert.Equal(t, map[string]string{"severity": "critical", "team": "platform"}, alerts["RepoServerNotReady"].Labels)
+	assert.Contains(t, alerts["RepoServerNotReady"].Expr.StrVal, "kube_statefulset_status_replicas{statefulset=\"argocd-repo-server\"")
+	assert.Contains(t, alerts["RedisNotReady"].Expr.StrVal, "kube_statefulset_status_replicas{statefulset=\"argocd-redis-ha-server\"")
+	assert.Contains(t, alerts, "RedisHAProxyNotReady")
+
+	assert.Equal(t, "ArgoCDCustom", rule.Spec.Groups[1].Name)
+	assert.Equal(t, "count(argocd_app_info) > 1000", alerts["TooManyApplications"].Expr.StrVal)
+	assert.Equal(t, map[string]string{"severity": "info", "team": "platform"}, alerts["TooManyApplications"].Labels)
+}
+
+func TestReconcileServiceMonitors(t *testing.T) {
+	a := makeTestArgoCD(func(cr *argoproj.ArgoCD) {
+		cr.Spec.Prometheus.Enabled = true
+		cr.Spec.ApplicationSet = &argoproj.ArgoCDApplicationSet{}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme, monitoringv1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	reconcileServiceMonitors := func() {
+		assert.NoError(t, r.reconcileMetricsServiceMonitor(a))
+		assert.NoError(t, r.reconcileRepoServerServiceMonitor(a))
+		assert.NoError(t, r.reconcileServerMetricsServiceMonitor(a))
+		assert.NoError(t, r.reconcileDexServiceMonitor(a))
+		assert.NoError(t, r.reconcileApplicationSetServiceMonitor(a))
+		assert.NoError(t, r.reconcileRedisServiceMonitor(a))
+	}
+	getServiceMonitor := func(suffix string) (*monitoringv1.ServiceMonitor, error) {
+		sm := &monitoringv1.ServiceMonitor{}
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: fmt.Sprintf("%s-%s", a.Name, suffix), Namespace: a.Namespace}, sm)
+		return sm, err
+	}
+
+	// without configuration, the metrics port is scraped with the defaults of Prometheus
+	reconcileServiceMonitors()
+	sm, err := getServiceMonitor("applicationset-controller-metrics")
+	assert.NoError(t, err)
+	assert.Equal(t, "argocd-applicationset-controller", sm.Spec.Selector.MatchLabels["app.kubernetes.io/name"])
+	assert.Equal(t, []monitoringv1.Endpoint{{Port: "metrics"}}, sm.Spec.Endpoints)
+	_, err = getServiceMonitor("dex-server-metrics")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = getServiceMonitor("redis-metrics")
+	assert.True(t, errors.IsNotFound(err))
+
+	// the scrape options are applied to the existing service monitors
+	a.Spec.SSO = &argoproj.ArgoCDSSOSpec{Provider: argoproj.SSOProviderTypeDex, Dex: &argoproj.ArgoCDDexSpec{OpenShiftOAuth: true}}
+	a.Spec.Monitoring.ServiceMonitor = argoproj.ArgoCDServiceMonitorSpec{
+		Interval:      "15s",
+		ScrapeTimeout: "5s",
+		Scheme:        "https",
+		TLSConfig: &argoproj.ArgoCDServiceMonitorTLSConfig{
+			CASecret:   &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "metrics-ca"}, Key: "ca.crt"},
+			ServerName: "argocd.example.com",
+		},
+		BearerTokenSecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "metrics-token"}, Key: "token"},
+		Labels:            map[string]string{"release": "kube-prometheus", "app.kubernetes.io/name": "ignored"},
+		MetricRelabelings: []argoproj.ArgoCDRelabelConfig{
+			{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: "drop"},
+		},
+		RedisExporter: argoproj.ArgoCDRedisExporterSpec{Enabled: true},
+	}
+	reconcileServiceMonitors()
+
+	want := monitoringv1.Endpoint{
+		Port:          "metrics",
+		Scheme:        "https",
+		Interval:      "15s",
+		ScrapeTimeout: "5s",
+		TLSConfig: &monitoringv1.TLSConfig{
+			CA:         monitoringv1.SecretOrConfigMap{Secret: a.Spec.Monitoring.ServiceMonitor.TLSConfig.CASecret},
+			ServerName: "argocd.example.com",
+		},
+		BearerTokenSecret:    *a.Spec.Monitoring.ServiceMonitor.BearerTokenSecret,
+		MetricRelabelConfigs: []*monitoringv1.RelabelConfig{{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: "drop"}},
+	}
+	for _, suffix := range []string{"metrics", "repo-server-metrics", "server-metrics", "dex-server-metrics", "applicationset-controller-metrics", "redis-metrics"} {
+		sm, err := getServiceMonitor(suffix)
+		assert.NoError(t, err, suffix)
+		assert.Equal(t, []monitoringv1.Endpoint{want}, sm.Spec.Endpoints, suffix)
+		assert.Equal(t, "kube-prometheus", sm.Labels["release"], suffix)
+		assert.Equal(t, sm.Name, sm.Labels["app.kubernetes.io/name"], suffix)
+	}
+
+	// the service monitors of disabled components are deleted
+	a.Spec.SSO = nil
+	a.Spec.ApplicationSet = nil
+	a.Spec.Monitoring.ServiceMonitor.RedisExporter.Enabled = false
+	reconcileServiceMonitors()
+	for _, suffix := range []string{"dex-server-metrics", "applicationset-controller-metrics", "redis-metrics"} {
+		_, err := getServiceMonitor(suffix)
+		assert.True(t, errors.IsNotFound(err), suffix)
+	}
+	_, err = getServiceMonitor("metrics")
+	assert.NoError(t, err)
+}
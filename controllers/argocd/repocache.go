@@ -0,0 +1,337 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// repoCacheVolumeName is the name of the repo server volume mounted at /tmp, backed by a PersistentVolumeClaim
+// per replica when the persistent cache is enabled.
+const repoCacheVolumeName = "tmp"
+
+// repoVolumeMountsOverrideTmp returns true if the user specified a custom volume mount for /tmp in the repo server.
+func repoVolumeMountsOverrideTmp(cr *argoproj.ArgoCD) bool {
+	for _, volumeMount := range cr.Spec.Repo.VolumeMounts {
+		if volumeMount.MountPath == "/tmp" {
+			return true
+		}
+	}
+	return false
+}
+
+// useRepoCache returns true if the repo server should run as a StatefulSet with a persistent cache. A custom /tmp
+// volume mount takes precedence over the cache.
+func useRepoCache(cr *argoproj.ArgoCD) bool {
+	return cr.Spec.Repo.IsCacheEnabled() && !repoVolumeMountsOverrideTmp(cr)
+}
+
+// newRepoServerWorkload returns the workload running the repo server for the given ArgoCD, which is a StatefulSet
+// when the persistent cache is in use and a Deployment otherwise.
+func newRepoServerWorkload(cr *argoproj.ArgoCD) client.Object {
+	if useRepoCache(cr) {
+		return newStatefulSetWithSuffix("repo-server", "repo-server", cr)
+	}
+	return newDeploymentWithSuffix("repo-server", "repo-server", cr)
+}
+
+// getRepoCacheEnv returns the environment variables tuning the caching and parallelism of the repo server.
+func getRepoCacheEnv(cr *argoproj.ArgoCD) []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+	cache := cr.Spec.Repo.Cache
+	if cache == nil {
+		return env
+	}
+
+	if cache.ParallelismLimit != nil {
+		env = append(env, corev1.EnvVar{Name: "ARGOCD_REPO_SERVER_PARALLELISM_LIMIT", Value: fmt.Sprint(*cache.ParallelismLimit)})
+	}
+	if cache.LsRemoteParallelismLimit != nil {
+		env = append(env, corev1.EnvVar{Name: "ARGOCD_GIT_LS_REMOTE_PARALLELISM_LIMIT", Value: fmt.Sprint(*cache.LsRemoteParallelismLimit)})
+	}
+	if cache.Expiration != nil {
+		env = append(env, corev1.EnvVar{Name: "ARGOCD_REPO_CACHE_EXPIRATION", Value: cache.Expiration.Duration.String()})
+	}
+	return env
+}
+
+// getRepoCacheVolumeClaimTemplate returns the PersistentVolumeClaim template of the repo server cache volumes.
+func getRepoCacheVolumeClaimTemplate(cr *argoproj.ArgoCD) corev1.PersistentVolumeClaim {
+	size := resource.MustParse(common.ArgoCDDefaultRepoCacheSize)
+	if cr.Spec.Repo.Cache.Size != nil {
+		size = *cr.Spec.Repo.Cache.Size
+	}
+
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: repoCacheVolumeName,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+			StorageClassName: cr.Spec.Repo.Cache.StorageClassName,
+		},
+	}
+}
+
+// getRepoCacheRetentionPolicy returns the retention policy of the repo server cache volumes, derived from the
+// eviction policy of the cache.
+func getRepoCacheRetentionPolicy(cr *argoproj.ArgoCD) *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy {
+	policy := appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+	if cr.Spec.Repo.Cache.EvictionPolicy == argoproj.ArgoCDRepoCacheEvictionPolicyDelete {
+		policy = appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+	}
+	return &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+		WhenDeleted: policy,
+		WhenScaled:  policy,
+	}
+}
+
+// repoCacheStorageClassChanged returns true if the storage class of the cache volumes differ between the existing
+// and the desired claim templates.
+func repoCacheStorageClassChanged(existing []corev1.PersistentVolumeClaim, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		if existing[i].Name != desired[i].Name || !reflect.DeepEqual(existing[i].Spec.StorageClassName, desired[i].Spec.StorageClassName) {
+			return true
+		}
+	}
+	return false
+}
+
+// repoCacheClaimChanged returns true if the size or the storage class of the cache volumes differ between the
+// existing and the desired claim templates.
+func repoCacheClaimChanged(existing []corev1.PersistentVolumeClaim, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		existingSize := existing[i].Spec.Resources.Requests[corev1.ResourceStorage]
+		desiredSize := desired[i].Spec.Resources.Requests[corev1.ResourceStorage]
+		if existing[i].Name != desired[i].Name || existingSize.Cmp(desiredSize) != 0 {
+			return true
+		}
+		if !reflect.DeepEqual(existing[i].Spec.StorageClassName, desired[i].Spec.StorageClassName) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileRepoStatefulSet will ensure the repo server runs as a StatefulSet with a persistent cache volume per
+// replica. The given Deployment carries the desired pod template of the repo server, and is removed once the
+// StatefulSet exists.
+func (r *ReconcileArgoCD) reconcileRepoStatefulSet(cr *argoproj.ArgoCD, deploy *appsv1.Deployment) error {
+	ss := newStatefulSetWithSuffix("repo-server", "repo-server", cr)
+	ss.Spec.Template = deploy.Spec.Template
+	ss.Spec.Replicas = deploy.Spec.Replicas
+	if ss.Spec.Replicas == nil {
+		var replicas int32 = 1
+		ss.Spec.Replicas = &replicas
+	}
+	ss.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+	ss.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{getRepoCacheVolumeClaimTemplate(cr)}
+	ss.Spec.PersistentVolumeClaimRetentionPolicy = getRepoCacheRetentionPolicy(cr)
+
+	existing := newStatefulSetWithSuffix("repo-server", "repo-server", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !cr.Spec.Repo.IsEnabled() {
+			log.Info("Existing ArgoCD Repo Server found but should be disabled. Deleting Repo Server")
+			return r.Client.Delete(context.TODO(), existing)
+		} else if cr.Spec.Repo.IsRemote() {
+			log.Info("Repo Server remote field exists, Repo Server statefulset should be disabled. Deleting Repo Server.")
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		if existing.DeletionTimestamp != nil {
+			// The StatefulSet is being replaced, it is recreated once removed.
+			return nil
+		}
+
+		if repoCacheClaimChanged(existing.Spec.VolumeClaimTemplates, ss.Spec.VolumeClaimTemplates) {
+			return r.replaceRepoStatefulSet(cr, existing, ss)
+		}
+
+		changed := false
+		updateRepoPodTemplate(&existing.Spec.Template, &ss.Spec.Template, &changed)
+		if !reflect.DeepEqual(ss.Spec.Replicas, existing.Spec.Replicas) {
+			existing.Spec.Replicas = ss.Spec.Replicas
+			changed = true
+		}
+		if !reflect.DeepEqual(ss.Spec.PersistentVolumeClaimRetentionPolicy, existing.Spec.PersistentVolumeClaimRetentionPolicy) {
+			existing.Spec.PersistentVolumeClaimRetentionPolicy = ss.Spec.PersistentVolumeClaimRetentionPolicy
+			changed = true
+		}
+
+		if changed {
+			if err := r.Client.Update(context.TODO(), existing); err != nil {
+				return err
+			}
+		}
+		return r.deleteRepoDeployment(cr)
+	}
+
+	if cr.Spec.Repo.IsRemote() {
+		log.Info("Custom Repo Endpoint. Skipping starting Repo Server.")
+		return nil
+	}
+
+	if !cr.Spec.Repo.IsEnabled() {
+		log.Info("ArgoCD Repo Server disabled. Skipping starting ArgoCD Repo Server.")
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(cr, ss, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Client.Create(context.TODO(), ss); err != nil {
+		return err
+	}
+	return r.deleteRepoDeployment(cr)
+}
+
+// replaceRepoStatefulSet applies the changes of the cache volumes of the repo server. The claim templates of a
+// StatefulSet are immutable, so the existing StatefulSet is removed without its pods, which are adopted by the
+// StatefulSet created on the next reconciliation.
+//
+// An increased size is applied by expanding the existing claims when their storage class allows it. A claim can
+// neither be shrunk nor moved to another storage class: when the storage class changes, the claims and the pods of
+// the repo server are removed, so the repo server is unavailable until its pods are recreated with new cache volumes.
+func (r *ReconcileArgoCD) replaceRepoStatefulSet(cr *argoproj.ArgoCD, existing, desired *appsv1.StatefulSet) error {
+	claims, err := r.getRepoCacheClaims(existing)
+	if err != nil {
+		return err
+	}
+
+	classChanged := repoCacheStorageClassChanged(existing.Spec.VolumeClaimTemplates, desired.Spec.VolumeClaimTemplates)
+	if !classChanged {
+		size := desired.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]
+		for i := range claims {
+			if err := r.expandRepoCacheClaim(&claims[i], size); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Info("Repo Server cache volume changed. Deleting Repo Server statefulset, keeping its pods, to recreate it.")
+	if err := r.Client.Delete(context.TODO(), existing, client.PropagationPolicy(metav1.DeletePropagationOrphan)); err != nil {
+		return err
+	}
+	if !classChanged {
+		return nil
+	}
+
+	log.Info("Repo Server cache storage class changed. Deleting Repo Server cache volumes and pods, the repo server is unavailable until they are recreated.")
+	for i := range claims {
+		if err := r.Client.Delete(context.TODO(), &claims[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return r.Client.DeleteAllOf(context.TODO(), &corev1.Pod{}, client.InNamespace(cr.Namespace),
+		client.MatchingLabels(existing.Spec.Selector.MatchLabels))
+}
+
+// getRepoCacheClaims returns the cache volume claims of the replicas of the given repo server StatefulSet.
+func (r *ReconcileArgoCD) getRepoCacheClaims(ss *appsv1.StatefulSet) ([]corev1.PersistentVolumeClaim, error) {
+	list := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(ss.Namespace)); err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("%s-%s-", repoCacheVolumeName, ss.Name)
+	claims := []corev1.PersistentVolumeClaim{}
+	for _, claim := range list.Items {
+		if strings.HasPrefix(claim.Name, prefix) {
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+// expandRepoCacheClaim increases the requested size of the given cache volume claim to size, if its storage class
+// allows volume expansion.
+func (r *ReconcileArgoCD) expandRepoCacheClaim(claim *corev1.PersistentVolumeClaim, size resource.Quantity) error {
+	current := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(current) == 0 {
+		return nil
+	}
+	if size.Cmp(current) < 0 {
+		log.Info(fmt.Sprintf("Repo Server cache volume %s can not be shrunk from %s to %s", claim.Name, current.String(), size.String()))
+		return nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName == "" {
+		log.Info(fmt.Sprintf("Repo Server cache volume %s has no storage class, it is not expanded", claim.Name))
+		return nil
+	}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: *claim.Spec.StorageClassName}, storageClass); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info(fmt.Sprintf("Storage class %s of Repo Server cache volume %s not found, it is not expanded", *claim.Spec.StorageClassName, claim.Name))
+			return nil
+		}
+		return err
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		log.Info(fmt.Sprintf("Storage class %s does not allow volume expansion, Repo Server cache volume %s is not expanded", storageClass.Name, claim.Name))
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("Expanding Repo Server cache volume %s from %s to %s", claim.Name, current.String(), size.String()))
+	claim.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	return r.Client.Update(context.TODO(), claim)
+}
+
+// deleteRepoDeployment removes the repo server Deployment, replaced by the StatefulSet when the cache is enabled.
+func (r *ReconcileArgoCD) deleteRepoDeployment(cr *argoproj.ArgoCD) error {
+	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+		return nil
+	}
+	log.Info("Repo Server cache enabled. Deleting Repo Server deployment.")
+	return r.Client.Delete(context.TODO(), deploy)
+}
+
+// deleteRepoStatefulSet removes the repo server StatefulSet, replaced by the Deployment when the cache is disabled.
+func (r *ReconcileArgoCD) deleteRepoStatefulSet(cr *argoproj.ArgoCD) error {
+	ss := newStatefulSetWithSuffix("repo-server", "repo-server", cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
+		return nil
+	}
+	log.Info("Repo Server cache disabled. Deleting Repo Server statefulset.")
+	return r.Client.Delete(context.TODO(), ss)
+}
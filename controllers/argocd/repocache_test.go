@@ -0,0 +1,186 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func TestGetRepoCacheEnv(t *testing.T) {
+	a := makeTestArgoCD()
+	assert.Empty(t, getRepoCacheEnv(a))
+
+	parallelismLimit, lsRemoteParallelismLimit := int32(5), int32(10)
+	a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{
+		ParallelismLimit:         &parallelismLimit,
+		LsRemoteParallelismLimit: &lsRemoteParallelismLimit,
+		Expiration:               &metav1.Duration{Duration: 48 * time.Hour},
+	}
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "ARGOCD_REPO_SERVER_PARALLELISM_LIMIT", Value: "5"},
+		{Name: "ARGOCD_GIT_LS_REMOTE_PARALLELISM_LIMIT", Value: "10"},
+		{Name: "ARGOCD_REPO_CACHE_EXPIRATION", Value: "48h0m0s"},
+	}, getRepoCacheEnv(a))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_cache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	storageClass := "fast"
+	size := resource.MustParse("50Gi")
+	parallelismLimit := int32(3)
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{
+			Enabled:          true,
+			Size:             &size,
+			StorageClassName: &storageClass,
+			EvictionPolicy:   argoproj.ArgoCDRepoCacheEvictionPolicyDelete,
+			ParallelismLimit: &parallelismLimit,
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, ss))
+	assert.Equal(t, int32(1), *ss.Spec.Replicas)
+	assert.Len(t, ss.Spec.VolumeClaimTemplates, 1)
+	claim := ss.Spec.VolumeClaimTemplates[0]
+	assert.Equal(t, "tmp", claim.Name)
+	assert.Equal(t, &storageClass, claim.Spec.StorageClassName)
+	assert.Equal(t, 0, size.Cmp(claim.Spec.Resources.Requests[corev1.ResourceStorage]))
+	assert.Equal(t, appsv1.DeletePersistentVolumeClaimRetentionPolicyType, ss.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
+	assert.Equal(t, appsv1.DeletePersistentVolumeClaimRetentionPolicyType, ss.Spec.PersistentVolumeClaimRetentionPolicy.WhenDeleted)
+
+	// /tmp is mounted from the claim template rather than an emptyDir volume
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: "tmp", MountPath: "/tmp"})
+	for _, v := range ss.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "tmp", v.Name)
+	}
+	assert.Contains(t, ss.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "ARGOCD_REPO_SERVER_PARALLELISM_LIMIT", Value: "3"})
+
+	deploy := &appsv1.Deployment{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}, deploy)
+	assert.True(t, errors.IsNotFound(err))
+
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_cacheVolumeChanged(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	storageClass, otherStorageClass := "fast", "slow"
+	allowExpansion := true
+	size := resource.MustParse("50Gi")
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{
+			Enabled:          true,
+			Size:             &size,
+			StorageClassName: &storageClass,
+		}
+	})
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "tmp-argocd-repo-server-0", Namespace: a.Namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "argocd-repo-server-0",
+		Namespace: a.Namespace,
+		Labels:    map[string]string{common.ArgoCDKeyName: "argocd-repo-server"},
+	}}
+
+	resObjs := []client.Object{a, claim, pod,
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: storageClass}, AllowVolumeExpansion: &allowExpansion}}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	// an increased size expands the existing claims and recreates the StatefulSet, keeping its pods
+	newSize := resource.MustParse("100Gi")
+	a.Spec.Repo.Cache.Size = &newSize
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, ss)))
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(claim), claim))
+	assert.Equal(t, 0, newSize.Cmp(claim.Spec.Resources.Requests[corev1.ResourceStorage]))
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(pod), pod))
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, 0, newSize.Cmp(ss.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[corev1.ResourceStorage]))
+
+	// the StatefulSet is not recreated once the claim templates are up to date
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+
+	// a changed storage class removes the claims and the pods
+	a.Spec.Repo.Cache.StorageClassName = &otherStorageClass
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, ss)))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), client.ObjectKeyFromObject(claim), claim)))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), client.ObjectKeyFromObject(pod), pod)))
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, &otherStorageClass, ss.Spec.VolumeClaimTemplates[0].Spec.StorageClassName)
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_toggleCache(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	key := types.NamespacedName{Name: "argocd-repo-server", Namespace: a.Namespace}
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, &appsv1.Deployment{}))
+
+	// enabling the cache replaces the Deployment with a StatefulSet
+	a.Spec.Repo.Cache = &argoproj.ArgoCDRepoCacheSpec{Enabled: true}
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, ss))
+	assert.Equal(t, appsv1.RetainPersistentVolumeClaimRetentionPolicyType, ss.Spec.PersistentVolumeClaimRetentionPolicy.WhenScaled)
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, &appsv1.Deployment{})))
+
+	// a custom /tmp volume mount takes precedence over the cache
+	a.Spec.Repo.VolumeMounts = []corev1.VolumeMount{{Name: "custom-tmp", MountPath: "/tmp"}}
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.True(t, errors.IsNotFound(r.Client.Get(context.TODO(), key, &appsv1.StatefulSet{})))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, &appsv1.Deployment{}))
+}
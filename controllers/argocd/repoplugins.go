@@ -0,0 +1,232 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// repoPluginUser is the user the argocd-cmp-server must run as to share the plugin sockets with the repo server.
+	repoPluginUser int64 = 999
+
+	// repoPluginConfigFile is the name of the plugin configuration file read by the argocd-cmp-server.
+	repoPluginConfigFile = "plugin.yaml"
+)
+
+// validateRepoPlugins returns an error if the repo server plugins of the given ArgoCD are invalid.
+func validateRepoPlugins(cr *argoproj.ArgoCD) error {
+	names := map[string]bool{"argocd-repo-server": true}
+	for _, c := range cr.Spec.Repo.SidecarContainers {
+		names[c.Name] = true
+	}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		if names[plugin.Name] {
+			return fmt.Errorf("repo server plugin %s conflicts with the name of another repo server container", plugin.Name)
+		}
+		names[plugin.Name] = true
+
+		if (plugin.Config == "") == (plugin.ConfigMap == nil) {
+			return fmt.Errorf("repo server plugin %s must set exactly one of config or configMap", plugin.Name)
+		}
+	}
+	return nil
+}
+
+// getRepoPluginConfigKey returns the key of the argocd-cmp-cm ConfigMap holding the configuration of the given plugin.
+func getRepoPluginConfigKey(plugin argoproj.ArgoCDRepoPluginSpec) string {
+	return fmt.Sprintf("%s.yaml", plugin.Name)
+}
+
+// getRepoPluginConfigVolume returns the volume holding the plugin.yaml file of the given plugin.
+func getRepoPluginConfigVolume(plugin argoproj.ArgoCDRepoPluginSpec) corev1.Volume {
+	source := &corev1.ConfigMapVolumeSource{
+		LocalObjectReference: corev1.LocalObjectReference{Name: common.ArgoCDCMPConfigMapName},
+		Items:                []corev1.KeyToPath{{Key: getRepoPluginConfigKey(plugin), Path: repoPluginConfigFile}},
+	}
+	if plugin.ConfigMap != nil {
+		source.LocalObjectReference = plugin.ConfigMap.LocalObjectReference
+		source.Items[0].Key = plugin.ConfigMap.Key
+		source.Optional = plugin.ConfigMap.Optional
+	}
+	return corev1.Volume{
+		Name:         fmt.Sprintf("cmp-%s-config", plugin.Name),
+		VolumeSource: corev1.VolumeSource{ConfigMap: source},
+	}
+}
+
+// getRepoPluginTmpVolume returns the volume mounted as /tmp in the sidecar of the given plugin.
+func getRepoPluginTmpVolume(plugin argoproj.ArgoCDRepoPluginSpec) corev1.Volume {
+	return corev1.Volume{
+		Name:         fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+}
+
+// getRepoPluginVolumes returns the volumes needed by the repo server plugins of the given ArgoCD.
+func getRepoPluginVolumes(cr *argoproj.ArgoCD) []corev1.Volume {
+	volumes := make([]corev1.Volume, 0)
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		volumes = append(volumes, getRepoPluginConfigVolume(plugin), getRepoPluginTmpVolume(plugin))
+	}
+	return volumes
+}
+
+// getRepoPluginContainers returns the sidecar containers running the repo server plugins of the given ArgoCD.
+func getRepoPluginContainers(cr *argoproj.ArgoCD) []corev1.Container {
+	containers := make([]corev1.Container, 0)
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		container := corev1.Container{
+			Name:            plugin.Name,
+			Image:           plugin.Image,
+			Command:         []string{"/var/run/argocd/argocd-cmp-server"},
+			ImagePullPolicy: corev1.PullAlways,
+			Env:             argoutil.EnvMerge(plugin.Env, proxyEnvVars(), false),
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: boolPtr(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsNonRoot: boolPtr(true),
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: "RuntimeDefault",
+				},
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "var-files",
+					MountPath: "/var/run/argocd",
+				},
+				{
+					Name:      "plugins",
+					MountPath: "/home/argocd/cmp-server/plugins",
+				},
+				{
+					Name:      getRepoPluginConfigVolume(plugin).Name,
+					MountPath: "/home/argocd/cmp-server/config",
+				},
+				{
+					Name:      getRepoPluginTmpVolume(plugin).Name,
+					MountPath: "/tmp",
+				},
+			},
+		}
+		if plugin.Resources != nil {
+			container.Resources = *plugin.Resources
+		}
+		// OpenShift assigns the same user to every container of the pod, elsewhere the sidecar must run with the
+		// user of the repo server image to be able to share the plugin sockets.
+		if !IsVersionAPIAvailable() {
+			container.SecurityContext.RunAsUser = int64Ptr(repoPluginUser)
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// reconcileRepoPluginsConfigMap will ensure that the argocd-cmp-cm ConfigMap holds the inline configuration of the
+// repo server plugins, and that it is removed when no plugin is configured inline. A ConfigMap that is not owned by
+// the ArgoCD is never updated or removed.
+func (r *ReconcileArgoCD) reconcileRepoPluginsConfigMap(cr *argoproj.ArgoCD) error {
+	if err := validateRepoPlugins(cr); err != nil {
+		return err
+	}
+
+	data := make(map[string]string)
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		if plugin.Config != "" {
+			data[getRepoPluginConfigKey(plugin)] = plugin.Config
+		}
+	}
+
+	cm := newConfigMapWithName(common.ArgoCDCMPConfigMapName, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		owned := metav1.IsControlledBy(cm, cr)
+		if err := r.reconcileRepoPluginsConfigMapCondition(cr, !owned && len(data) > 0); err != nil {
+			return err
+		}
+		if !owned {
+			log.Info(fmt.Sprintf("Skipping configmap %s as it is not owned by ArgoCD %s", cm.Name, cr.Name))
+			return nil
+		}
+		if len(data) == 0 {
+			log.Info(fmt.Sprintf("Deleting configmap %s as no repo server plugin is configured", cm.Name))
+			return r.Client.Delete(context.TODO(), cm)
+		}
+		if !reflect.DeepEqual(cm.Data, data) {
+			cm.Data = data
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil
+	}
+
+	if err := r.reconcileRepoPluginsConfigMapCondition(cr, false); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	cm.Data = data
+	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Creating configmap %s", cm.Name))
+	return r.Client.Create(context.TODO(), cm)
+}
+
+// reconcileRepoPluginsConfigMapCondition sets the RepoPluginsConfigMapConflicted condition of the given ArgoCD when
+// conflicted is true, and removes it otherwise.
+func (r *ReconcileArgoCD) reconcileRepoPluginsConfigMapCondition(cr *argoproj.ArgoCD, conflicted bool) error {
+	if !conflicted {
+		if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionRepoPluginsConfigMapConflicted) == nil {
+			return nil
+		}
+		return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+			meta.RemoveStatusCondition(&status.Conditions, argoproj.ArgoCDConditionRepoPluginsConfigMapConflicted)
+		})
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionRepoPluginsConfigMapConflicted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ConfigMapNotOwned",
+		Message:            fmt.Sprintf("ConfigMap %s is not owned by ArgoCD %s, the inline configuration of the repo server plugins is not applied", common.ArgoCDCMPConfigMapName, cr.Name),
+		ObservedGeneration: cr.Generation,
+	}
+	if existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	if err := argoutil.CreateEvent(r.Client, "Warning", "Skipped", condition.Message, condition.Reason, cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create event for unowned repo server plugins ConfigMap", "name", cr.Name, "namespace", cr.Namespace)
+	}
+	return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		meta.SetStatusCondition(&status.Conditions, condition)
+	})
+}
@@ -0,0 +1,241 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+const testRepoPluginConfig = `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: cdk8s
+spec:
+  generate:
+    command: [cdk8s, synth, --stdout]
+`
+
+func TestValidateRepoPlugins(t *testing.T) {
+	configMap := &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "my-plugin"},
+		Key:                  "plugin.yaml",
+	}
+	tests := []struct {
+		name    string
+		plugins []argoproj.ArgoCDRepoPluginSpec
+		sidecar string
+		wantErr bool
+	}{
+		{
+			name: "valid plugins",
+			plugins: []argoproj.ArgoCDRepoPluginSpec{
+				{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig},
+				{Name: "tanka", Image: "tanka:latest", ConfigMap: configMap},
+			},
+		},
+		{
+			name:    "missing configuration",
+			plugins: []argoproj.ArgoCDRepoPluginSpec{{Name: "cdk8s", Image: "cdk8s:latest"}},
+			wantErr: true,
+		},
+		{
+			name:    "inline and ConfigMap configuration",
+			plugins: []argoproj.ArgoCDRepoPluginSpec{{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig, ConfigMap: configMap}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate plugin",
+			plugins: []argoproj.ArgoCDRepoPluginSpec{
+				{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig},
+				{Name: "cdk8s", Image: "cdk8s:latest", ConfigMap: configMap},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "plugin named as a sidecar container",
+			plugins: []argoproj.ArgoCDRepoPluginSpec{{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig}},
+			sidecar: "cdk8s",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+				a.Spec.Repo.Plugins = test.plugins
+				if test.sidecar != "" {
+					a.Spec.Repo.SidecarContainers = []corev1.Container{{Name: test.sidecar}}
+				}
+			})
+			err := validateRepoPlugins(a)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileRepoPluginsConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPluginSpec{
+			{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig},
+			{Name: "tanka", Image: "tanka:latest", ConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-plugin"},
+				Key:                  "plugin.yaml",
+			}},
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCMPConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{"cdk8s.yaml": testRepoPluginConfig}, cm.Data)
+
+	// the ConfigMap is removed when no plugin is configured inline
+	a.Spec.Repo.Plugins = a.Spec.Repo.Plugins[1:]
+	assert.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCMPConfigMapName, Namespace: testNamespace}, cm)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileRepoPluginsConfigMap_notOwned(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPluginSpec{
+			{Name: "cdk8s", Image: "cdk8s:latest", Config: testRepoPluginConfig},
+		}
+	})
+	userCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: common.ArgoCDCMPConfigMapName, Namespace: testNamespace},
+		Data:       map[string]string{"user.yaml": "user"},
+	}
+
+	resObjs := []client.Object{a, userCM}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the user ConfigMap is not updated and the conflict is reported
+	assert.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCMPConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{"user.yaml": "user"}, cm.Data)
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoproj.ArgoCDConditionRepoPluginsConfigMapConflicted))
+
+	// the user ConfigMap is not removed when no plugin is configured inline
+	a.Spec.Repo.Plugins = nil
+	assert.NoError(t, r.reconcileRepoPluginsConfigMap(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDCMPConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, map[string]string{"user.yaml": "user"}, cm.Data)
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionRepoPluginsConfigMapConflicted))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_plugins(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Plugins = []argoproj.ArgoCDRepoPluginSpec{
+			{
+				Name:      "cdk8s",
+				Image:     "cdk8s:latest",
+				Config:    testRepoPluginConfig,
+				Resources: &resources,
+				Env:       []corev1.EnvVar{{Name: "FOO", Value: "BAR"}},
+			},
+			{Name: "tanka", Image: "tanka:latest", ConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-plugin"},
+				Key:                  "tanka.yaml",
+			}},
+		}
+		a.Spec.Repo.SidecarContainers = []corev1.Container{{Name: "sidecar", Image: "sidecar:latest"}}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	containers := deployment.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 4)
+	assert.Equal(t, "cdk8s", containers[1].Name)
+	assert.Equal(t, "tanka", containers[2].Name)
+	assert.Equal(t, "sidecar", containers[3].Name)
+
+	cdk8s := containers[1]
+	assert.Equal(t, "cdk8s:latest", cdk8s.Image)
+	assert.Equal(t, []string{"/var/run/argocd/argocd-cmp-server"}, cdk8s.Command)
+	assert.Equal(t, resources, cdk8s.Resources)
+	assert.Contains(t, cdk8s.Env, corev1.EnvVar{Name: "FOO", Value: "BAR"})
+	assert.Equal(t, int64Ptr(repoPluginUser), cdk8s.SecurityContext.RunAsUser)
+	assert.Equal(t, boolPtr(true), cdk8s.SecurityContext.RunAsNonRoot)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "var-files", MountPath: "/var/run/argocd"},
+		{Name: "plugins", MountPath: "/home/argocd/cmp-server/plugins"},
+		{Name: "cmp-cdk8s-config", MountPath: "/home/argocd/cmp-server/config"},
+		{Name: "cmp-cdk8s-tmp", MountPath: "/tmp"},
+	}, cdk8s.VolumeMounts)
+
+	volumes := deployment.Spec.Template.Spec.Volumes
+	assert.Contains(t, volumes, corev1.Volume{
+		Name: "cmp-cdk8s-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: common.ArgoCDCMPConfigMapName},
+			Items:                []corev1.KeyToPath{{Key: "cdk8s.yaml", Path: "plugin.yaml"}},
+		}},
+	})
+	assert.Contains(t, volumes, corev1.Volume{
+		Name: "cmp-tanka-config",
+		VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "my-plugin"},
+			Items:                []corev1.KeyToPath{{Key: "tanka.yaml", Path: "plugin.yaml"}},
+		}},
+	})
+	assert.Contains(t, volumes, corev1.Volume{
+		Name:         "cmp-tanka-tmp",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+
+	// removing a plugin removes its sidecar and volumes
+	a.Spec.Repo.Plugins = a.Spec.Repo.Plugins[:1]
+	assert.NoError(t, r.reconcileRepoDeployment(a, false))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.Containers, 3)
+	for _, v := range deployment.Spec.Template.Spec.Volumes {
+		assert.NotContains(t, v.Name, "tanka")
+	}
+}
@@ -128,7 +128,7 @@ func (r *ReconcileArgoCD) reconcileRole(name string, policyRules []v1.PolicyRule
 			}
 		}
 		customRole := getCustomRoleName(name)
-		role := newRole(name, policyRules, cr)
+		role := newRole(name, r.policyRulesForManagedNamespace(name, namespace.Name, policyRules), cr)
 		if err := applyReconcilerHook(cr, role, ""); err != nil {
 			return nil, err
 		}
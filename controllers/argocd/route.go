@@ -43,6 +43,14 @@ func IsRouteAPIAvailable() bool {
 	return routeAPIFound
 }
 
+// isRouteAPIAvailable returns true if the Route API is available to the reconciler.
+func (r *ReconcileArgoCD) isRouteAPIAvailable() bool {
+	if r.apis != nil {
+		return r.apis.route
+	}
+	return IsRouteAPIAvailable()
+}
+
 // verifyRouteAPI will verify that the Route API is present.
 func verifyRouteAPI() error {
 	found, err := argoutil.VerifyAPI(routev1.GroupName, routev1.GroupVersion.Version)
@@ -227,7 +235,7 @@ func (r *ReconcileArgoCD) reconcileServerRoute(cr *argoproj.ArgoCD) error {
 
 	route.Spec.Host = hostname
 
-	if cr.Spec.Server.Insecure {
+	if getArgoServerInsecure(cr) {
 		// Disable TLS and rely on the cluster certificate.
 		route.Spec.Port = &routev1.RoutePort{
 			TargetPort: intstr.FromString("http"),
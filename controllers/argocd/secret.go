@@ -35,6 +35,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -365,6 +367,127 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoproj.ArgoCD, secre
 	return nil
 }
 
+// reconcileAdminPasswordRotation will rotate the admin password of the given ArgoCD once the rotation
+// interval has elapsed since its last change.
+func (r *ReconcileArgoCD) reconcileAdminPasswordRotation(cr *argoproj.ArgoCD) error {
+	rotation := cr.Spec.AdminPasswordRotation
+	if !rotation.IsEnabled() {
+		return r.reconcileAdminPasswordRotationCondition(cr, nil)
+	}
+
+	clusterSecret := argoutil.NewSecretWithSuffix(cr, "cluster")
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, clusterSecret.Name, clusterSecret) ||
+		!argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		log.Info("admin password secrets not found, waiting to rotate the admin password")
+		return nil
+	}
+
+	if next := getAdminPasswordNextRotation(secret, rotation); time.Now().Before(next) {
+		return nil
+	}
+
+	// a policy no password can be generated with is reported, without failing the reconciliation of the
+	// resources depending on the secrets
+	pwBytes, err := generateRotatedArgoAdminPassword(rotation)
+	if err != nil {
+		return r.reconcileAdminPasswordRotationCondition(cr, err)
+	}
+	hashedPassword, err := argopass.HashPassword(string(pwBytes))
+	if err != nil {
+		return err
+	}
+
+	log.Info("rotating admin password")
+	if clusterSecret.Data == nil {
+		clusterSecret.Data = make(map[string][]byte)
+	}
+	clusterSecret.Data[common.ArgoCDKeyAdminPassword] = pwBytes
+	if err := r.Client.Update(context.TODO(), clusterSecret); err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	mtime := nowBytes()
+	secret.Data[common.ArgoCDKeyAdminPassword] = []byte(hashedPassword)
+	secret.Data[common.ArgoCDKeyAdminPasswordMTime] = mtime
+	if err := r.Client.Update(context.TODO(), secret); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("admin password rotated, the new password is stored in secret %s", clusterSecret.Name)
+	if err := argoutil.CreateEvent(r.Client, "Normal", "Rotated", message, "AdminPasswordRotated", cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create admin password rotation event")
+	}
+
+	rotatedAt, _ := time.Parse(time.RFC3339, string(mtime))
+	return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		status.AdminPasswordLastRotation = &metav1.Time{Time: rotatedAt}
+		meta.RemoveStatusCondition(&status.Conditions, argoproj.ArgoCDConditionAdminPasswordRotationFailed)
+	})
+}
+
+// reconcileAdminPasswordRotationCondition sets the AdminPasswordRotationFailed condition of the given ArgoCD when
+// the admin password could not be generated with the given error, and removes it otherwise.
+func (r *ReconcileArgoCD) reconcileAdminPasswordRotationCondition(cr *argoproj.ArgoCD, generateErr error) error {
+	if generateErr == nil {
+		if meta.FindStatusCondition(cr.Status.Conditions, argoproj.ArgoCDConditionAdminPasswordRotationFailed) == nil {
+			return nil
+		}
+		return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+			meta.RemoveStatusCondition(&status.Conditions, argoproj.ArgoCDConditionAdminPasswordRotationFailed)
+		})
+	}
+
+	condition := metav1.Condition{
+		Type:               argoproj.ArgoCDConditionAdminPasswordRotationFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "InvalidPasswordPolicy",
+		Message:            fmt.Sprintf("failed to generate admin password: %s", generateErr),
+		ObservedGeneration: cr.Generation,
+	}
+	if existing := meta.FindStatusCondition(cr.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Message == condition.Message && existing.ObservedGeneration == condition.ObservedGeneration {
+		return nil
+	}
+
+	if err := argoutil.CreateEvent(r.Client, "Warning", "RotationFailed", condition.Message, condition.Reason, cr.ObjectMeta, cr.TypeMeta); err != nil {
+		log.Error(err, "failed to create admin password rotation event")
+	}
+	return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		meta.SetStatusCondition(&status.Conditions, condition)
+	})
+}
+
+// getAdminPasswordNextRotation returns the time at which the admin password stored in the given
+// argocd-secret Secret is due for rotation. Passwords without a valid modification time are due immediately.
+func getAdminPasswordNextRotation(secret *corev1.Secret, rotation *argoproj.ArgoCDAdminPasswordRotationSpec) time.Time {
+	mtime, err := time.Parse(time.RFC3339, string(secret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	if err != nil {
+		return time.Time{}
+	}
+	return mtime.Add(rotation.Interval.Duration)
+}
+
+// getAdminPasswordRotation returns the delay after which the admin password of the given ArgoCD has
+// to be rotated, 0 if the password is not rotated.
+func (r *ReconcileArgoCD) getAdminPasswordRotation(cr *argoproj.ArgoCD) time.Duration {
+	if !cr.Spec.AdminPasswordRotation.IsEnabled() {
+		return 0
+	}
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		return 0
+	}
+	d := time.Until(getAdminPasswordNextRotation(secret, cr.Spec.AdminPasswordRotation))
+	if d < minScheduledRequeue {
+		d = minScheduledRequeue
+	}
+	return d
+}
+
 // reconcileGrafanaSecret will ensure that the Grafana Secret is present.
 func (r *ReconcileArgoCD) reconcileGrafanaSecret(cr *argoproj.ArgoCD) error {
 	//nolint:staticcheck
@@ -506,8 +629,7 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoproj.ArgoCD) erro
 		}
 
 		// Trigger rollout of repository server
-		repoDepl := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-		err = r.triggerRollout(repoDepl, "repo.tls.cert.changed")
+		err = r.triggerRollout(newRepoServerWorkload(cr), "repo.tls.cert.changed")
 		if err != nil {
 			return err
 		}
@@ -608,8 +730,7 @@ func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoproj.ArgoCD, useTLSFor
 		}
 
 		// Trigger rollout of repository server
-		repoDepl := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-		err = r.triggerRollout(repoDepl, "redis.tls.cert.changed")
+		err = r.triggerRollout(newRepoServerWorkload(cr), "redis.tls.cert.changed")
 		if err != nil {
 			return err
 		}
@@ -635,6 +756,14 @@ func (r *ReconcileArgoCD) reconcileSecrets(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileAdminPasswordRotation(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileLocalUsers(cr); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	argopass "github.com/argoproj/argo-cd/v2/util/password"
 
@@ -16,6 +17,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -327,6 +329,124 @@ func Test_ReconcileArgoCD_ReconcileShouldNotChangeWhenUpdatedAdminPass(t *testin
 	}
 }
 
+func Test_ReconcileArgoCD_ReconcileAdminPasswordRotation(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.AdminPasswordRotation = &argoproj.ArgoCDAdminPasswordRotationSpec{
+			Enabled:  true,
+			Interval: metav1.Duration{Duration: 24 * time.Hour},
+			Length:   20,
+			Digits:   4,
+			Symbols:  2,
+		}
+	})
+
+	hashedPassword, _ := argopass.HashPassword("something")
+	clusterSecret := argoutil.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("something")}
+	argoSecret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	argoSecret.Data = map[string][]byte{
+		common.ArgoCDKeyAdminPassword:      []byte(hashedPassword),
+		common.ArgoCDKeyAdminPasswordMTime: []byte(time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)),
+	}
+
+	resObjs := []client.Object{a, clusterSecret, argoSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the password is not rotated before the interval elapsed
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterSecret.Name, Namespace: testNamespace}, clusterSecret))
+	assert.Equal(t, "something", string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.Nil(t, a.Status.AdminPasswordLastRotation)
+	assert.InDelta(t, 23*time.Hour, r.getAdminPasswordRotation(a), float64(time.Minute))
+
+	// the password is rotated once the interval elapsed
+	argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime] = []byte(time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339))
+	assert.NoError(t, r.Client.Update(context.TODO(), argoSecret))
+	assert.Equal(t, minScheduledRequeue, r.getAdminPasswordRotation(a))
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterSecret.Name, Namespace: testNamespace}, clusterSecret))
+	password := string(clusterSecret.Data[common.ArgoCDKeyAdminPassword])
+	assert.Len(t, password, 20)
+	assert.NotEqual(t, "something", password)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: argoSecret.Name, Namespace: testNamespace}, argoSecret))
+	valid, _ := argopass.VerifyPassword(password, string(argoSecret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.True(t, valid)
+	mtime, err := time.Parse(time.RFC3339, string(argoSecret.Data[common.ArgoCDKeyAdminPasswordMTime]))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), mtime, time.Minute)
+
+	assert.NotNil(t, a.Status.AdminPasswordLastRotation)
+	assert.True(t, mtime.Equal(a.Status.AdminPasswordLastRotation.Time))
+
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(testNamespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "AdminPasswordRotated", events.Items[0].Reason)
+}
+
+func Test_ReconcileArgoCD_ReconcileAdminPasswordRotation_invalidPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.AdminPasswordRotation = &argoproj.ArgoCDAdminPasswordRotationSpec{
+			Enabled:  true,
+			Interval: metav1.Duration{Duration: 24 * time.Hour},
+			Length:   16,
+			Digits:   10,
+			Symbols:  10,
+		}
+	})
+
+	hashedPassword, _ := argopass.HashPassword("something")
+	clusterSecret := argoutil.NewSecretWithSuffix(a, "cluster")
+	clusterSecret.Data = map[string][]byte{common.ArgoCDKeyAdminPassword: []byte("something")}
+	argoSecret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	argoSecret.Data = map[string][]byte{
+		common.ArgoCDKeyAdminPassword:      []byte(hashedPassword),
+		common.ArgoCDKeyAdminPasswordMTime: []byte(time.Now().Add(-25 * time.Hour).UTC().Format(time.RFC3339)),
+	}
+
+	resObjs := []client.Object{a, clusterSecret, argoSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the failure is reported on the instance instead of failing the reconciliation
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: clusterSecret.Name, Namespace: testNamespace}, clusterSecret))
+	assert.Equal(t, "something", string(clusterSecret.Data[common.ArgoCDKeyAdminPassword]))
+	assert.Nil(t, a.Status.AdminPasswordLastRotation)
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionAdminPasswordRotationFailed)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "InvalidPasswordPolicy", condition.Reason)
+	}
+
+	// the event is only emitted once
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	events := &corev1.EventList{}
+	assert.NoError(t, r.Client.List(context.TODO(), events, client.InNamespace(testNamespace)))
+	assert.Len(t, events.Items, 1)
+	assert.Equal(t, "InvalidPasswordPolicy", events.Items[0].Reason)
+
+	// the condition is removed once the policy is fixed
+	a.Spec.AdminPasswordRotation.Digits = 4
+	a.Spec.AdminPasswordRotation.Symbols = 2
+	assert.NoError(t, r.Client.Update(context.TODO(), a))
+	assert.NoError(t, r.reconcileAdminPasswordRotation(a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, argoproj.ArgoCDConditionAdminPasswordRotationFailed))
+	assert.NotNil(t, a.Status.AdminPasswordLastRotation)
+}
+
 func Test_ReconcileArgoCD_ReconcileRedisTLSSecret(t *testing.T) {
 	argocd := &argoproj.ArgoCD{
 		ObjectMeta: metav1.ObjectMeta{
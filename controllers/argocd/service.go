@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +40,145 @@ func getArgoServerServiceType(cr *argoproj.ArgoCD) corev1.ServiceType {
 	return corev1.ServiceTypeClusterIP
 }
 
+// getArgoServerServiceSpec will return the options of the server Service for the ArgoCD.
+func getArgoServerServiceSpec(cr *argoproj.ArgoCD) argoproj.ArgoCDServiceSpec {
+	return argoproj.ArgoCDServiceSpec{
+		Type:                 getArgoServerServiceType(cr),
+		ArgoCDServiceOptions: cr.Spec.Server.Service.ArgoCDServiceOptions,
+	}
+}
+
+// ensureServiceSpec ensures that the given Service has the type and the customizations of the given Service options.
+//
+// Returns true when the Service has been changed, otherwise returns false.
+//
+// When this method returns true for an existing Service, the svc resource will need to be updated on the cluster.
+func ensureServiceSpec(svc *corev1.Service, spec argoproj.ArgoCDServiceSpec) bool {
+	changed := false
+
+	serviceType := spec.Type
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeClusterIP
+	}
+	if svc.Spec.Type != serviceType {
+		svc.Spec.Type = serviceType
+		changed = true
+	}
+
+	if ensureServiceMetadata(svc, &svc.ObjectMeta.Annotations, spec.Annotations, common.AnnotationServiceAnnotations) {
+		changed = true
+	}
+	if ensureServiceMetadata(svc, &svc.ObjectMeta.Labels, spec.Labels, common.AnnotationServiceLabels) {
+		changed = true
+	}
+
+	// Node ports, the external traffic policy and the source ranges are only allowed for the Service types that
+	// expose the Service outside of the cluster.
+	exposed := serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+	for i, port := range svc.Spec.Ports {
+		nodePort := int32(0)
+		if exposed {
+			nodePort = port.NodePort
+			if p, ok := spec.NodePorts[port.Name]; ok {
+				nodePort = p
+			}
+		}
+		if port.NodePort != nodePort {
+			svc.Spec.Ports[i].NodePort = nodePort
+			changed = true
+		}
+	}
+
+	var trafficPolicy corev1.ServiceExternalTrafficPolicy
+	if exposed {
+		trafficPolicy = corev1.ServiceExternalTrafficPolicyCluster
+		if spec.ExternalTrafficPolicy != "" {
+			trafficPolicy = spec.ExternalTrafficPolicy
+		}
+	}
+	if svc.Spec.ExternalTrafficPolicy != trafficPolicy {
+		svc.Spec.ExternalTrafficPolicy = trafficPolicy
+		changed = true
+	}
+
+	var sourceRanges []string
+	if serviceType == corev1.ServiceTypeLoadBalancer {
+		sourceRanges = spec.LoadBalancerSourceRanges
+	}
+	if (len(sourceRanges) > 0 || len(svc.Spec.LoadBalancerSourceRanges) > 0) &&
+		!reflect.DeepEqual(sourceRanges, svc.Spec.LoadBalancerSourceRanges) {
+		svc.Spec.LoadBalancerSourceRanges = sourceRanges
+		changed = true
+	}
+
+	// The IP family policy is defaulted by the API server, so it is only reconciled when set.
+	if spec.IPFamilyPolicy != nil && !reflect.DeepEqual(spec.IPFamilyPolicy, svc.Spec.IPFamilyPolicy) {
+		svc.Spec.IPFamilyPolicy = spec.IPFamilyPolicy
+		changed = true
+	}
+
+	affinity := corev1.ServiceAffinityNone
+	if spec.SessionAffinity != "" {
+		affinity = spec.SessionAffinity
+	}
+	if svc.Spec.SessionAffinity != affinity && (svc.Spec.SessionAffinity != "" || affinity != corev1.ServiceAffinityNone) {
+		svc.Spec.SessionAffinity = affinity
+		if affinity == corev1.ServiceAffinityNone {
+			svc.Spec.SessionAffinityConfig = nil
+		}
+		changed = true
+	}
+
+	return changed
+}
+
+// ensureServiceMetadata ensures that the given annotations or labels of the given Service hold the desired entries,
+// and removes the entries no longer desired. The keys of the desired entries are listed in the given annotation of
+// the Service, to remove them when they are no longer desired.
+//
+// Returns true when the Service has been changed, otherwise returns false.
+func ensureServiceMetadata(svc *corev1.Service, entries *map[string]string, desired map[string]string, keysAnnotation string) bool {
+	changed := false
+
+	previous := svc.ObjectMeta.Annotations[keysAnnotation]
+	for _, key := range strings.Split(previous, ",") {
+		if _, ok := desired[key]; ok || key == "" {
+			continue
+		}
+		if _, ok := (*entries)[key]; ok {
+			delete(*entries, key)
+			changed = true
+		}
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key, value := range desired {
+		keys = append(keys, key)
+		if *entries == nil {
+			*entries = make(map[string]string)
+		}
+		if current, ok := (*entries)[key]; !ok || current != value {
+			(*entries)[key] = value
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	if current := strings.Join(keys, ","); current != previous {
+		if current == "" {
+			delete(svc.ObjectMeta.Annotations, keysAnnotation)
+		} else {
+			if svc.ObjectMeta.Annotations == nil {
+				svc.ObjectMeta.Annotations = make(map[string]string)
+			}
+			svc.ObjectMeta.Annotations[keysAnnotation] = current
+		}
+		changed = true
+	}
+
+	return changed
+}
+
 // newService returns a new Service for the given ArgoCD instance.
 func newService(cr *argoproj.ArgoCD) *corev1.Service {
 	return &corev1.Service{
@@ -93,7 +234,9 @@ func (r *ReconcileArgoCD) reconcileGrafanaService(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("metrics", "metrics", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		// Service found, do nothing
+		if ensureServiceSpec(svc, cr.Spec.Controller.MetricsService) {
+			return r.Client.Update(context.TODO(), svc)
+		}
 		return nil
 	}
 
@@ -109,6 +252,7 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoproj.ArgoCD) error {
 			TargetPort: intstr.FromInt(8082),
 		},
 	}
+	ensureServiceSpec(svc, cr.Spec.Controller.MetricsService)
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -214,7 +358,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) erro
 			return r.Client.Delete(context.TODO(), svc)
 		}
 
-		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS(), r.isRouteAPIAvailable()) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		return nil // Service found, do nothing
@@ -224,7 +368,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyService(cr *argoproj.ArgoCD) erro
 		return nil //return as Ha is not enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS(), r.isRouteAPIAvailable())
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis-ha-haproxy", cr),
@@ -270,7 +414,7 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		if !cr.Spec.Redis.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS(), r.isRouteAPIAvailable()) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		if cr.Spec.HA.Enabled {
@@ -279,14 +423,17 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 		if cr.Spec.Redis.IsRemote() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil // Service found, do nothing
+		if ensureServiceSpec(svc, cr.Spec.Redis.Service) {
+			return r.Client.Update(context.TODO(), svc)
+		}
+		return nil
 	}
 
 	if cr.Spec.HA.Enabled || !cr.Spec.Redis.IsEnabled() {
 		return nil //return as Ha is enabled do nothing
 	}
 
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRedisServerTLSSecretName, cr.Spec.Redis.WantsAutoTLS(), r.isRouteAPIAvailable())
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
@@ -300,6 +447,7 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 			TargetPort: intstr.FromInt(common.ArgoCDDefaultRedisPort),
 		},
 	}
+	ensureServiceSpec(svc, cr.Spec.Redis.Service)
 
 	if cr.Spec.Redis.IsEnabled() && cr.Spec.Redis.IsRemote() {
 		log.Info("Skipping service creation, redis remote is enabled")
@@ -314,17 +462,18 @@ func (r *ReconcileArgoCD) reconcileRedisService(cr *argoproj.ArgoCD) error {
 
 // ensureAutoTLSAnnotation ensures that the service svc has the desired state
 // of the auto TLS annotation set, which is either set (when enabled is true)
-// or unset (when enabled is false).
+// or unset (when enabled is false). The annotation is only supported when the
+// Route API is available.
 //
 // Returns true when annotations have been updated, otherwise returns false.
 //
 // When this method returns true, the svc resource will need to be updated on
 // the cluster.
-func ensureAutoTLSAnnotation(k8sClient client.Client, svc *corev1.Service, secretName string, enabled bool) bool {
+func ensureAutoTLSAnnotation(k8sClient client.Client, svc *corev1.Service, secretName string, enabled, routeAPIAvailable bool) bool {
 	var autoTLSAnnotationName, autoTLSAnnotationValue string
 
 	// We currently only support OpenShift for automatic TLS
-	if IsRouteAPIAvailable() {
+	if routeAPIAvailable {
 		autoTLSAnnotationName = common.AnnotationOpenShiftServiceCA
 		if svc.Annotations == nil {
 			svc.Annotations = make(map[string]string)
@@ -366,21 +515,24 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoproj.ArgoCD) error {
 		if !cr.Spec.Repo.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS(), r.isRouteAPIAvailable()) {
 			return r.Client.Update(context.TODO(), svc)
 		}
 		if cr.Spec.Repo.IsRemote() {
 			log.Info("skip creating repo server service, repo remote is enabled")
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		return nil // Service found, do nothing
+		if ensureServiceSpec(svc, cr.Spec.Repo.Service) {
+			return r.Client.Update(context.TODO(), svc)
+		}
+		return nil
 	}
 
 	if !cr.Spec.Repo.IsEnabled() {
 		return nil
 	}
 
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDRepoServerTLSSecretName, cr.Spec.Repo.WantsAutoTLS(), r.isRouteAPIAvailable())
 
 	svc.Spec.Selector = map[string]string{
 		common.ArgoCDKeyName: nameWithSuffix("repo-server", cr),
@@ -399,6 +551,7 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoproj.ArgoCD) error {
 			TargetPort: intstr.FromInt(common.ArgoCDDefaultRepoMetricsPort),
 		},
 	}
+	ensureServiceSpec(svc, cr.Spec.Repo.Service)
 
 	if cr.Spec.Repo.IsEnabled() && cr.Spec.Repo.IsRemote() {
 		log.Info("skip creating repo server service, repo remote is enabled")
@@ -415,7 +568,10 @@ func (r *ReconcileArgoCD) reconcileRepoService(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("server-metrics", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-		return nil // Service found, do nothing
+		if ensureServiceSpec(svc, cr.Spec.Server.MetricsService) {
+			return r.Client.Update(context.TODO(), svc)
+		}
+		return nil
 	}
 
 	svc.Spec.Selector = map[string]string{
@@ -430,6 +586,73 @@ func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoproj.ArgoCD) err
 			TargetPort: intstr.FromInt(8083),
 		},
 	}
+	ensureServiceSpec(svc, cr.Spec.Server.MetricsService)
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// reconcileDexMetricsService will ensure that the Service for the Dex metrics is present when Dex is used.
+func (r *ReconcileArgoCD) reconcileDexMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("dex-server-metrics", "dex-server", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !UseDex(cr) {
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		return nil
+	}
+
+	if !UseDex(cr) {
+		return nil // Dex is disabled, do nothing
+	}
+
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("dex-server", cr),
+	}
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultDexMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultDexMetricsPort),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
+// reconcileRedisMetricsService will ensure that the Service for the Redis exporter is present when it is enabled.
+func (r *ReconcileArgoCD) reconcileRedisMetricsService(cr *argoproj.ArgoCD) error {
+	svc := newServiceWithSuffix("redis-metrics", "redis", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if !isRedisExporterEnabled(cr) {
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		return nil
+	}
+
+	if !isRedisExporterEnabled(cr) {
+		return nil // Redis exporter not enabled, do nothing.
+	}
+
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("redis", cr),
+	}
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       common.ArgoCDKeyMetrics,
+			Port:       common.ArgoCDDefaultRedisExporterPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultRedisExporterPort),
+		},
+	}
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -440,7 +663,7 @@ func (r *ReconcileArgoCD) reconcileServerMetricsService(cr *argoproj.ArgoCD) err
 // reconcileServerService will ensure that the Service is present for the Argo CD server component.
 func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 	svc := newServiceWithSuffix("server", "server", cr)
-	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS())
+	ensureAutoTLSAnnotation(r.Client, svc, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS(), r.isRouteAPIAvailable())
 
 	svc.Spec.Ports = []corev1.ServicePort{
 		{
@@ -460,7 +683,7 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 		common.ArgoCDKeyName: nameWithSuffix("server", cr),
 	}
 
-	svc.Spec.Type = getArgoServerServiceType(cr)
+	ensureServiceSpec(svc, getArgoServerServiceSpec(cr))
 
 	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
 		return err
@@ -472,11 +695,10 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoproj.ArgoCD) error {
 		if !cr.Spec.Server.IsEnabled() {
 			return r.Client.Delete(context.TODO(), svc)
 		}
-		if ensureAutoTLSAnnotation(r.Client, existingSVC, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS()) {
+		if ensureAutoTLSAnnotation(r.Client, existingSVC, common.ArgoCDServerTLSSecretName, cr.Spec.Server.WantsAutoTLS(), r.isRouteAPIAvailable()) {
 			changed = true
 		}
-		if !reflect.DeepEqual(svc.Spec.Type, existingSVC.Spec.Type) {
-			existingSVC.Spec.Type = svc.Spec.Type
+		if ensureServiceSpec(existingSVC, getArgoServerServiceSpec(cr)) {
 			changed = true
 		}
 		if changed {
@@ -498,6 +720,10 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		log.Error(err, "error reconciling dex service")
 	}
 
+	if err := r.reconcileDexMetricsService(cr); err != nil {
+		log.Error(err, "error reconciling dex metrics service")
+	}
+
 	err := r.reconcileGrafanaService(cr)
 	if err != nil {
 		return err
@@ -518,6 +744,11 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
+	err = r.reconcileRedisMetricsService(cr)
+	if err != nil {
+		return err
+	}
+
 	err = r.reconcileRepoService(cr)
 	if err != nil {
 		return err
@@ -26,46 +26,42 @@ func TestEnsureAutoTLSAnnotation(t *testing.T) {
 	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
 	fakeClient := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
 	t.Run("Ensure annotation will be set for OpenShift", func(t *testing.T) {
-		routeAPIFound = true
 		svc := newService(a)
 
 		// Annotation is inserted, update is required
-		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true)
+		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true, true)
 		assert.Equal(t, needUpdate, true)
 		atls, ok := svc.Annotations[common.AnnotationOpenShiftServiceCA]
 		assert.Equal(t, ok, true)
 		assert.Equal(t, atls, "some-secret")
 
 		// Annotation already set, doesn't need update
-		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true)
+		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true, true)
 		assert.Equal(t, needUpdate, false)
 	})
 	t.Run("Ensure annotation will be unset for OpenShift", func(t *testing.T) {
-		routeAPIFound = true
 		svc := newService(a)
 		svc.Annotations = make(map[string]string)
 		svc.Annotations[common.AnnotationOpenShiftServiceCA] = "some-secret"
 
 		// Annotation getting removed, update required
-		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false)
+		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false, true)
 		assert.Equal(t, needUpdate, true)
 		_, ok := svc.Annotations[common.AnnotationOpenShiftServiceCA]
 		assert.Equal(t, ok, false)
 
 		// Annotation does not exist, no update required
-		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false)
+		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false, true)
 		assert.Equal(t, needUpdate, false)
 	})
 	t.Run("Ensure annotation will not be set for non-OpenShift", func(t *testing.T) {
-		routeAPIFound = false
 		svc := newService(a)
-		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true)
+		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", true, false)
 		assert.Equal(t, needUpdate, false)
 		_, ok := svc.Annotations[common.AnnotationOpenShiftServiceCA]
 		assert.Equal(t, ok, false)
 	})
 	t.Run("Ensure annotation will not be set if the TLS secret is already present", func(t *testing.T) {
-		routeAPIFound = true
 		svc := newService(a)
 		secret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -75,13 +71,13 @@ func TestEnsureAutoTLSAnnotation(t *testing.T) {
 		}
 		err := fakeClient.Create(context.Background(), secret)
 		assert.NoError(t, err)
-		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, secret.Name, true)
+		needUpdate := ensureAutoTLSAnnotation(fakeClient, svc, secret.Name, true, true)
 		assert.Equal(t, needUpdate, false)
 		_, ok := svc.Annotations[common.AnnotationOpenShiftServiceCA]
 		assert.Equal(t, ok, false)
 
 		// Annotation does not exist, no update required
-		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false)
+		needUpdate = ensureAutoTLSAnnotation(fakeClient, svc, "some-secret", false, true)
 		assert.Equal(t, needUpdate, false)
 	})
 }
@@ -176,3 +172,93 @@ func TestReconcileArgoCD_reconcileRepoServerWithRemoteEnabled(t *testing.T) {
 	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: cr.Name + "-repo-server", Namespace: cr.Namespace}, s),
 		"services \"argocd-repo-server\" not found")
 }
+
+func TestEnsureServiceSpec(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd-server", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{{Name: "http", Port: 80}, {Name: "https", Port: 443}},
+		},
+	}
+	assert.False(t, ensureServiceSpec(svc, argoproj.ArgoCDServiceSpec{}))
+
+	singleStack := corev1.IPFamilyPolicySingleStack
+	spec := argoproj.ArgoCDServiceSpec{
+		Type: corev1.ServiceTypeLoadBalancer,
+		ArgoCDServiceOptions: argoproj.ArgoCDServiceOptions{
+			Annotations:              map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+			Labels:                   map[string]string{"exposure": "internal"},
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+			IPFamilyPolicy:           &singleStack,
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			NodePorts:                map[string]int32{"https": 30443},
+			SessionAffinity:          corev1.ServiceAffinityClientIP,
+		},
+	}
+	assert.True(t, ensureServiceSpec(svc, spec))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, svc.Spec.Type)
+	assert.Equal(t, "true", svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+	assert.Equal(t, "internal", svc.Labels["exposure"])
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyLocal, svc.Spec.ExternalTrafficPolicy)
+	assert.Equal(t, &singleStack, svc.Spec.IPFamilyPolicy)
+	assert.Equal(t, []string{"10.0.0.0/8"}, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, int32(0), svc.Spec.Ports[0].NodePort)
+	assert.Equal(t, int32(30443), svc.Spec.Ports[1].NodePort)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+
+	// an up-to-date Service is not changed
+	assert.False(t, ensureServiceSpec(svc, spec))
+
+	// a node port allocated by the API server is kept
+	svc.Spec.Ports[0].NodePort = 31080
+	assert.False(t, ensureServiceSpec(svc, spec))
+
+	// removed options are removed from the Service
+	assert.True(t, ensureServiceSpec(svc, argoproj.ArgoCDServiceSpec{}))
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	assert.NotContains(t, svc.Annotations, "service.beta.kubernetes.io/aws-load-balancer-internal")
+	assert.NotContains(t, svc.Annotations, common.AnnotationServiceAnnotations)
+	assert.NotContains(t, svc.Labels, "exposure")
+	assert.Empty(t, svc.Spec.ExternalTrafficPolicy)
+	assert.Empty(t, svc.Spec.LoadBalancerSourceRanges)
+	assert.Equal(t, int32(0), svc.Spec.Ports[0].NodePort)
+	assert.Equal(t, int32(0), svc.Spec.Ports[1].NodePort)
+	assert.Equal(t, corev1.ServiceAffinityNone, svc.Spec.SessionAffinity)
+}
+
+func TestReconcileArgoCD_reconcileRepoService_drift(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Repo.Service.Annotations = map[string]string{"example.com/team": "platform"}
+	})
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileRepoService(a))
+
+	svc := &corev1.Service{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, svc))
+	assert.Equal(t, "platform", svc.Annotations["example.com/team"])
+
+	// a manual change to the Service is reverted
+	svc.Spec.Type = corev1.ServiceTypeNodePort
+	delete(svc.Annotations, "example.com/team")
+	assert.NoError(t, r.Client.Update(context.TODO(), svc))
+
+	assert.NoError(t, r.reconcileRepoService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, svc))
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	assert.Equal(t, "platform", svc.Annotations["example.com/team"])
+
+	// the options are applied on update
+	a.Spec.Repo.Service.Annotations = nil
+	a.Spec.Repo.Service.SessionAffinity = corev1.ServiceAffinityClientIP
+	assert.NoError(t, r.reconcileRepoService(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, svc))
+	assert.NotContains(t, svc.Annotations, "example.com/team")
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+}
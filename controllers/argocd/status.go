@@ -304,8 +304,16 @@ func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoproj.ArgoCD) error {
 func (r *ReconcileArgoCD) reconcileStatusRepo(cr *argoproj.ArgoCD) error {
 	status := "Unknown"
 
-	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+	if useRepoCache(cr) {
+		ss := newStatefulSetWithSuffix("repo-server", "repo-server", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
+			status = "Pending"
+
+			if ss.Spec.Replicas != nil && ss.Status.ReadyReplicas == *ss.Spec.Replicas {
+				status = "Running"
+			}
+		}
+	} else if deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr); argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 		status = "Pending"
 
 		if deploy.Spec.Replicas != nil {
@@ -399,7 +407,7 @@ func (r *ReconcileArgoCD) reconcileStatusNotifications(cr *argoproj.ArgoCD) erro
 func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 	cr.Status.Host = ""
 
-	if (cr.Spec.Server.Route.Enabled || cr.Spec.Server.Ingress.Enabled) && IsRouteAPIAvailable() {
+	if (cr.Spec.Server.Route.Enabled || cr.Spec.Server.Ingress.Enabled) && r.isRouteAPIAvailable() {
 		route := newRouteWithSuffix("server", cr)
 
 		// The Red Hat OpenShift ingress controller implementation is designed to watch ingress objects and create one or more routes
@@ -472,6 +480,47 @@ func (r *ReconcileArgoCD) reconcileStatusHost(cr *argoproj.ArgoCD) error {
 				cr.Status.Host = hosts
 			}
 		}
+	} else if cr.Spec.Server.Gateway.Enabled && r.isGatewayAPIAvailable() {
+		route := newHTTPRouteWithSuffix("server", cr)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) {
+			log.Info("argocd-server httproute requested but not found on cluster")
+			cr.Status.Phase = "Pending"
+			return nil
+		}
+		if isHTTPRouteAccepted(route) {
+			cr.Status.Host = cr.Spec.Server.Host
+		} else {
+			cr.Status.Phase = "Pending"
+		}
 	}
 	return r.Client.Status().Update(context.TODO(), cr)
 }
+
+// reconcileStatusExtraConfigConflicts will ensure that the ExtraConfig conflicts are updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusExtraConfigConflicts(cr *argoproj.ArgoCD, conflicts []argoproj.ExtraConfigConflict) error {
+	if reflect.DeepEqual(cr.Status.ExtraConfigConflicts, conflicts) {
+		return nil
+	}
+	return r.updateStatus(cr, func(status *argoproj.ArgoCDStatus) {
+		status.ExtraConfigConflicts = conflicts
+	})
+}
+
+// updateStatus applies the given change to the status of the given ArgoCD and updates it. The update is
+// made on a copy, the ArgoCD read back from the API server must not replace the spec the remaining
+// resources are reconciled from.
+func (r *ReconcileArgoCD) updateStatus(cr *argoproj.ArgoCD, change func(*argoproj.ArgoCDStatus)) error {
+	return r.updateStatusWithContext(context.TODO(), cr, change)
+}
+
+// updateStatusWithContext is updateStatus making the update with the given context.
+func (r *ReconcileArgoCD) updateStatusWithContext(ctx context.Context, cr *argoproj.ArgoCD, change func(*argoproj.ArgoCDStatus)) error {
+	updated := cr.DeepCopy()
+	change(&updated.Status)
+	if err := r.Client.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	change(&cr.Status)
+	cr.ResourceVersion = updated.ResourceVersion
+	return nil
+}
@@ -0,0 +1,99 @@
+package argocd
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stepClient is the client of the reconciler. It sends the Kubernetes API calls through the client of the
+// reconciliation step in progress, which traces them or invokes the hooks, and through its base client otherwise.
+// The steps change the client of the reconciler instead of running on a copy of it, so that the state recorded by
+// a step, such as the managed namespaces, is seen by the following steps and by the watches.
+type stepClient struct {
+	client.Client
+	mu      sync.RWMutex
+	current client.Client
+}
+
+// get returns the client of the step in progress, or the base client.
+func (c *stepClient) get() client.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.current != nil {
+		return c.current
+	}
+	return c.Client
+}
+
+// push sends the calls through the client returned by wrap, given the client of the step in progress, until the
+// returned function is called.
+func (c *stepClient) push(wrap func(c client.Client) client.Client) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	previous := c.current
+	if previous != nil {
+		c.current = wrap(previous)
+	} else {
+		c.current = wrap(c.Client)
+	}
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.current = previous
+	}
+}
+
+func (c *stepClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.get().Get(ctx, key, obj, opts...)
+}
+
+func (c *stepClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.get().List(ctx, list, opts...)
+}
+
+func (c *stepClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.get().Create(ctx, obj, opts...)
+}
+
+func (c *stepClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.get().Delete(ctx, obj, opts...)
+}
+
+func (c *stepClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.get().Update(ctx, obj, opts...)
+}
+
+func (c *stepClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.get().Patch(ctx, obj, patch, opts...)
+}
+
+func (c *stepClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.get().DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *stepClient) Status() client.SubResourceWriter {
+	return c.get().Status()
+}
+
+func (c *stepClient) SubResource(subResource string) client.SubResourceClient {
+	return c.get().SubResource(subResource)
+}
+
+// stepClient returns the client of the reconciler, installing it over the client the reconciler was created with
+// on first use. It is installed by SetupWithManager, before the watches read the client concurrently.
+func (r *ReconcileArgoCD) stepClient() *stepClient {
+	if c, ok := r.Client.(*stepClient); ok {
+		return c
+	}
+	c := &stepClient{Client: r.Client}
+	r.Client = c
+	return c
+}
+
+// pushClient sends the Kubernetes API calls of the reconciler through the client returned by wrap, given the client
+// of the step in progress, until the returned function is called.
+func (r *ReconcileArgoCD) pushClient(wrap func(c client.Client) client.Client) func() {
+	return r.stepClient().push(wrap)
+}
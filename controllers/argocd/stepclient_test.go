@@ -0,0 +1,75 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestReconcileStep_SharesReconciler(t *testing.T) {
+	a := makeTestArgoCD()
+	defer deleteInstanceMetrics(a)
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	// the state recorded by a step is seen by the following steps
+	assert.NoError(t, r.reconcileStep(context.TODO(), a, "first", func() error {
+		r.ManagedSourceNamespaces = map[string]string{"source": ""}
+		return nil
+	}))
+	assert.NoError(t, r.reconcileStep(context.TODO(), a, "second", func() error {
+		assert.Contains(t, r.ManagedSourceNamespaces, "source")
+		return nil
+	}))
+	assert.Contains(t, r.ManagedSourceNamespaces, "source")
+
+	// the client of a step is only used during the step
+	var hooked client.Client
+	assert.NoError(t, r.reconcileStep(context.TODO(), a, "hooks", func() error {
+		restore := r.pushClient(func(c client.Client) client.Client { return newHookClient(c, sch, a) })
+		defer restore()
+		hooked = r.stepClient().get()
+		return nil
+	}))
+	assert.IsType(t, &hookClient{}, hooked)
+	assert.Same(t, cl, r.stepClient().get())
+}
+
+func TestReconcileArgoCD_Reconcile_recordsManagedNamespaces(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+	assert.NoError(t, createNamespace(r, a.Namespace, ""))
+	assert.NoError(t, createNamespace(r, "managed", a.Namespace))
+
+	// the managed namespaces recorded by the reconciliation are seen by the watches of the reconciler
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}}
+	_, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	if assert.NotNil(t, r.ManagedNamespaces) {
+		names := []string{}
+		for _, ns := range r.ManagedNamespaces.Items {
+			names = append(names, ns.Name)
+		}
+		assert.Contains(t, names, "managed")
+	}
+}
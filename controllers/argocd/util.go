@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
@@ -37,6 +38,7 @@ import (
 	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+	"github.com/argoproj-labs/argocd-operator/controllers/tracing"
 
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
 	oappsv1 "github.com/openshift/api/apps/v1"
@@ -63,16 +65,33 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 const (
-	grafanaDeprecatedWarning = "Warning: grafana field is deprecated from ArgoCD: field will be ignored."
+	grafanaDeprecatedWarning = "Warning: grafana field is deprecated from ArgoCD: field will be ignored. Use monitoring.dashboards to publish the Argo CD dashboards to an existing Grafana."
+
+	// minScheduledRequeue is the minimum delay before requeuing an ArgoCD for a scheduled task,
+	// such as renewing an API token or rotating the admin password.
+	minScheduledRequeue = 10 * time.Second
 )
 
 var (
 	versionAPIFound = false
 )
 
+// earliestRequeue returns the shortest of the given requeue delays, ignoring the zero delays.
+func earliestRequeue(delays ...time.Duration) time.Duration {
+	var earliest time.Duration
+	for _, d := range delays {
+		if d > 0 && (earliest == 0 || d < earliest) {
+			earliest = d
+		}
+	}
+	return earliest
+}
+
 // IsVersionAPIAvailable returns true if the version api is present
 func IsVersionAPIAvailable() bool {
 	return versionAPIFound
@@ -99,6 +118,19 @@ func generateArgoAdminPassword() ([]byte, error) {
 	return []byte(pass), err
 }
 
+// generateRotatedArgoAdminPassword will generate and return an admin password for Argo CD following the given rotation policy.
+func generateRotatedArgoAdminPassword(rotation *argoproj.ArgoCDAdminPasswordRotationSpec) ([]byte, error) {
+	length := rotation.Length
+	if length == 0 {
+		length = common.ArgoCDDefaultAdminPasswordLength
+	}
+	// Repeated characters are allowed as the letters, digits and symbols available without repeats
+	// are too few for some of the policies accepted by the CRD, such as 64 characters without uppercase.
+	pass, err := password.Generate(length, rotation.Digits, rotation.Symbols, rotation.NoUpper, true)
+
+	return []byte(pass), err
+}
+
 // generateRedisAdminPassword will generate and return the admin password for Redis.
 func generateRedisAdminPassword() ([]byte, error) {
 	pass, err := password.Generate(
@@ -256,9 +288,10 @@ func getArgoRepoResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
-// getArgoServerInsecure returns the insecure value for the ArgoCD Server component.
+// getArgoServerInsecure returns the insecure value for the ArgoCD Server component. The server runs insecure when
+// it is exposed through a Gateway, as the routes send plaintext to its HTTP port once the Gateway terminated TLS.
 func getArgoServerInsecure(cr *argoproj.ArgoCD) bool {
-	return cr.Spec.Server.Insecure
+	return cr.Spec.Server.Insecure || cr.Spec.Server.Gateway.Enabled || cr.Spec.Server.GRPC.Gateway.Enabled
 }
 
 func isRepoServerTLSVerificationRequested(cr *argoproj.ArgoCD) bool {
@@ -287,6 +320,42 @@ func getArgoServerHost(cr *argoproj.ArgoCD) string {
 	return host
 }
 
+// getArgoServerHosts will return all the hosts the Argo CD server is exposed under for the given ArgoCD.
+func getArgoServerHosts(cr *argoproj.ArgoCD) []string {
+	hosts := []string{getArgoServerHost(cr)}
+	for _, host := range cr.Spec.Server.AdditionalHosts {
+		if host != "" && !contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// validateArgoServerHosts returns an error if the canonical host is not one of the hosts of the Argo CD server.
+func validateArgoServerHosts(cr *argoproj.ArgoCD) error {
+	if cr.Spec.Server.CanonicalHost == "" {
+		return nil
+	}
+	if !contains(getArgoServerHosts(cr), cr.Spec.Server.CanonicalHost) {
+		return fmt.Errorf("canonical host %s must be the host or one of the additional hosts of the Argo CD server", cr.Spec.Server.CanonicalHost)
+	}
+	return nil
+}
+
+// getArgoServerRootPath will return the path prefix the Argo CD server is exposed under by its Ingress or HTTPRoute,
+// or an empty string when it is exposed at the root of its hosts.
+func getArgoServerRootPath(cr *argoproj.ArgoCD) string {
+	path := ""
+	if cr.Spec.Server.Ingress.Enabled {
+		path = cr.Spec.Server.Ingress.Path
+	} else if cr.Spec.Server.Gateway.Enabled {
+		path = cr.Spec.Server.Gateway.Path
+	}
+	// Some ingress controllers, like the AWS Load Balancer Controller, match prefixes with a wildcard
+	path = strings.TrimSuffix(path, "*")
+	return strings.TrimRight(path, "/")
+}
+
 // getKeycloakIngressHost will return the host for the given ArgoCD.
 func getKeycloakIngressHost(cr *argoproj.ArgoCDKeycloakSpec) string {
 	if cr != nil && len(cr.Host) > 0 {
@@ -330,7 +399,8 @@ func getArgoServerResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 }
 
 // getArgoServerURI will return the URI for the ArgoCD server.
-// The hostname for argocd-server is from the route, ingress, an external hostname or service name in that order.
+// The hostname for argocd-server is the canonical hostname, or is from the route, ingress, an external hostname or
+// service name in that order. The URI includes the root path argocd-server is exposed under.
 func (r *ReconcileArgoCD) getArgoServerURI(cr *argoproj.ArgoCD) string {
 	host := nameWithSuffix("server", cr) // Default to service name
 
@@ -355,7 +425,47 @@ func (r *ReconcileArgoCD) getArgoServerURI(cr *argoproj.ArgoCD) string {
 		}
 	}
 
-	return fmt.Sprintf("https://%s", host) // TODO: Safe to assume HTTPS here?
+	return getArgoServerExternalURL(cr, host)
+}
+
+// getArgoServerExternalURL will return the URL of the ArgoCD server exposed under the given hostname, or under the
+// canonical hostname when the server is exposed under several hostnames.
+func getArgoServerExternalURL(cr *argoproj.ArgoCD, host string) string {
+	if cr.Spec.Server.CanonicalHost != "" {
+		host = cr.Spec.Server.CanonicalHost
+	}
+	return getArgoServerHostURL(cr, host)
+}
+
+// getArgoServerHostURL will return the URL of the ArgoCD server exposed under the given hostname.
+func getArgoServerHostURL(cr *argoproj.ArgoCD, host string) string {
+	return fmt.Sprintf("https://%s%s", host, getArgoServerRootPath(cr)) // TODO: Safe to assume HTTPS here?
+}
+
+// getArgoServerAdditionalURLs will return the URLs, besides the URI of the ArgoCD server, the server is exposed under
+// by its route, ingress or external hostnames.
+func (r *ReconcileArgoCD) getArgoServerAdditionalURLs(cr *argoproj.ArgoCD) []string {
+	hosts := make([]string, 0)
+	if cr.Spec.Server.Route.Enabled {
+		route := newRouteWithSuffix("server", cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route) {
+			hosts = append(hosts, route.Spec.Host)
+		}
+	}
+	hosts = append(hosts, cr.Spec.Server.Host)
+	hosts = append(hosts, cr.Spec.Server.AdditionalHosts...)
+
+	uri := r.getArgoServerURI(cr)
+	urls := make([]string, 0)
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		if url := getArgoServerHostURL(cr, host); url != uri && !contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
+	return urls
 }
 
 // getArgoServerOperationProcessors will return the numeric Operation Processors value for the ArgoCD Server.
@@ -428,6 +538,20 @@ func getRedisContainerImage(cr *argoproj.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getRedisExporterContainerImage will return the container image for the Redis exporter.
+func getRedisExporterContainerImage(cr *argoproj.ArgoCD) string {
+	exporter := cr.Spec.Monitoring.ServiceMonitor.RedisExporter
+	img := exporter.Image
+	if img == "" {
+		img = common.ArgoCDDefaultRedisExporterImage
+	}
+	tag := exporter.Version
+	if tag == "" {
+		tag = common.ArgoCDDefaultRedisExporterVersion
+	}
+	return argoutil.CombineImageTag(img, tag)
+}
+
 // getRedisHAContainerImage will return the container image for the Redis server in HA mode.
 func getRedisHAContainerImage(cr *argoproj.ArgoCD) string {
 	defaultImg, defaultTag := false, false
@@ -536,6 +660,18 @@ func getRedisResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	return resources
 }
 
+// getRedisExporterResources will return the ResourceRequirements for the Redis exporter.
+func getRedisExporterResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	// Allow override of resource requirements from CR
+	if cr.Spec.Monitoring.ServiceMonitor.RedisExporter.Resources != nil {
+		resources = *cr.Spec.Monitoring.ServiceMonitor.RedisExporter.Resources
+	}
+
+	return resources
+}
+
 // getRedisHAResources will return the ResourceRequirements for the Redis HA.
 func getRedisHAResources(cr *argoproj.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
@@ -660,6 +796,14 @@ func InspectCluster() error {
 		return err
 	}
 
+	if err := verifyGatewayAPI(); err != nil {
+		return err
+	}
+
+	if err := verifyGrafanaDashboardAPI(); err != nil {
+		return err
+	}
+
 	if err := verifyKeycloakTemplateAPIs(); err != nil {
 		return err
 	}
@@ -733,136 +877,182 @@ func (r *ReconcileArgoCD) redisShouldUseTLS(cr *argoproj.ArgoCD) bool {
 	return false
 }
 
+// reconcileStep runs the given sub-reconciler of the given instance in its own span, counting the errors it returns.
+// The Kubernetes API calls of the sub-reconciler are traced under that span.
+func (r *ReconcileArgoCD) reconcileStep(ctx context.Context, cr *argoproj.ArgoCD, reconciler string, fn func() error) error {
+	ctx, span := tracing.Start(tracing.WithInstance(ctx, cr.Namespace, cr.Name), "reconcile "+reconciler)
+	restore := r.pushClient(func(c client.Client) client.Client { return tracing.WithContext(ctx, c) })
+	err := fn()
+	restore()
+	tracing.End(span, err)
+	if err != nil {
+		ReconcileErrors.WithLabelValues(cr.Namespace, cr.Name, reconciler).Inc()
+	}
+	return err
+}
+
 // reconcileResources will reconcile common ArgoCD resources.
-func (r *ReconcileArgoCD) reconcileResources(cr *argoproj.ArgoCD) error {
+func (r *ReconcileArgoCD) reconcileResources(ctx context.Context, cr *argoproj.ArgoCD) error {
+	// The resources are reconciled using the hook client, which invokes the resource hooks with every resource
+	// created, updated or patched by the reconcilers.
+	defer r.pushClient(func(c client.Client) client.Client { return newHookClient(c, r.Scheme, cr) })()
 
 	// we reconcile SSO first so that we can catch and throw errors for any illegal SSO configurations right away, and return control from here
 	// preventing dex resources from getting created anyway through the other function calls, effectively bypassing the SSO checks
 	log.Info("reconciling SSO")
-	if err := r.reconcileSSO(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "sso", func() error { return r.reconcileSSO(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
 	log.Info("reconciling status")
-	if err := r.reconcileStatus(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "status", func() error { return r.reconcileStatus(cr) }); err != nil {
 		log.Info(err.Error())
 	}
 
+	log.Info("reconciling managed namespaces")
+	if err := r.reconcileStep(ctx, cr, "managednamespaces", func() error { return r.reconcileManagedNamespaces(cr) }); err != nil {
+		return err
+	}
+
 	log.Info("reconciling roles")
-	if err := r.reconcileRoles(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "roles", func() error { return r.reconcileRoles(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling rolebindings")
-	if err := r.reconcileRoleBindings(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "rolebindings", func() error { return r.reconcileRoleBindings(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling service accounts")
-	if err := r.reconcileServiceAccounts(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "serviceaccounts", func() error { return r.reconcileServiceAccounts(cr) }); err != nil {
 		log.Info(err.Error())
 		return err
 	}
 
 	log.Info("reconciling certificate authority")
-	if err := r.reconcileCertificateAuthority(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "certificateauthority", func() error { return r.reconcileCertificateAuthority(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling secrets")
-	if err := r.reconcileSecrets(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "secrets", func() error { return r.reconcileSecrets(cr) }); err != nil {
 		return err
 	}
 
 	useTLSForRedis := r.redisShouldUseTLS(cr)
 
 	log.Info("reconciling config maps")
-	if err := r.reconcileConfigMaps(cr, useTLSForRedis); err != nil {
+	if err := r.reconcileStep(ctx, cr, "configmaps", func() error { return r.reconcileConfigMaps(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling services")
-	if err := r.reconcileServices(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "services", func() error { return r.reconcileServices(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling deployments")
-	if err := r.reconcileDeployments(cr, useTLSForRedis); err != nil {
+	if err := r.reconcileStep(ctx, cr, "deployments", func() error { return r.reconcileDeployments(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling statefulsets")
-	if err := r.reconcileStatefulSets(cr, useTLSForRedis); err != nil {
+	if err := r.reconcileStep(ctx, cr, "statefulsets", func() error { return r.reconcileStatefulSets(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling autoscalers")
-	if err := r.reconcileAutoscalers(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "autoscalers", func() error { return r.reconcileAutoscalers(cr) }); err != nil {
 		return err
 	}
 
 	log.Info("reconciling ingresses")
-	if err := r.reconcileIngresses(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "ingresses", func() error { return r.reconcileIngresses(cr) }); err != nil {
 		return err
 	}
 
-	if IsRouteAPIAvailable() {
+	if r.isRouteAPIAvailable() {
 		log.Info("reconciling routes")
-		if err := r.reconcileRoutes(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "routes", func() error { return r.reconcileRoutes(cr) }); err != nil {
 			return err
 		}
 	}
 
-	if IsPrometheusAPIAvailable() {
+	if r.isGatewayAPIAvailable() {
+		log.Info("reconciling gateway routes")
+		if err := r.reconcileStep(ctx, cr, "gatewayroutes", func() error { return r.reconcileGatewayRoutes(cr) }); err != nil {
+			return err
+		}
+	}
+
+	if r.isPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
-		if err := r.reconcilePrometheus(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "prometheus", func() error { return r.reconcilePrometheus(cr) }); err != nil {
 			return err
 		}
 
 		// Reconciles prometheusRule created to alert based on argo-cd workload status
-		if err := r.reconcilePrometheusRule(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "prometheusrule", func() error { return r.reconcilePrometheusRule(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileMetricsServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileRepoServerServiceMonitor(cr) }); err != nil {
+			return err
+		}
+
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileServerMetricsServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileMetricsServiceMonitor(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileDexServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileRepoServerServiceMonitor(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileApplicationSetServiceMonitor(cr) }); err != nil {
 			return err
 		}
 
-		if err := r.reconcileServerMetricsServiceMonitor(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "servicemonitors", func() error { return r.reconcileRedisServiceMonitor(cr) }); err != nil {
 			return err
 		}
 	}
 
+	log.Info("reconciling grafana dashboards")
+	if err := r.reconcileStep(ctx, cr, "dashboards", func() error { return r.reconcileDashboards(cr) }); err != nil {
+		return err
+	}
+
 	// check ManagedApplicationSetSourceNamespaces for proper cleanup
 	if cr.Spec.ApplicationSet != nil || len(r.ManagedApplicationSetSourceNamespaces) > 0 {
 		log.Info("reconciling ApplicationSet controller")
-		if err := r.reconcileApplicationSetController(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "applicationset", func() error { return r.reconcileApplicationSetController(cr) }); err != nil {
 			return err
 		}
 	}
 
 	if cr.Spec.Notifications.Enabled {
 		log.Info("reconciling Notifications controller")
-		if err := r.reconcileNotificationsController(cr); err != nil {
+		if err := r.reconcileStep(ctx, cr, "notifications", func() error { return r.reconcileNotificationsController(cr) }); err != nil {
 			return err
 		}
 	}
 
-	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "tlssecrets", func() error { return r.reconcileRepoServerTLSSecret(cr) }); err != nil {
 		return err
 	}
 
-	if err := r.reconcileRedisTLSSecret(cr, useTLSForRedis); err != nil {
+	if err := r.reconcileStep(ctx, cr, "tlssecrets", func() error { return r.reconcileRedisTLSSecret(cr, useTLSForRedis) }); err != nil {
 		return err
 	}
 
-	if err := r.ReconcileNetworkPolicies(cr); err != nil {
+	if err := r.reconcileStep(ctx, cr, "networkpolicies", func() error { return r.ReconcileNetworkPolicies(cr) }); err != nil {
 		return err
 	}
 
@@ -968,7 +1158,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper handler.MapFunc) *builder.Builder {
+func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretResourceMapper, applicationSetGitlabSCMTLSConfigMapMapper, conflictedInstanceMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -1060,6 +1250,17 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 	// Watch for changes to primary resource ArgoCD
 	bldr.For(&argoproj.ArgoCD{}, builder.WithPredicates(deleteSSOPred, deleteNotificationsPred))
 
+	// The deletion of an ArgoCD hands its namespace over to the next ArgoCD, which was conflicted
+	deletedInstancePred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetDeletionTimestamp() == nil && e.ObjectNew.GetDeletionTimestamp() != nil
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+	bldr.Watches(&argoproj.ArgoCD{}, handler.EnqueueRequestsFromMapFunc(conflictedInstanceMapper), builder.WithPredicates(deletedInstancePred))
+
 	// Watch for changes to ConfigMap sub-resources owned by ArgoCD instances.
 	bldr.Owns(&corev1.ConfigMap{})
 
@@ -1113,12 +1314,20 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		log.Info("unable to inspect cluster")
 	}
 
-	if IsRouteAPIAvailable() {
+	if r.isRouteAPIAvailable() {
 		// Watch OpenShift Route sub-resources owned by ArgoCD instances.
 		bldr.Owns(&routev1.Route{})
 	}
 
-	if IsPrometheusAPIAvailable() {
+	if r.isGatewayAPIAvailable() {
+		// Watch Gateway API route sub-resources owned by ArgoCD instances.
+		bldr.Owns(&gatewayv1.HTTPRoute{})
+		if r.isGRPCRouteAPIAvailable() {
+			bldr.Owns(&gatewayv1alpha2.GRPCRoute{})
+		}
+	}
+
+	if r.isPrometheusAPIAvailable() {
 		// Watch Prometheus sub-resources owned by ArgoCD instances.
 		bldr.Owns(&monitoringv1.Prometheus{})
 
@@ -1126,6 +1335,11 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 		bldr.Owns(&monitoringv1.ServiceMonitor{})
 	}
 
+	if IsGrafanaDashboardAPIAvailable() {
+		// Watch grafana-operator GrafanaDashboard sub-resources owned by ArgoCD instances.
+		bldr.Owns(grafanaDashboardObject())
+	}
+
 	if CanUseKeycloakWithTemplate() {
 		// Watch for the changes to Deployment Config
 		bldr.Owns(&oappsv1.DeploymentConfig{}, builder.WithPredicates(deploymentConfigPred))
@@ -1137,7 +1351,7 @@ func (r *ReconcileArgoCD) setResourceWatches(bldr *builder.Builder, clusterResou
 
 	namespaceHandler := handler.EnqueueRequestsFromMapFunc(namespaceResourceMapper)
 
-	bldr.Watches(&corev1.Namespace{}, namespaceHandler, builder.WithPredicates(namespaceFilterPredicate()))
+	bldr.Watches(&corev1.Namespace{}, namespaceHandler, builder.WithPredicates(r.namespaceFilterPredicate()))
 
 	return bldr
 }
@@ -1211,7 +1425,7 @@ type DeprecationEventEmissionStatus struct {
 // This is temporary and can be removed in v0.0.6 when we remove the deprecated fields.
 var DeprecationEventEmissionTracker = make(map[string]DeprecationEventEmissionStatus)
 
-func namespaceFilterPredicate() predicate.Predicate {
+func (r *ReconcileArgoCD) namespaceFilterPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			// This checks if ArgoCDManagedByLabel exists in newMeta, if exists then -
@@ -1240,6 +1454,10 @@ func namespaceFilterPredicate() predicate.Predicate {
 				}
 				return true
 			}
+			// Namespaces may be selected by their labels in the managedNamespaces of an ArgoCD, they are
+			// then labelled as managed by the instance by the reconciler.
+			labelsChanged := !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) &&
+				r.isSelectedByManagedNamespaces(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
 			// This checks if the old meta had the label, if it did, delete the RBACs for the namespace
 			// which were created when the label was added to the namespace.
 			if ns, ok := e.ObjectOld.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" {
@@ -1261,7 +1479,7 @@ func namespaceFilterPredicate() predicate.Predicate {
 				}
 
 			}
-			return false
+			return labelsChanged
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			if ns, ok := e.Object.GetLabels()[common.ArgoCDManagedByLabel]; ok && ns != "" {
@@ -1411,7 +1629,27 @@ func (r *ReconcileArgoCD) setManagedNamespaces(cr *argoproj.ArgoCD) error {
 		return err
 	}
 
-	namespaces.Items = append(namespaces.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}})
+	// add the namespaces selected by labels, and leave out the ones labelled by the operator which are no longer selected
+	selected, err := r.getSelectedNamespaces(cr)
+	if err != nil {
+		return err
+	}
+	r.recordManagedNamespacesSelections(cr)
+	r.ManagedNamespaceSelections = make(map[string]argoproj.ManagedNamespacesSpec)
+	managed := []corev1.Namespace{}
+	for _, namespace := range namespaces.Items {
+		if _, ok := namespace.Annotations[common.ArgoCDManagedBySelectorAnnotation]; ok && !isNamespaceSelected(selected, namespace.Name) {
+			continue
+		}
+		managed = append(managed, namespace)
+	}
+	for _, s := range selected {
+		r.ManagedNamespaceSelections[s.namespace.Name] = s.selection
+		if !containsNamespace(managed, s.namespace.Name) {
+			managed = append(managed, s.namespace)
+		}
+	}
+	namespaces.Items = append(managed, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cr.Namespace}})
 	r.ManagedNamespaces = namespaces
 	return nil
 }
@@ -253,6 +253,26 @@ var argoServerURITests = []struct {
 		}},
 		want: "https://test-host-name",
 	},
+	{
+		name:         "test with canonical host name",
+		routeEnabled: false,
+		opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+			a.Spec.Server.Host = "argocd.internal"
+			a.Spec.Server.AdditionalHosts = []string{"argocd.example.com"}
+			a.Spec.Server.CanonicalHost = "argocd.example.com"
+		}},
+		want: "https://argocd.example.com",
+	},
+	{
+		name:         "test with root path",
+		routeEnabled: false,
+		opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+			a.Spec.Server.Host = "test-host-name"
+			a.Spec.Server.Gateway.Enabled = true
+			a.Spec.Server.Gateway.Path = "/argocd/"
+		}},
+		want: "https://test-host-name/argocd",
+	},
 }
 
 func setRouteAPIFound(t *testing.T, routeEnabled bool) {
@@ -277,6 +297,122 @@ func TestGetArgoServerURI(t *testing.T) {
 	}
 }
 
+func TestGenerateRotatedArgoAdminPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation argoproj.ArgoCDAdminPasswordRotationSpec
+		length   int
+	}{
+		{
+			name:     "default length",
+			rotation: argoproj.ArgoCDAdminPasswordRotationSpec{Digits: 5},
+			length:   common.ArgoCDDefaultAdminPasswordLength,
+		},
+		{
+			name:     "max length",
+			rotation: argoproj.ArgoCDAdminPasswordRotationSpec{Length: 64, Digits: 5},
+			length:   64,
+		},
+		{
+			name:     "max length without uppercase",
+			rotation: argoproj.ArgoCDAdminPasswordRotationSpec{Length: 64, Digits: 5, NoUpper: true},
+			length:   64,
+		},
+		{
+			name:     "min length without uppercase",
+			rotation: argoproj.ArgoCDAdminPasswordRotationSpec{Length: 32, NoUpper: true},
+			length:   32,
+		},
+		{
+			name:     "more digits than available",
+			rotation: argoproj.ArgoCDAdminPasswordRotationSpec{Length: 16, Digits: 12, Symbols: 4},
+			length:   16,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass, err := generateRotatedArgoAdminPassword(&tt.rotation)
+			assert.NoError(t, err)
+			assert.Len(t, pass, tt.length)
+			if tt.rotation.NoUpper {
+				assert.Equal(t, strings.ToLower(string(pass)), string(pass))
+			}
+		})
+	}
+}
+
+func TestGetArgoServerRootPath(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []argoCDOpt
+		want string
+	}{
+		{
+			name: "not exposed",
+			want: "",
+		},
+		{
+			name: "ingress at the root",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Enabled = true
+				a.Spec.Server.Ingress.Path = "/"
+			}},
+			want: "",
+		},
+		{
+			name: "ingress with a sub-path",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Enabled = true
+				a.Spec.Server.Ingress.Path = "/argocd"
+			}},
+			want: "/argocd",
+		},
+		{
+			name: "ingress with a wildcard sub-path",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Enabled = true
+				a.Spec.Server.Ingress.Path = "/argocd/*"
+			}},
+			want: "/argocd",
+		},
+		{
+			name: "disabled ingress with a sub-path",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Ingress.Path = "/argocd"
+			}},
+			want: "",
+		},
+		{
+			name: "HTTPRoute with a sub-path",
+			opts: []argoCDOpt{func(a *argoproj.ArgoCD) {
+				a.Spec.Server.Gateway.Enabled = true
+				a.Spec.Server.Gateway.Path = "/tools/argocd"
+			}},
+			want: "/tools/argocd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getArgoServerRootPath(makeTestArgoCD(tt.opts...)))
+		})
+	}
+}
+
+func TestValidateArgoServerHosts(t *testing.T) {
+	a := makeTestArgoCD(func(a *argoproj.ArgoCD) {
+		a.Spec.Server.Host = "argocd.internal"
+		a.Spec.Server.AdditionalHosts = []string{"argocd.example.com", "argocd.internal"}
+	})
+	assert.Equal(t, []string{"argocd.internal", "argocd.example.com"}, getArgoServerHosts(a))
+	assert.NoError(t, validateArgoServerHosts(a))
+
+	a.Spec.Server.CanonicalHost = "argocd.example.com"
+	assert.NoError(t, validateArgoServerHosts(a))
+
+	a.Spec.Server.CanonicalHost = "argocd.example.org"
+	assert.Error(t, validateArgoServerHosts(a))
+}
+
 func TestRemoveDeletionFinalizer(t *testing.T) {
 	t.Run("ArgoCD resource present", func(t *testing.T) {
 		a := makeTestArgoCD(addFinalizer(common.ArgoCDDeletionFinalizer))
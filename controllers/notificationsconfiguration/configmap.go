@@ -2,15 +2,21 @@ package notificationsconfiguration
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -57,6 +63,14 @@ func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(c
 		expectedConfiguration[k] = v
 	}
 
+	services, err := getNotificationsServicesConfiguration(cr)
+	if err != nil {
+		return err
+	}
+	for k, v := range services {
+		expectedConfiguration[k] = v
+	}
+
 	for k, v := range cr.Spec.Subscriptions {
 		expectedConfiguration[k] = v
 	}
@@ -65,21 +79,93 @@ func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigmap(c
 		expectedConfiguration["context"] = mapToString(cr.Spec.Context)
 	}
 
+	appliedHash := NotificationsConfigMap.Annotations[common.AnnotationNotificationsConfigHash]
+	expectedHash := hashNotificationsConfiguration(expectedConfiguration)
 	if !reflect.DeepEqual(expectedConfiguration, NotificationsConfigMap.Data) {
+		// The configmap was modified since the configuration was last applied, while the NotificationsConfiguration
+		// didn't change: the manual edits are reported rather than overwritten. They are overwritten on the next
+		// change of the NotificationsConfiguration.
+		if appliedHash != "" && appliedHash != hashNotificationsConfiguration(NotificationsConfigMap.Data) &&
+			cr.Generation == cr.Status.ObservedGeneration {
+			setSyncedCondition(cr, metav1.ConditionFalse, "ManualEdit",
+				fmt.Sprintf("%s was modified outside of the NotificationsConfiguration, the edits are overwritten on its next change. Modified keys: %s",
+					ArgoCDNotificationsConfigMap, strings.Join(getModifiedKeys(expectedConfiguration, NotificationsConfigMap.Data), ", ")))
+			return nil
+		}
+
 		NotificationsConfigMap.Data = expectedConfiguration
+		setConfigHash(NotificationsConfigMap, expectedHash)
 		err := r.Client.Update(context.TODO(), NotificationsConfigMap)
 		if err != nil {
 			return err
 		}
+	} else if appliedHash != expectedHash {
+		setConfigHash(NotificationsConfigMap, expectedHash)
+		if err := r.Client.Update(context.TODO(), NotificationsConfigMap); err != nil {
+			return err
+		}
 	}
 
-	// Do nothing
+	setSyncedCondition(cr, metav1.ConditionTrue, "Synced", fmt.Sprintf("%s is up to date", ArgoCDNotificationsConfigMap))
 	return nil
 }
+
 func mapToString(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	result := ""
-	for key, value := range m {
-		result += fmt.Sprintf("%s: %s\n", key, value)
+	for _, key := range keys {
+		result += fmt.Sprintf("%s: %s\n", key, m[key])
 	}
 	return result
 }
+
+// hashNotificationsConfiguration returns the hash of the given notifications configmap data.
+func hashNotificationsConfiguration(data map[string]string) string {
+	if data == nil {
+		data = map[string]string{}
+	}
+	// Maps are marshalled with sorted keys, which makes the hash stable
+	out, _ := json.Marshal(data)
+	return fmt.Sprintf("%x", sha256.Sum256(out))
+}
+
+// setConfigHash records the hash of the configuration applied to the given notifications configmap.
+func setConfigHash(cm *corev1.ConfigMap, hash string) {
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[common.AnnotationNotificationsConfigHash] = hash
+}
+
+// getModifiedKeys returns the sorted keys whose values differ between the expected and the actual configmap data.
+func getModifiedKeys(expected map[string]string, actual map[string]string) []string {
+	keys := []string{}
+	for k, v := range expected {
+		if actualValue, ok := actual[k]; !ok || actualValue != v {
+			keys = append(keys, k)
+		}
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// setSyncedCondition sets the Synced condition of the given NotificationsConfiguration.
+func setSyncedCondition(cr *v1alpha1.NotificationsConfiguration, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               v1alpha1.NotificationsConfigurationConditionSynced,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cr.Generation,
+	})
+}
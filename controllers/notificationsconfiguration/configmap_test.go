@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 )
 
 type notificationsOpts func(*v1alpha1.NotificationsConfiguration)
@@ -226,3 +228,50 @@ func TestReconcileNotifications_DeleteConfigMap(t *testing.T) {
 	assert.Equal(t, testCM.Data["trigger.on-sync-status-test"],
 		"- when: app.status.sync.status == 'Unknown' \n send: [my-custom-template]")
 }
+
+func TestReconcileNotifications_ManualEdit(t *testing.T) {
+
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n",
+		}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionSynced))
+	assert.Equal(t, []string{"trigger.on-created"}, a.Status.Triggers)
+
+	// Edit the ConfigMap manually
+	key := types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: a.Namespace}
+	testCM := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, testCM))
+	testCM.Data["service.slack"] = "token: $slack-token"
+	assert.NoError(t, r.Client.Update(context.TODO(), testCM))
+
+	// The manual edit is reported and kept
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+	condition := meta.FindStatusCondition(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionSynced)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "ManualEdit", condition.Reason)
+	assert.Contains(t, condition.Message, "Modified keys: service.slack")
+	assert.NoError(t, r.Client.Get(context.TODO(), key, testCM))
+	assert.Equal(t, "token: $slack-token", testCM.Data["service.slack"])
+
+	// The next change of the NotificationsConfiguration overwrites the manual edit
+	a.Spec.Services = map[string]string{"service.webhook": "url: https://example.com"}
+	a.Generation = 2
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+	assert.True(t, meta.IsStatusConditionTrue(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionSynced))
+	assert.Equal(t, int64(2), a.Status.ObservedGeneration)
+	assert.Equal(t, []string{"service.webhook"}, a.Status.Services)
+	assert.NoError(t, r.Client.Get(context.TODO(), key, testCM))
+	assert.NotContains(t, testCM.Data, "service.slack")
+	assert.Equal(t, "url: https://example.com", testCM.Data["service.webhook"])
+}
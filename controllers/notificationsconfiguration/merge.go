@@ -0,0 +1,259 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/util/glob"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// notificationsMerge is the result of the merge of the NotificationsConfigurations of an instance.
+type notificationsMerge struct {
+	// config is the merged NotificationsConfiguration, with the metadata of the default NotificationsConfiguration.
+	config *v1alpha1.NotificationsConfiguration
+	// serviceNamespaces maps the keys of the typed services to the namespace of the NotificationsConfiguration
+	// defining them, where their Secrets are read from.
+	serviceNamespaces map[string]string
+	// conflicts maps the NotificationsConfigurations to their keys that are ignored, as they are defined by a
+	// NotificationsConfiguration of higher precedence.
+	conflicts map[types.NamespacedName][]string
+	// restricted maps the NotificationsConfigurations of the source namespaces to their keys that are ignored, as
+	// their namespace isn't trusted.
+	restricted map[types.NamespacedName][]string
+}
+
+// mergeNotificationsConfigurations merges the given NotificationsConfigurations, ordered by decreasing precedence.
+// A trigger, template, service, subscription or context key defined by several NotificationsConfigurations is taken
+// from the one of highest precedence, and reported as a conflict for the others.
+//
+// The NotificationsConfigurations of the source namespaces, outside of the namespace of the first one, only
+// contribute triggers and templates, unless their namespace matches one of the trusted namespaces, in which case
+// they also contribute services and subscriptions. The context is only taken from the namespace of the instance.
+func mergeNotificationsConfigurations(sources []*v1alpha1.NotificationsConfiguration, trustedNamespaces []string) notificationsMerge {
+	m := notificationsMerge{
+		config:            sources[0].DeepCopy(),
+		serviceNamespaces: map[string]string{},
+		conflicts:         map[types.NamespacedName][]string{},
+		restricted:        map[types.NamespacedName][]string{},
+	}
+	spec := &m.config.Spec
+	*spec = v1alpha1.NotificationsConfigurationSpec{
+		Triggers:      map[string]string{},
+		Templates:     map[string]string{},
+		Services:      map[string]string{},
+		Subscriptions: map[string]string{},
+	}
+
+	defined := map[string]bool{}
+	for _, src := range sources {
+		key := client.ObjectKeyFromObject(src)
+		// add returns true if the given key isn't defined by a NotificationsConfiguration of higher precedence
+		add := func(k string) bool {
+			if defined[k] {
+				m.conflicts[key] = append(m.conflicts[key], k)
+				return false
+			}
+			defined[k] = true
+			return true
+		}
+		// allow returns true if the given key can be taken from the NotificationsConfiguration
+		local := src.Namespace == sources[0].Namespace
+		trusted := local || glob.MatchStringInList(trustedNamespaces, src.Namespace, glob.GLOB)
+		allow := func(k string, trustedOnly bool) bool {
+			if local || (trustedOnly && trusted) {
+				return true
+			}
+			m.restricted[key] = append(m.restricted[key], k)
+			return false
+		}
+
+		for _, k := range sortedKeys(src.Spec.Triggers) {
+			if add(k) {
+				spec.Triggers[k] = src.Spec.Triggers[k]
+			}
+		}
+		for _, k := range sortedKeys(src.Spec.Templates) {
+			if add(k) {
+				spec.Templates[k] = src.Spec.Templates[k]
+			}
+		}
+		for _, k := range sortedKeys(src.Spec.Services) {
+			if allow(k, true) && add(k) {
+				spec.Services[k] = src.Spec.Services[k]
+			}
+		}
+		for _, svc := range src.Spec.ServiceDefinitions {
+			if k := getNotificationsServiceKey(svc); allow(k, true) && add(k) {
+				spec.ServiceDefinitions = append(spec.ServiceDefinitions, svc)
+				m.serviceNamespaces[k] = src.Namespace
+			}
+		}
+		for _, k := range sortedKeys(src.Spec.Subscriptions) {
+			if allow(k, true) && add(k) {
+				spec.Subscriptions[k] = src.Spec.Subscriptions[k]
+			}
+		}
+		for _, k := range sortedKeys(src.Spec.Context) {
+			if allow("context."+k, false) && add("context."+k) {
+				if spec.Context == nil {
+					spec.Context = map[string]string{}
+				}
+				spec.Context[k] = src.Spec.Context[k]
+			}
+		}
+	}
+	return m
+}
+
+// getNotificationsSources returns the NotificationsConfigurations merged into the notifications configuration of
+// the namespace of the given default NotificationsConfiguration, ordered by decreasing precedence: the default
+// NotificationsConfiguration, the other NotificationsConfigurations of the namespace sorted by name, then the
+// NotificationsConfigurations of the source namespaces sorted by namespace and name. The trusted source namespaces
+// of the instance are also returned.
+func (r *NotificationsConfigurationReconciler) getNotificationsSources(cr *v1alpha1.NotificationsConfiguration) ([]*v1alpha1.NotificationsConfiguration, []string, error) {
+	sources := []*v1alpha1.NotificationsConfiguration{cr}
+
+	list := &v1alpha1.NotificationsConfigurationList{}
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(cr.Namespace)); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the NotificationsConfigurations of namespace %s : %s", cr.Namespace, err)
+	}
+	sortNotificationsConfigurations(list.Items)
+	for i := range list.Items {
+		if list.Items[i].Name != cr.Name {
+			sources = append(sources, &list.Items[i])
+		}
+	}
+
+	notifications, err := r.getNotificationsSpec(cr.Namespace)
+	if err != nil || notifications == nil || len(notifications.SourceNamespaces) == 0 {
+		return sources, nil, err
+	}
+	list = &v1alpha1.NotificationsConfigurationList{}
+	if err := r.Client.List(context.TODO(), list); err != nil {
+		return nil, nil, fmt.Errorf("failed to list the NotificationsConfigurations : %s", err)
+	}
+	sortNotificationsConfigurations(list.Items)
+	for i := range list.Items {
+		if ns := list.Items[i].Namespace; ns != cr.Namespace && glob.MatchStringInList(notifications.SourceNamespaces, ns, glob.GLOB) {
+			sources = append(sources, &list.Items[i])
+		}
+	}
+	return sources, notifications.TrustedSourceNamespaces, nil
+}
+
+// getNotificationsSpec returns the notifications options of the ArgoCD instance in the given namespace, or nil if
+// notifications are not enabled.
+func (r *NotificationsConfigurationReconciler) getNotificationsSpec(namespace string) (*argoproj.ArgoCDNotifications, error) {
+	list := &argoproj.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list the ArgoCD instances of namespace %s : %s", namespace, err)
+	}
+	for _, argocd := range list.Items {
+		if argocd.Spec.Notifications.Enabled {
+			return argocd.Spec.Notifications.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
+
+// getNotificationsTargetNamespaces returns the namespaces whose notifications configuration merges the
+// NotificationsConfigurations of the given namespace: the namespace itself, and the namespaces of the ArgoCD
+// instances listing it as a notifications source namespace.
+func (r *NotificationsConfigurationReconciler) getNotificationsTargetNamespaces(ctx context.Context, namespace string) ([]string, error) {
+	namespaces := []string{namespace}
+
+	list := &argoproj.ArgoCDList{}
+	if err := r.Client.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list the ArgoCD instances : %s", err)
+	}
+	for _, argocd := range list.Items {
+		if argocd.Namespace != namespace && argocd.Spec.Notifications.Enabled &&
+			glob.MatchStringInList(argocd.Spec.Notifications.SourceNamespaces, namespace, glob.GLOB) {
+			namespaces = append(namespaces, argocd.Namespace)
+		}
+	}
+	sort.Strings(namespaces[1:])
+	return namespaces, nil
+}
+
+// sortNotificationsConfigurations sorts the given NotificationsConfigurations by namespace and name.
+func sortNotificationsConfigurations(items []v1alpha1.NotificationsConfiguration) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+}
+
+// setConflictedCondition sets the Conflicted condition of the given NotificationsConfiguration from the keys it
+// defines that are ignored.
+func setConflictedCondition(cr *v1alpha1.NotificationsConfiguration, conflicts []string) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.NotificationsConfigurationConditionConflicted,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NoConflict",
+		Message:            "The notifications configuration doesn't conflict with other NotificationsConfigurations",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(conflicts) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DuplicateKeys"
+		condition.Message = fmt.Sprintf("Keys defined by a NotificationsConfiguration of higher precedence are ignored: %s", strings.Join(conflicts, ", "))
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// setRestrictedCondition sets the Restricted condition of the given NotificationsConfiguration from the keys it
+// defines that are ignored, as its namespace isn't trusted.
+func setRestrictedCondition(cr *v1alpha1.NotificationsConfiguration, restricted []string) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.NotificationsConfigurationConditionRestricted,
+		Status:             metav1.ConditionFalse,
+		Reason:             "NotRestricted",
+		Message:            "The notifications configuration is fully merged",
+		ObservedGeneration: cr.Generation,
+	}
+	if len(restricted) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "UntrustedNamespace"
+		condition.Message = fmt.Sprintf("Keys that can only be defined in the namespace of the Argo CD instance or in a trusted source namespace are ignored: %s", strings.Join(restricted, ", "))
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// argocdMapper maps an ArgoCD instance to the default NotificationsConfiguration of its namespace, so that a change
+// of its notifications source namespaces is applied.
+func (r *NotificationsConfigurationReconciler) argocdMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Name:      common.ArgoCDDefaultNotificationsConfigurationName,
+		Namespace: o.GetNamespace(),
+	}}}
+}
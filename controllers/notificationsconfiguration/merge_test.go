@@ -0,0 +1,297 @@
+package notificationsconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestMergeNotificationsConfigurations(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Triggers = map[string]string{"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n"}
+		a.Spec.Context = map[string]string{"argocdUrl": "https://argocd.example.com"}
+	})
+	b := makeTestNotificationsConfiguration(func(b *v1alpha1.NotificationsConfiguration) {
+		b.Name = "team"
+		b.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- when: \"false\"\n  send: [app-created]\n",
+			"trigger.on-deleted": "- when: \"true\"\n  send: [app-deleted]\n",
+		}
+		b.Spec.Services = map[string]string{"service.slack": "token: $slack-token"}
+		b.Spec.Context = map[string]string{"argocdUrl": "https://other.example.com", "environment": "prod"}
+	})
+	c := makeTestNotificationsConfiguration(func(c *v1alpha1.NotificationsConfiguration) {
+		c.Name = "team"
+		c.Namespace = "team-a"
+		c.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+			{Name: "ops", Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "ops")}},
+		}
+	})
+
+	m := mergeNotificationsConfigurations([]*v1alpha1.NotificationsConfiguration{a, b, c}, []string{"team-*"})
+	assert.Equal(t, a.Name, m.config.Name)
+	assert.Equal(t, map[string]string{
+		"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n",
+		"trigger.on-deleted": "- when: \"true\"\n  send: [app-deleted]\n",
+	}, m.config.Spec.Triggers)
+	assert.Equal(t, map[string]string{"service.slack": "token: $slack-token"}, m.config.Spec.Services)
+	assert.Equal(t, c.Spec.ServiceDefinitions[1:], m.config.Spec.ServiceDefinitions)
+	assert.Equal(t, map[string]string{"service.slack.ops": "team-a"}, m.serviceNamespaces)
+	assert.Equal(t, map[string]string{"argocdUrl": "https://argocd.example.com", "environment": "prod"}, m.config.Spec.Context)
+	assert.Equal(t, map[types.NamespacedName][]string{
+		{Name: "team", Namespace: "default"}: {"trigger.on-created", "context.argocdUrl"},
+		{Name: "team", Namespace: "team-a"}:  {"service.slack"},
+	}, m.conflicts)
+	assert.Empty(t, m.restricted)
+}
+
+func TestMergeNotificationsConfigurations_untrustedSourceNamespace(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Context = map[string]string{"argocdUrl": "https://argocd.example.com"}
+	})
+	b := makeTestNotificationsConfiguration(func(b *v1alpha1.NotificationsConfiguration) {
+		b.Name = "team"
+		b.Namespace = "team-a"
+		b.Spec.Triggers = map[string]string{"trigger.on-deleted": "- when: \"true\"\n  send: [app-deleted]\n"}
+		b.Spec.Templates = map[string]string{"template.app-deleted": "message: deleted\n"}
+		b.Spec.Services = map[string]string{"service.webhook.exfiltrate": "url: https://evil.example.com"}
+		b.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+		}
+		b.Spec.Subscriptions = map[string]string{"subscriptions": "- recipients: [webhook:exfiltrate]\n  triggers: [on-deleted]\n"}
+		b.Spec.Context = map[string]string{"argocdUrl": "https://evil.example.com", "environment": "prod"}
+	})
+
+	// a source namespace can not override the context nor add services or global subscriptions
+	m := mergeNotificationsConfigurations([]*v1alpha1.NotificationsConfiguration{a, b}, nil)
+	assert.Equal(t, b.Spec.Triggers, m.config.Spec.Triggers)
+	assert.Equal(t, b.Spec.Templates, m.config.Spec.Templates)
+	assert.Empty(t, m.config.Spec.Services)
+	assert.Empty(t, m.config.Spec.ServiceDefinitions)
+	assert.Empty(t, m.serviceNamespaces)
+	assert.Empty(t, m.config.Spec.Subscriptions)
+	assert.Equal(t, map[string]string{"argocdUrl": "https://argocd.example.com"}, m.config.Spec.Context)
+	assert.Empty(t, m.conflicts)
+	assert.Equal(t, map[types.NamespacedName][]string{
+		{Name: "team", Namespace: "team-a"}: {"service.webhook.exfiltrate", "service.slack", "subscriptions", "context.argocdUrl", "context.environment"},
+	}, m.restricted)
+
+	// a trusted source namespace can add services and subscriptions, but not override the context
+	m = mergeNotificationsConfigurations([]*v1alpha1.NotificationsConfiguration{a, b}, []string{"team-a"})
+	assert.Equal(t, b.Spec.Services, m.config.Spec.Services)
+	assert.Equal(t, b.Spec.ServiceDefinitions, m.config.Spec.ServiceDefinitions)
+	assert.Equal(t, b.Spec.Subscriptions, m.config.Spec.Subscriptions)
+	assert.Equal(t, map[string]string{"argocdUrl": "https://argocd.example.com"}, m.config.Spec.Context)
+	assert.Equal(t, map[types.NamespacedName][]string{
+		{Name: "team", Namespace: "team-a"}: {"context.argocdUrl", "context.environment"},
+	}, m.restricted)
+}
+
+func TestReconcileNotifications_MergeSourceNamespaces(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Namespace = "argocd"
+		a.Spec.Triggers = map[string]string{"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n"}
+	})
+	b := makeTestNotificationsConfiguration(func(b *v1alpha1.NotificationsConfiguration) {
+		b.Name = "team"
+		b.Namespace = "team-a"
+		b.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- when: \"false\"\n  send: [app-created]\n",
+			"trigger.on-deleted": "- when: \"true\"\n  send: [app-deleted]\n",
+		}
+		b.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+		}
+	})
+	ignored := makeTestNotificationsConfiguration(func(c *v1alpha1.NotificationsConfiguration) {
+		c.Name = "other"
+		c.Namespace = "other"
+		c.Spec.Triggers = map[string]string{"trigger.on-other": "- when: \"true\"\n  send: [app-other]\n"}
+	})
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec: argoproj.ArgoCDSpec{Notifications: argoproj.ArgoCDNotifications{
+			Enabled:                 true,
+			SourceNamespaces:        []string{"team-*"},
+			TrustedSourceNamespaces: []string{"team-a"},
+		}},
+	}
+	slackSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("xoxb-1")},
+	}
+	notificationsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDNotificationsSecret, Namespace: "argocd"},
+	}
+
+	resObjs := []client.Object{a, b, ignored, argocd, slackSecret, notificationsSecret}
+	subresObjs := []client.Object{a, b, ignored}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	namespaces, err := r.getNotificationsTargetNamespaces(context.TODO(), "team-a")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "argocd"}, namespaces)
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(b)})
+	assert.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: "argocd"}, cm))
+	assert.Equal(t, "- when: \"true\"\n  send: [app-created]\n", cm.Data["trigger.on-created"])
+	assert.Equal(t, "- when: \"true\"\n  send: [app-deleted]\n", cm.Data["trigger.on-deleted"])
+	assert.Equal(t, "token: $slack-token\n", cm.Data["service.slack"])
+	assert.NotContains(t, cm.Data, "trigger.on-other")
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsSecret, Namespace: "argocd"}, secret))
+	assert.Equal(t, "xoxb-1", string(secret.Data["slack-token"]))
+
+	// the conflicting trigger is reported on the NotificationsConfiguration of lower precedence
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(b), b))
+	condition := meta.FindStatusCondition(b.Status.Conditions, v1alpha1.NotificationsConfigurationConditionConflicted)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "trigger.on-created")
+	assert.True(t, meta.IsStatusConditionTrue(b.Status.Conditions, v1alpha1.NotificationsConfigurationConditionSynced))
+	assert.True(t, meta.IsStatusConditionFalse(b.Status.Conditions, v1alpha1.NotificationsConfigurationConditionRestricted))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(a), a))
+	assert.True(t, meta.IsStatusConditionFalse(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionConflicted))
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(ignored), ignored))
+	assert.Empty(t, ignored.Status.Conditions)
+}
+
+func TestReconcileNotifications_MergeUntrustedSourceNamespace(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Namespace = "argocd"
+		a.Spec.Context = map[string]string{"argocdUrl": "https://argocd.example.com"}
+	})
+	b := makeTestNotificationsConfiguration(func(b *v1alpha1.NotificationsConfiguration) {
+		b.Name = "team"
+		b.Namespace = "team-a"
+		b.Spec.Triggers = map[string]string{"trigger.on-deleted": "- when: \"true\"\n  send: [app-deleted]\n"}
+		b.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+		}
+		b.Spec.Subscriptions = map[string]string{"subscriptions": "- recipients: [slack:all]\n  triggers: [on-deleted]\n"}
+		b.Spec.Context = map[string]string{"argocdUrl": "https://evil.example.com"}
+	})
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec: argoproj.ArgoCDSpec{Notifications: argoproj.ArgoCDNotifications{
+			Enabled:          true,
+			SourceNamespaces: []string{"team-*"},
+		}},
+	}
+	slackSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "team-a"},
+		Data:       map[string][]byte{"token": []byte("xoxb-1")},
+	}
+	notificationsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDNotificationsSecret, Namespace: "argocd"},
+	}
+
+	resObjs := []client.Object{a, b, argocd, slackSecret, notificationsSecret}
+	subresObjs := []client.Object{a, b}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(b)})
+	assert.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: "argocd"}, cm))
+	assert.Equal(t, "- when: \"true\"\n  send: [app-deleted]\n", cm.Data["trigger.on-deleted"])
+	assert.NotContains(t, cm.Data, "service.slack")
+	assert.NotContains(t, cm.Data, "subscriptions")
+	assert.Contains(t, cm.Data["context"], "https://argocd.example.com")
+	assert.NotContains(t, cm.Data["context"], "evil")
+
+	secret := &corev1.Secret{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsSecret, Namespace: "argocd"}, secret))
+	assert.NotContains(t, secret.Data, "slack-token")
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(b), b))
+	condition := meta.FindStatusCondition(b.Status.Conditions, v1alpha1.NotificationsConfigurationConditionRestricted)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Contains(t, condition.Message, "service.slack, subscriptions, context.argocdUrl")
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(a), a))
+	assert.Nil(t, meta.FindStatusCondition(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionRestricted))
+}
+
+func TestReconcileNotifications_IgnoreNonSourceNamespace(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Namespace = "argocd"
+		a.Spec.Triggers = map[string]string{"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n"}
+	})
+	other := makeTestNotificationsConfiguration(func(c *v1alpha1.NotificationsConfiguration) {
+		c.Name = "team"
+		c.Namespace = "other"
+		c.Spec.Triggers = map[string]string{
+			"trigger.on-created": "- when: \"false\"\n  send: [app-created]\n",
+			"trigger.on-other":   "- when: \"true\"\n  send: [app-other]\n",
+		}
+		c.Spec.Subscriptions = map[string]string{"subscriptions": "- recipients: [slack:all]\n  triggers: [on-other]\n"}
+	})
+	argocd := &argoproj.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec: argoproj.ArgoCDSpec{Notifications: argoproj.ArgoCDNotifications{
+			Enabled:          true,
+			SourceNamespaces: []string{"team-*"},
+		}},
+	}
+	notificationsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDNotificationsSecret, Namespace: "argocd"},
+	}
+
+	resObjs := []client.Object{a, other, argocd, notificationsSecret}
+	subresObjs := []client.Object{a, other}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(a)})
+	assert.NoError(t, err)
+	cm := &corev1.ConfigMap{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: "argocd"}, cm))
+	resourceVersion := cm.ResourceVersion
+
+	// a NotificationsConfiguration outside of the source namespaces, which has no default NotificationsConfiguration
+	// of its own, is ignored: it isn't merged into the configuration of the instance and gets no status
+	namespaces, err := r.getNotificationsTargetNamespaces(context.TODO(), "other")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"other"}, namespaces)
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: client.ObjectKeyFromObject(other)})
+	assert.NoError(t, err)
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: "argocd"}, cm))
+	assert.Equal(t, resourceVersion, cm.ResourceVersion)
+	assert.Equal(t, "- when: \"true\"\n  send: [app-created]\n", cm.Data["trigger.on-created"])
+	assert.NotContains(t, cm.Data, "trigger.on-other")
+	assert.NotContains(t, cm.Data, "subscriptions")
+
+	assert.NoError(t, r.Client.Get(context.TODO(), client.ObjectKeyFromObject(other), other))
+	assert.Empty(t, other.Status.Conditions)
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: "other"}, &corev1.ConfigMap{}))
+}
@@ -20,9 +20,11 @@ import (
 	"context"
 
 	v1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,21 +57,29 @@ func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, re
 	reqLogger := logr.FromContext(ctx, "Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling NotificationsConfiguration")
 
-	notificationsConfig := &v1alpha1.NotificationsConfiguration{}
-	err := r.Client.Get(ctx, request.NamespacedName, notificationsConfig)
+	// The NotificationsConfigurations of a namespace are merged into the notifications configuration of the namespace,
+	// and of the namespaces whose ArgoCD instance lists it as a notifications source namespace.
+	namespaces, err := r.getNotificationsTargetNamespaces(ctx, request.Namespace)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	if err := r.reconcileNotificationsConfigurationResources(notificationsConfig); err != nil {
-		return reconcile.Result{}, err
+	for _, namespace := range namespaces {
+		notificationsConfig := &v1alpha1.NotificationsConfiguration{}
+		key := types.NamespacedName{Name: common.ArgoCDDefaultNotificationsConfigurationName, Namespace: namespace}
+		if err := r.Client.Get(ctx, key, notificationsConfig); err != nil {
+			if errors.IsNotFound(err) {
+				// The notifications configuration of a namespace is managed along with its default
+				// NotificationsConfiguration. Owned objects are automatically garbage collected.
+				continue
+			}
+			// Error reading the object - requeue the request.
+			return reconcile.Result{}, err
+		}
+
+		if err := r.reconcileNotificationsConfigurationResources(notificationsConfig); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	// Return and don't requeue
@@ -79,6 +89,6 @@ func (r *NotificationsConfigurationReconciler) Reconcile(ctx context.Context, re
 // SetupWithManager sets up the controller with the Manager.
 func (r *NotificationsConfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	setResourceWatches(bldr)
+	setResourceWatches(bldr, r.secretMapper, r.argocdMapper)
 	return bldr.Complete(r)
 }
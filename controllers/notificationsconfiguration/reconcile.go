@@ -15,27 +15,158 @@
 package notificationsconfiguration
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 )
 
-// reconcileNotificationsConfigurationResources will reconcile all the resources for the given CR.
+// reconcileNotificationsConfigurationResources will reconcile all the resources for the given default CR, merging
+// the other NotificationsConfigurations of its namespace and of the notifications source namespaces.
 func (r *NotificationsConfigurationReconciler) reconcileNotificationsConfigurationResources(cr *v1alpha1.NotificationsConfiguration) error {
 
-	if err := r.reconcileNotificationsConfigmap(cr); err != nil {
+	sources, trustedNamespaces, err := r.getNotificationsSources(cr)
+	if err != nil {
 		return err
 	}
-	return nil
+	existing := make([]*v1alpha1.NotificationsConfigurationStatus, len(sources))
+	for i, src := range sources {
+		existing[i] = src.Status.DeepCopy()
+	}
+
+	validationErrs := make([]error, len(sources))
+	invalid := []string{}
+	changed := false
+	for i, src := range sources {
+		if validationErrs[i] = validateNotificationsConfiguration(src); validationErrs[i] != nil {
+			invalid = append(invalid, client.ObjectKeyFromObject(src).String())
+		}
+		changed = changed || src.Generation != src.Status.ObservedGeneration
+	}
+
+	merge := mergeNotificationsConfigurations(sources, trustedNamespaces)
+	merged := merge.config
+	// Manual edits of the configmap are only kept while none of the merged NotificationsConfigurations changed
+	if changed {
+		merged.Status.ObservedGeneration = -1
+	} else {
+		merged.Status.ObservedGeneration = merged.Generation
+	}
+
+	// An invalid configuration is reported in the status, and the last valid configuration is kept in place
+	if len(invalid) > 0 {
+		setSyncedCondition(merged, metav1.ConditionFalse, "Invalid",
+			fmt.Sprintf("The notifications configuration is invalid, see the Invalid condition of %s", strings.Join(invalid, ", ")))
+	} else if err = r.reconcileNotificationsSecret(merged, merge.serviceNamespaces); err == nil {
+		err = r.reconcileNotificationsConfigmap(merged)
+	}
+	if err != nil {
+		setSyncedCondition(merged, metav1.ConditionFalse, "SyncFailed", err.Error())
+	}
+	synced := meta.FindStatusCondition(merged.Status.Conditions, v1alpha1.NotificationsConfigurationConditionSynced)
+
+	for i, src := range sources {
+		setSyncedCondition(src, synced.Status, synced.Reason, synced.Message)
+		setInvalidCondition(src, validationErrs[i])
+		setConflictedCondition(src, merge.conflicts[client.ObjectKeyFromObject(src)])
+		if src.Namespace != cr.Namespace {
+			setRestrictedCondition(src, merge.restricted[client.ObjectKeyFromObject(src)])
+		}
+		src.Status.Triggers = sortedKeys(src.Spec.Triggers)
+		src.Status.Services = getConfiguredServices(src)
+		src.Status.ObservedGeneration = src.Generation
+		if !reflect.DeepEqual(existing[i], &src.Status) {
+			if statusErr := r.Client.Status().Update(context.TODO(), src); statusErr != nil && err == nil {
+				err = statusErr
+			}
+		}
+	}
+	return err
+}
+
+// getConfiguredServices returns the sorted names of the services of the given NotificationsConfiguration.
+func getConfiguredServices(cr *v1alpha1.NotificationsConfiguration) []string {
+	services := sortedKeys(cr.Spec.Services)
+	for _, svc := range cr.Spec.ServiceDefinitions {
+		if getNotificationsServiceType(svc) != "" {
+			services = append(services, getNotificationsServiceKey(svc))
+		}
+	}
+	sort.Strings(services)
+	return services
+}
+
+// setInvalidCondition sets the Invalid condition of the given NotificationsConfiguration from the result of its
+// validation.
+func setInvalidCondition(cr *v1alpha1.NotificationsConfiguration, validationErr error) {
+	condition := metav1.Condition{
+		Type:               v1alpha1.NotificationsConfigurationConditionInvalid,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Valid",
+		Message:            "The notifications configuration is valid",
+		ObservedGeneration: cr.Generation,
+	}
+	if validationErr != nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ValidationFailed"
+		condition.Message = validationErr.Error()
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func setResourceWatches(bld *builder.Builder) *builder.Builder {
+func setResourceWatches(bld *builder.Builder, secretMapper, argocdMapper handler.MapFunc) *builder.Builder {
 	// Watch for changes to primary resource NotificationsConfiguration
 	bld.For(&v1alpha1.NotificationsConfiguration{})
 	// Watch for changes to Configmap sub-resources owned by NotificationsConfigurationController.
 	bld.Owns(&corev1.ConfigMap{})
+	// Watch for changes to the Secrets referenced by the typed notification services, and to the notifications secret.
+	bld.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(secretMapper))
+	// Watch for changes to the notifications source namespaces of the ArgoCD instances.
+	bld.Watches(&argoproj.ArgoCD{}, handler.EnqueueRequestsFromMapFunc(argocdMapper))
 
 	return bld
 }
+
+// secretMapper maps a Secret to the NotificationsConfigurations of its namespace that reference it. A change to the
+// notifications secret maps to every NotificationsConfiguration of the namespace.
+func (r *NotificationsConfigurationReconciler) secretMapper(ctx context.Context, o client.Object) []reconcile.Request {
+	list := &v1alpha1.NotificationsConfigurationList{}
+	if err := r.Client.List(ctx, list, client.InNamespace(o.GetNamespace())); err != nil {
+		return nil
+	}
+
+	result := []reconcile.Request{}
+	for _, cr := range list.Items {
+		if o.GetName() == ArgoCDNotificationsSecret || referencesNotificationsSecret(&cr, o.GetName()) {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cr)})
+		}
+	}
+	return result
+}
+
+// referencesNotificationsSecret returns true if a typed service of the given NotificationsConfiguration references
+// the Secret with the given name.
+func referencesNotificationsSecret(cr *v1alpha1.NotificationsConfiguration, name string) bool {
+	for _, svc := range cr.Spec.ServiceDefinitions {
+		for _, selector := range getNotificationsService(svc).secrets {
+			if selector.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
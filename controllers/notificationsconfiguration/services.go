@@ -0,0 +1,267 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	ArgoCDNotificationsSecret = "argocd-notifications-secret"
+)
+
+// invalidSecretKeyChars matches the characters that can't be used in the key of a Secret, nor in a reference to
+// the notifications secret.
+var invalidSecretKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// notificationsService holds the rendered configuration of a typed notification service, along with the Secret
+// keys it references in the notifications secret.
+type notificationsService struct {
+	// key is the key of the service in the notifications configmap, e.g. service.slack.
+	key string
+	// config is the configuration of the service.
+	config map[string]interface{}
+	// secrets maps the keys of the notifications secret used by the service to the user Secret keys holding them.
+	secrets map[string]corev1.SecretKeySelector
+}
+
+// ref registers the given user Secret key under the given notifications secret key, and returns the reference to
+// use in the service configuration.
+func (s *notificationsService) ref(selector corev1.SecretKeySelector, parts ...string) string {
+	key := notificationsSecretKey(append([]string{strings.TrimPrefix(strings.ReplaceAll(s.key, ".", "-"), "service-")}, parts...)...)
+	s.secrets[key] = selector
+	return "$" + key
+}
+
+// notificationsSecretKey returns the key of the notifications secret made of the given parts.
+func notificationsSecretKey(parts ...string) string {
+	return invalidSecretKeyChars.ReplaceAllString(strings.Join(parts, "-"), "-")
+}
+
+// getNotificationsServiceType returns the type of the given service, or an empty string if not exactly one type is set.
+func getNotificationsServiceType(svc v1alpha1.NotificationsServiceSpec) string {
+	types := []string{}
+	if svc.Slack != nil {
+		types = append(types, "slack")
+	}
+	if svc.Email != nil {
+		types = append(types, "email")
+	}
+	if svc.Webhook != nil {
+		types = append(types, "webhook")
+	}
+	if svc.Teams != nil {
+		types = append(types, "teams")
+	}
+	if svc.PagerDuty != nil {
+		types = append(types, "pagerdutyv2")
+	}
+	if len(types) != 1 {
+		return ""
+	}
+	return types[0]
+}
+
+// getNotificationsServiceKey returns the key of the given service in the notifications configmap.
+func getNotificationsServiceKey(svc v1alpha1.NotificationsServiceSpec) string {
+	key := "service." + getNotificationsServiceType(svc)
+	if svc.Name != "" {
+		key += "." + svc.Name
+	}
+	return key
+}
+
+// getNotificationsService renders the configuration of the given typed service.
+func getNotificationsService(svc v1alpha1.NotificationsServiceSpec) notificationsService {
+	s := notificationsService{
+		key:     getNotificationsServiceKey(svc),
+		config:  map[string]interface{}{},
+		secrets: map[string]corev1.SecretKeySelector{},
+	}
+
+	switch {
+	case svc.Slack != nil:
+		s.config["token"] = s.ref(svc.Slack.Token, "token")
+		if svc.Slack.SigningSecret != nil {
+			s.config["signingSecret"] = s.ref(*svc.Slack.SigningSecret, "signing-secret")
+		}
+		if svc.Slack.Username != "" {
+			s.config["username"] = svc.Slack.Username
+		}
+		if svc.Slack.Icon != "" {
+			s.config["icon"] = svc.Slack.Icon
+		}
+		if svc.Slack.APIURL != "" {
+			s.config["apiURL"] = svc.Slack.APIURL
+		}
+	case svc.Email != nil:
+		s.config["host"] = svc.Email.Host
+		s.config["port"] = svc.Email.Port
+		s.config["from"] = svc.Email.From
+		if svc.Email.Username != nil {
+			s.config["username"] = s.ref(*svc.Email.Username, "username")
+		}
+		if svc.Email.Password != nil {
+			s.config["password"] = s.ref(*svc.Email.Password, "password")
+		}
+		if svc.Email.HTML {
+			s.config["html"] = true
+		}
+		if svc.Email.InsecureSkipVerify {
+			s.config["insecure_skip_verify"] = true
+		}
+	case svc.Webhook != nil:
+		s.config["url"] = svc.Webhook.URL
+		headers := []map[string]string{}
+		for _, header := range svc.Webhook.Headers {
+			value := header.Value
+			if header.ValueFrom != nil {
+				value = s.ref(*header.ValueFrom, "header", strings.ToLower(header.Name))
+			}
+			headers = append(headers, map[string]string{"name": header.Name, "value": value})
+		}
+		if len(headers) > 0 {
+			s.config["headers"] = headers
+		}
+		if svc.Webhook.BasicAuth != nil {
+			s.config["basicAuth"] = map[string]string{
+				"username": svc.Webhook.BasicAuth.Username,
+				"password": s.ref(svc.Webhook.BasicAuth.Password, "password"),
+			}
+		}
+		if svc.Webhook.InsecureSkipVerify {
+			s.config["insecureSkipVerify"] = true
+		}
+	case svc.Teams != nil:
+		urls := map[string]string{}
+		for channel, selector := range svc.Teams.RecipientURLs {
+			urls[channel] = s.ref(selector, "recipient", channel)
+		}
+		s.config["recipientUrls"] = urls
+	case svc.PagerDuty != nil:
+		keys := map[string]string{}
+		for service, selector := range svc.PagerDuty.ServiceKeys {
+			keys[service] = s.ref(selector, service)
+		}
+		s.config["serviceKeys"] = keys
+	}
+	return s
+}
+
+// getNotificationsServicesConfiguration returns the entries of the notifications configmap for the typed services
+// of the given NotificationsConfiguration.
+func getNotificationsServicesConfiguration(cr *v1alpha1.NotificationsConfiguration) (map[string]string, error) {
+	configuration := map[string]string{}
+	for _, svc := range cr.Spec.ServiceDefinitions {
+		s := getNotificationsService(svc)
+		out, err := yaml.Marshal(s.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render notifications service %s: %w", s.key, err)
+		}
+		configuration[s.key] = string(out)
+	}
+	return configuration, nil
+}
+
+// reconcileNotificationsSecret will ensure that the credentials of the typed services, read from the referenced
+// Secrets, are synced into the notifications secret. Keys previously synced and no longer referenced are removed.
+// The Secrets of a service are read from the namespace given in serviceNamespaces, or from the namespace of the CR.
+func (r *NotificationsConfigurationReconciler) reconcileNotificationsSecret(cr *v1alpha1.NotificationsConfiguration, serviceNamespaces map[string]string) error {
+	desired := map[string][]byte{}
+	for _, svc := range cr.Spec.ServiceDefinitions {
+		namespace, ok := serviceNamespaces[getNotificationsServiceKey(svc)]
+		if !ok {
+			namespace = cr.Namespace
+		}
+		for key, selector := range getNotificationsService(svc).secrets {
+			source := &corev1.Secret{}
+			if err := argoutil.FetchObject(r.Client, namespace, selector.Name, source); err != nil {
+				return fmt.Errorf("failed to get the secret %s referenced by %s : %s", selector.Name, getNotificationsServiceKey(svc), err)
+			}
+			value, ok := source.Data[selector.Key]
+			if !ok {
+				return fmt.Errorf("key %s not found in the secret %s referenced by %s", selector.Key, selector.Name, getNotificationsServiceKey(svc))
+			}
+			desired[key] = value
+		}
+	}
+
+	secret := &corev1.Secret{}
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, ArgoCDNotificationsSecret, secret); err != nil {
+		if errors.IsNotFound(err) && len(desired) == 0 {
+			return nil
+		}
+		return fmt.Errorf("failed to get the secret %s : %s", ArgoCDNotificationsSecret, err)
+	}
+
+	changed := false
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for _, key := range splitManagedKeys(secret.Annotations[common.AnnotationNotificationsSecretKeys]) {
+		if _, ok := desired[key]; !ok {
+			delete(secret.Data, key)
+			changed = true
+		}
+	}
+	keys := []string{}
+	for key, value := range desired {
+		keys = append(keys, key)
+		if !reflect.DeepEqual(secret.Data[key], value) {
+			secret.Data[key] = value
+			changed = true
+		}
+	}
+	sort.Strings(keys)
+
+	managedKeys := strings.Join(keys, ",")
+	if secret.Annotations[common.AnnotationNotificationsSecretKeys] != managedKeys {
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		if managedKeys == "" {
+			delete(secret.Annotations, common.AnnotationNotificationsSecretKeys)
+		} else {
+			secret.Annotations[common.AnnotationNotificationsSecretKeys] = managedKeys
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return r.Client.Update(context.TODO(), secret)
+}
+
+// splitManagedKeys returns the keys listed in the given comma separated value.
+func splitManagedKeys(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
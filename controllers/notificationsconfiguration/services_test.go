@@ -0,0 +1,118 @@
+package notificationsconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func secretKeySelector(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+}
+
+func TestGetNotificationsServicesConfiguration(t *testing.T) {
+	password := secretKeySelector("smtp", "password")
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token"), Username: "argocd"}},
+			{Name: "gmail", Email: &v1alpha1.EmailNotificationsService{Host: "smtp.gmail.com", Port: 465, From: "argocd@example.com", Password: &password}},
+			{Name: "github", Webhook: &v1alpha1.WebhookNotificationsService{
+				URL: "https://api.github.com",
+				Headers: []v1alpha1.WebhookNotificationsHeader{
+					{Name: "Authorization", ValueFrom: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "github"}, Key: "token"}},
+					{Name: "Accept", Value: "application/json"},
+				},
+			}},
+			{Teams: &v1alpha1.TeamsNotificationsService{RecipientURLs: map[string]corev1.SecretKeySelector{"ops": secretKeySelector("teams", "ops")}}},
+			{PagerDuty: &v1alpha1.PagerDutyNotificationsService{ServiceKeys: map[string]corev1.SecretKeySelector{"payments": secretKeySelector("pagerduty", "payments")}}},
+		}
+	})
+
+	configuration, err := getNotificationsServicesConfiguration(a)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"service.slack":          "token: $slack-token\nusername: argocd\n",
+		"service.email.gmail":    "from: argocd@example.com\nhost: smtp.gmail.com\npassword: $email-gmail-password\nport: 465\n",
+		"service.webhook.github": "headers:\n- name: Authorization\n  value: $webhook-github-header-authorization\n- name: Accept\n  value: application/json\nurl: https://api.github.com\n",
+		"service.teams":          "recipientUrls:\n  ops: $teams-recipient-ops\n",
+		"service.pagerdutyv2":    "serviceKeys:\n  payments: $pagerdutyv2-payments\n",
+	}, configuration)
+}
+
+func TestReconcileNotifications_SyncSecret(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+		}
+	})
+	slackSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: a.Namespace},
+		Data:       map[string][]byte{"token": []byte("xoxb-1")},
+	}
+	notificationsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDNotificationsSecret, Namespace: a.Namespace},
+		Data:       map[string][]byte{"manual": []byte("kept")},
+	}
+
+	resObjs := []client.Object{a, slackSecret, notificationsSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsSecret(a, nil))
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Name: ArgoCDNotificationsSecret, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, "xoxb-1", string(secret.Data["slack-token"]))
+	assert.Equal(t, "kept", string(secret.Data["manual"]))
+	assert.Equal(t, "slack-token", secret.Annotations[common.AnnotationNotificationsSecretKeys])
+
+	// a rotated token is synced again
+	slackSecret.Data["token"] = []byte("xoxb-2")
+	assert.NoError(t, r.Client.Update(context.TODO(), slackSecret))
+	assert.NoError(t, r.reconcileNotificationsSecret(a, nil))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.Equal(t, "xoxb-2", string(secret.Data["slack-token"]))
+
+	// keys of removed services are removed, others are left untouched
+	a.Spec.ServiceDefinitions = nil
+	assert.NoError(t, r.reconcileNotificationsSecret(a, nil))
+	assert.NoError(t, r.Client.Get(context.TODO(), key, secret))
+	assert.NotContains(t, secret.Data, "slack-token")
+	assert.Equal(t, "kept", string(secret.Data["manual"]))
+	assert.NotContains(t, secret.Annotations, common.AnnotationNotificationsSecretKeys)
+}
+
+func TestReconcileNotifications_SyncSecretMissingReference(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.ServiceDefinitions = []v1alpha1.NotificationsServiceSpec{
+			{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+		}
+	})
+	notificationsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDNotificationsSecret, Namespace: a.Namespace},
+	}
+
+	resObjs := []client.Object{a, notificationsSecret}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.Error(t, r.reconcileNotificationsSecret(a, nil))
+	assert.Len(t, r.secretMapper(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: a.Namespace}}), 1)
+	assert.Empty(t, r.secretMapper(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: a.Namespace}}))
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationsconfiguration
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/file"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// notificationsTriggerCondition is a condition of a notifications trigger.
+type notificationsTriggerCondition struct {
+	When string   `json:"when"`
+	Send []string `json:"send"`
+}
+
+// validateNotificationsConfiguration returns an error describing every invalid trigger, template and service of the
+// given NotificationsConfiguration.
+func validateNotificationsConfiguration(cr *v1alpha1.NotificationsConfiguration) error {
+	problems := []string{}
+	for _, name := range sortedKeys(cr.Spec.Triggers) {
+		if err := validateNotificationsTrigger(cr.Spec.Triggers[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	for _, name := range sortedKeys(cr.Spec.Templates) {
+		if err := validateNotificationsTemplate(name, cr.Spec.Templates[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	problems = append(problems, validateNotificationsServices(cr)...)
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateNotificationsTrigger returns an error if the given trigger isn't a list of conditions with valid when
+// expressions, each sending at least one template.
+func validateNotificationsTrigger(trigger string) error {
+	conditions := []notificationsTriggerCondition{}
+	if err := yaml.Unmarshal([]byte(trigger), &conditions); err != nil {
+		return fmt.Errorf("invalid trigger: %w", err)
+	}
+	if len(conditions) == 0 {
+		return errors.New("trigger has no condition")
+	}
+	for i, condition := range conditions {
+		if strings.TrimSpace(condition.When) == "" {
+			return fmt.Errorf("condition %d has no when expression", i)
+		}
+		if err := validateNotificationsExpression(condition.When); err != nil {
+			return fmt.Errorf("condition %d has an invalid when expression: %w", i, err)
+		}
+		if len(condition.Send) == 0 {
+			return fmt.Errorf("condition %d sends no template", i)
+		}
+	}
+	return nil
+}
+
+// validateNotificationsExpression compiles the given trigger expression with the expression language of the
+// notifications controller. Variables and functions aren't checked, as they are provided by the controller.
+func validateNotificationsExpression(expression string) error {
+	_, err := expr.Compile(expression)
+	var exprErr *file.Error
+	if errors.As(err, &exprErr) {
+		// Report the position of the error rather than the multi-line snippet of the expression.
+		return fmt.Errorf("%s (%d:%d)", exprErr.Message, exprErr.Line, exprErr.Column+1)
+	}
+	return err
+}
+
+// validateNotificationsTemplate returns an error if the given template isn't a YAML document whose string values are
+// valid Go templates. Functions aren't checked, as they are provided by the notifications controller.
+func validateNotificationsTemplate(name string, template string) error {
+	var document interface{}
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return walkNotificationsTemplate(document, func(text string) error {
+		tree := parse.New(name)
+		tree.Mode = parse.SkipFuncCheck
+		_, err := tree.Parse(text, "", "", map[string]*parse.Tree{})
+		return err
+	})
+}
+
+// walkNotificationsTemplate calls fn for every string value of the given YAML document.
+func walkNotificationsTemplate(document interface{}, fn func(string) error) error {
+	switch value := document.(type) {
+	case string:
+		return fn(value)
+	case []interface{}:
+		for _, v := range value {
+			if err := walkNotificationsTemplate(v, fn); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := []string{}
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := walkNotificationsTemplate(value[k], fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateNotificationsServices returns the problems found in the typed services of the given
+// NotificationsConfiguration.
+func validateNotificationsServices(cr *v1alpha1.NotificationsConfiguration) []string {
+	problems := []string{}
+	keys := map[string]bool{}
+	for i, svc := range cr.Spec.ServiceDefinitions {
+		if getNotificationsServiceType(svc) == "" {
+			problems = append(problems, fmt.Sprintf("serviceDefinitions[%d]: exactly one service type must be set", i))
+			continue
+		}
+
+		key := getNotificationsServiceKey(svc)
+		if keys[key] {
+			problems = append(problems, fmt.Sprintf("%s: duplicate service", key))
+		}
+		keys[key] = true
+		if _, ok := cr.Spec.Services[key]; ok {
+			problems = append(problems, fmt.Sprintf("%s: service also defined in services", key))
+		}
+
+		switch {
+		case svc.Email != nil:
+			if svc.Email.Host == "" || svc.Email.Port == 0 || svc.Email.From == "" {
+				problems = append(problems, fmt.Sprintf("%s: host, port and from are required", key))
+			}
+		case svc.Webhook != nil:
+			if svc.Webhook.URL == "" {
+				problems = append(problems, fmt.Sprintf("%s: url is required", key))
+			}
+			for _, header := range svc.Webhook.Headers {
+				if (header.Value == "") == (header.ValueFrom == nil) {
+					problems = append(problems, fmt.Sprintf("%s: exactly one of value and valueFrom must be set for header %s", key, header.Name))
+				}
+			}
+		case svc.Teams != nil:
+			if len(svc.Teams.RecipientURLs) == 0 {
+				problems = append(problems, fmt.Sprintf("%s: at least one recipient URL is required", key))
+			}
+		case svc.PagerDuty != nil:
+			if len(svc.PagerDuty.ServiceKeys) == 0 {
+				problems = append(problems, fmt.Sprintf("%s: at least one service key is required", key))
+			}
+		}
+	}
+	return problems
+}
+
+// sortedKeys returns the keys of the given map in alphabetical order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package notificationsconfiguration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func TestValidateNotificationsConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.NotificationsConfigurationSpec
+		wantErr string
+	}{
+		{
+			name: "valid configuration",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Triggers: map[string]string{
+					"trigger.on-sync-failed": "- when: app.status.operationState.phase in ['Error', 'Failed']\n  send: [app-sync-failed]\n",
+				},
+				Templates: map[string]string{
+					"template.app-sync-failed": "message: |\n  {{if eq .serviceType \"slack\"}}:exclamation:{{end}} {{.app.metadata.name}} failed at {{.app.status.operationState.finishedAt}}\n",
+				},
+			},
+		},
+		{
+			name: "trigger without when expression",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Triggers: map[string]string{"trigger.on-created": "- send: [app-created]\n"},
+			},
+			wantErr: "trigger.on-created: condition 0 has no when expression",
+		},
+		{
+			name: "trigger with unclosed bracket",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Triggers: map[string]string{"trigger.on-failed": "- when: app.status.phase in ['Error', 'Failed'\n  send: [app-failed]\n"},
+			},
+			wantErr: "trigger.on-failed: condition 0 has an invalid when expression: unexpected token EOF (1:38)",
+		},
+		{
+			name: "trigger with invalid expression",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Triggers: map[string]string{"trigger.on-failed": "- when: app.status.phase == == 'Error'\n  send: [app-failed]\n"},
+			},
+			wantErr: "trigger.on-failed: condition 0 has an invalid when expression: unexpected token Operator(\"==\") (1:21)",
+		},
+		{
+			name: "trigger with unterminated string",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Triggers: map[string]string{"trigger.on-failed": "- when: app.status.phase == 'Error\n  send: [app-failed]\n"},
+			},
+			wantErr: "trigger.on-failed: condition 0 has an invalid when expression: literal not terminated (1:27)",
+		},
+		{
+			name: "template with invalid syntax",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Templates: map[string]string{"template.app-created": "message: Application {{.app.metadata.name} has been created.\n"},
+			},
+			wantErr: "template.app-created: template: template.app-created:1: bad character U+007D '}'",
+		},
+		{
+			name: "service without type",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				ServiceDefinitions: []v1alpha1.NotificationsServiceSpec{{Name: "empty"}},
+			},
+			wantErr: "serviceDefinitions[0]: exactly one service type must be set",
+		},
+		{
+			name: "service conflicting with an untyped service",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				Services: map[string]string{"service.slack": "token: $slack-token"},
+				ServiceDefinitions: []v1alpha1.NotificationsServiceSpec{
+					{Slack: &v1alpha1.SlackNotificationsService{Token: secretKeySelector("slack", "token")}},
+				},
+			},
+			wantErr: "service.slack: service also defined in services",
+		},
+		{
+			name: "webhook header without value",
+			spec: v1alpha1.NotificationsConfigurationSpec{
+				ServiceDefinitions: []v1alpha1.NotificationsServiceSpec{
+					{Webhook: &v1alpha1.WebhookNotificationsService{URL: "https://example.com", Headers: []v1alpha1.WebhookNotificationsHeader{{Name: "X-Token"}}}},
+				},
+			},
+			wantErr: "service.webhook: exactly one of value and valueFrom must be set for header X-Token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+				a.Spec = test.spec
+			})
+			err := validateNotificationsConfiguration(a)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestReconcileNotifications_InvalidCondition(t *testing.T) {
+	a := makeTestNotificationsConfiguration(func(a *v1alpha1.NotificationsConfiguration) {
+		a.Spec.Triggers = map[string]string{"trigger.on-created": "- when: \"true\"\n  send: [app-created]\n"}
+	})
+
+	resObjs := []client.Object{a}
+	subresObjs := []client.Object{a}
+	runtimeObjs := []runtime.Object{}
+	sch := makeTestReconcilerScheme(v1alpha1.AddToScheme, argoproj.AddToScheme)
+	cl := makeTestReconcilerClient(sch, resObjs, subresObjs, runtimeObjs)
+	r := makeTestReconciler(cl, sch)
+
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+	assert.True(t, meta.IsStatusConditionFalse(a.Status.Conditions, v1alpha1.NotificationsConfigurationConditionInvalid))
+
+	cm := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: ArgoCDNotificationsConfigMap, Namespace: a.Namespace}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+
+	// an invalid trigger is reported, and the last valid configuration is kept
+	a.Spec.Triggers["trigger.on-created"] = "- send: [app-created]\n"
+	assert.NoError(t, r.reconcileNotificationsConfigurationResources(a))
+
+	stored := &v1alpha1.NotificationsConfiguration{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: a.Name, Namespace: a.Namespace}, stored))
+	condition := meta.FindStatusCondition(stored.Status.Conditions, v1alpha1.NotificationsConfigurationConditionInvalid)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "trigger.on-created: condition 0 has no when expression", condition.Message)
+
+	assert.NoError(t, r.Client.Get(context.TODO(), key, cm))
+	assert.Equal(t, "- when: \"true\"\n  send: [app-created]\n", cm.Data["trigger.on-created"])
+}
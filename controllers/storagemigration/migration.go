@@ -0,0 +1,213 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storagemigration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,resourceNames=argocds.argoproj.io,verbs=get
+//+kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,resourceNames=argocds.argoproj.io,verbs=get;update
+
+const (
+	// ArgoCDCRDName is the name of the ArgoCD CustomResourceDefinition.
+	ArgoCDCRDName = "argocds.argoproj.io"
+
+	// ProgressConfigMapName is the name of the ConfigMap recording the progress of the migration.
+	ProgressConfigMapName = "argocd-operator-storage-migration"
+
+	// PhaseRunning is the phase of a migration in progress.
+	PhaseRunning = "Running"
+	// PhaseCompleted is the phase of a completed migration.
+	PhaseCompleted = "Completed"
+
+	// DefaultPageSize is the default number of ArgoCD instances migrated between two progress records.
+	DefaultPageSize = 50
+
+	progressKeyPhase          = "phase"
+	progressKeyStorageVersion = "storageVersion"
+	progressKeyContinue       = "continue"
+	progressKeyMigrated       = "migrated"
+)
+
+var log = logf.Log.WithName("storage-migration")
+
+// Migrator rewrites the stored ArgoCD instances in the storage version of the ArgoCD CRD, so that the versions that
+// are no longer stored can eventually be removed from the CRD.
+type Migrator struct {
+	Client client.Client
+	// Namespace is the namespace of the ConfigMap recording the progress of the migration.
+	Namespace string
+	// PageSize is the number of ArgoCD instances migrated between two progress records.
+	PageSize int64
+}
+
+// Run migrates every ArgoCD instance to the storage version, reading it through the conversion path and writing it
+// back unchanged. The progress is recorded in a ConfigMap after each page of instances, so that an interrupted
+// migration resumes where it stopped. Once every instance is migrated, the stored versions of the CRD are reduced to
+// the storage version.
+func (m *Migrator) Run(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: ArgoCDCRDName}, crd); err != nil {
+		return fmt.Errorf("failed to get the CRD %s: %w", ArgoCDCRDName, err)
+	}
+	storageVersion := getStorageVersion(crd)
+	if storageVersion == "" {
+		return fmt.Errorf("no storage version found in the CRD %s", ArgoCDCRDName)
+	}
+	if reflect.DeepEqual(crd.Status.StoredVersions, []string{storageVersion}) {
+		log.Info("ArgoCD instances are already stored in the storage version", "version", storageVersion)
+		return nil
+	}
+
+	progress, err := m.getProgress(ctx)
+	if err != nil {
+		return err
+	}
+	// A migration recorded for another storage version starts over.
+	if progress.Data[progressKeyStorageVersion] != storageVersion || progress.Data[progressKeyPhase] == PhaseCompleted {
+		progress.Data = map[string]string{
+			progressKeyPhase:          PhaseRunning,
+			progressKeyStorageVersion: storageVersion,
+			progressKeyMigrated:       "0",
+		}
+	}
+	migrated, _ := strconv.Atoi(progress.Data[progressKeyMigrated])
+	log.Info("migrating ArgoCD instances to the storage version", "version", storageVersion, "migrated", migrated)
+
+	pageSize := m.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	for {
+		list := &argoproj.ArgoCDList{}
+		err := m.Client.List(ctx, list, client.Limit(pageSize), client.Continue(progress.Data[progressKeyContinue]))
+		if errors.IsResourceExpired(err) {
+			// The continue token expired, the migration restarts from the first instance: migrating an instance
+			// twice is harmless.
+			log.Info("the recorded migration progress expired, restarting from the first instance")
+			progress.Data[progressKeyContinue] = ""
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list the ArgoCD instances: %w", err)
+		}
+
+		for i := range list.Items {
+			if err := m.migrate(ctx, &list.Items[i]); err != nil {
+				return err
+			}
+			migrated++
+		}
+
+		progress.Data[progressKeyMigrated] = strconv.Itoa(migrated)
+		progress.Data[progressKeyContinue] = list.Continue
+		if err := m.Client.Update(ctx, progress); err != nil {
+			return fmt.Errorf("failed to record the migration progress: %w", err)
+		}
+		if list.Continue == "" {
+			break
+		}
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.Client.Get(ctx, client.ObjectKey{Name: ArgoCDCRDName}, crd); err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		return m.Client.Status().Update(ctx, crd)
+	}); err != nil {
+		return fmt.Errorf("failed to update the stored versions of the CRD %s: %w", ArgoCDCRDName, err)
+	}
+
+	delete(progress.Data, progressKeyContinue)
+	progress.Data[progressKeyPhase] = PhaseCompleted
+	if err := m.Client.Update(ctx, progress); err != nil {
+		return fmt.Errorf("failed to record the migration progress: %w", err)
+	}
+	log.Info("migrated ArgoCD instances to the storage version", "version", storageVersion, "migrated", migrated)
+	return nil
+}
+
+// migrate writes the given ArgoCD instance back unchanged, which stores it in the storage version.
+func (m *Migrator) migrate(ctx context.Context, cr *argoproj.ArgoCD) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := m.Client.Update(ctx, cr)
+		if errors.IsConflict(err) {
+			if getErr := m.Client.Get(ctx, client.ObjectKeyFromObject(cr), cr); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+	if errors.IsNotFound(err) {
+		// Deleted since it was listed, nothing to migrate.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate the ArgoCD %s/%s: %w", cr.Namespace, cr.Name, err)
+	}
+	return nil
+}
+
+// getProgress returns the ConfigMap recording the progress of the migration, creating it if needed.
+func (m *Migrator) getProgress(ctx context.Context) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := m.Client.Get(ctx, client.ObjectKey{Name: ProgressConfigMapName, Namespace: m.Namespace}, cm)
+	if err == nil {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		return cm, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get the migration progress: %w", err)
+	}
+
+	cm = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProgressConfigMapName,
+			Namespace: m.Namespace,
+		},
+		Data: map[string]string{},
+	}
+	if err := m.Client.Create(ctx, cm); err != nil {
+		return nil, fmt.Errorf("failed to create the migration progress: %w", err)
+	}
+	return cm, nil
+}
+
+// getStorageVersion returns the storage version of the given CRD.
+func getStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
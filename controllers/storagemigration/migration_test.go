@@ -0,0 +1,102 @@
+package storagemigration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	argoproj "github.com/argoproj-labs/argocd-operator/api/v1beta1"
+)
+
+func makeTestCRD(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: ArgoCDCRDName},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true},
+				{Name: "v1beta1", Served: true, Storage: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+func makeTestMigrator(objs ...client.Object) *Migrator {
+	s := runtime.NewScheme()
+	_ = corev1.AddToScheme(s)
+	_ = apiextensionsv1.AddToScheme(s)
+	_ = argoproj.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).Build()
+	return &Migrator{Client: cl, Namespace: "argocd-operator-system"}
+}
+
+func TestMigrator_Run(t *testing.T) {
+	crd := makeTestCRD("v1alpha1", "v1beta1")
+	a := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"}}
+	b := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "team-a"}}
+	m := makeTestMigrator(crd, a, b)
+	for _, cr := range []*argoproj.ArgoCD{a, b} {
+		assert.NoError(t, m.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), cr))
+	}
+
+	assert.NoError(t, m.Run(context.TODO()))
+
+	assert.NoError(t, m.Client.Get(context.TODO(), client.ObjectKeyFromObject(crd), crd))
+	assert.Equal(t, []string{"v1beta1"}, crd.Status.StoredVersions)
+
+	// every instance was written back
+	migrated := &argoproj.ArgoCD{}
+	for _, cr := range []*argoproj.ArgoCD{a, b} {
+		assert.NoError(t, m.Client.Get(context.TODO(), client.ObjectKeyFromObject(cr), migrated))
+		assert.NotEqual(t, cr.ResourceVersion, migrated.ResourceVersion)
+	}
+
+	progress := &corev1.ConfigMap{}
+	assert.NoError(t, m.Client.Get(context.TODO(), client.ObjectKey{Name: ProgressConfigMapName, Namespace: m.Namespace}, progress))
+	assert.Equal(t, map[string]string{
+		"phase":          PhaseCompleted,
+		"storageVersion": "v1beta1",
+		"migrated":       "2",
+	}, progress.Data)
+}
+
+func TestMigrator_RunResume(t *testing.T) {
+	crd := makeTestCRD("v1alpha1", "v1beta1")
+	a := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"}}
+	progress := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ProgressConfigMapName, Namespace: "argocd-operator-system"},
+		Data: map[string]string{
+			"phase":          PhaseRunning,
+			"storageVersion": "v1beta1",
+			"migrated":       "3",
+		},
+	}
+	m := makeTestMigrator(crd, a, progress)
+
+	assert.NoError(t, m.Run(context.TODO()))
+
+	// the instances migrated before the interruption are accounted for
+	assert.NoError(t, m.Client.Get(context.TODO(), client.ObjectKeyFromObject(progress), progress))
+	assert.Equal(t, "4", progress.Data["migrated"])
+	assert.Equal(t, PhaseCompleted, progress.Data["phase"])
+}
+
+func TestMigrator_RunAlreadyMigrated(t *testing.T) {
+	crd := makeTestCRD("v1beta1")
+	a := &argoproj.ArgoCD{ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"}}
+	m := makeTestMigrator(crd, a)
+
+	assert.NoError(t, m.Run(context.TODO()))
+
+	err := m.Client.Get(context.TODO(), client.ObjectKey{Name: ProgressConfigMapName, Namespace: m.Namespace}, &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+}
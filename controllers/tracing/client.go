@@ -0,0 +1,234 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// AttributeNamespace is the attribute holding the namespace of the object of a Kubernetes API call.
+	AttributeNamespace = attribute.Key("k8s.namespace.name")
+	// AttributeName is the attribute holding the name of the object of a Kubernetes API call.
+	AttributeName = attribute.Key("k8s.object.name")
+	// AttributeSubResource is the attribute holding the subresource of a Kubernetes API call.
+	AttributeSubResource = attribute.Key("k8s.subresource")
+)
+
+// tracingClient is a client.Client starting a span for each Kubernetes API call.
+type tracingClient struct {
+	client.Client
+}
+
+// NewClient returns a client starting a span for each Kubernetes API call made through the given client.
+func NewClient(c client.Client) client.Client {
+	return &tracingClient{Client: c}
+}
+
+// start starts the span of the given Kubernetes API call on the given object.
+func (c *tracingClient) start(ctx context.Context, verb string, obj client.Object, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, AttributeNamespace.String(obj.GetNamespace()), AttributeName.String(obj.GetName()))
+	return Start(ctx, verb+" "+c.kind(obj), attrs...)
+}
+
+// kind returns the kind of the given object, or its type name if it isn't registered in the scheme of the client.
+func (c *tracingClient) kind(obj runtime.Object) string {
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		return gvk.Kind
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
+
+func (c *tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) (err error) {
+	ctx, span := Start(ctx, "Get "+c.kind(obj), AttributeNamespace.String(key.Namespace), AttributeName.String(key.Name))
+	defer func() { End(span, err) }()
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	ctx, span := Start(ctx, "List "+c.kind(list), AttributeNamespace.String(listOpts.Namespace))
+	defer func() { End(span, err) }()
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) (err error) {
+	ctx, span := c.start(ctx, "Create", obj)
+	defer func() { End(span, err) }()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, span := c.start(ctx, "Delete", obj)
+	defer func() { End(span, err) }()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := c.start(ctx, "Update", obj)
+	defer func() { End(span, err) }()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := c.start(ctx, "Patch", obj)
+	defer func() { End(span, err) }()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) (err error) {
+	deleteOpts := &client.DeleteAllOfOptions{}
+	deleteOpts.ApplyOptions(opts)
+	ctx, span := Start(ctx, "DeleteAllOf "+c.kind(obj), AttributeNamespace.String(deleteOpts.Namespace))
+	defer func() { End(span, err) }()
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *tracingClient) Status() client.SubResourceWriter {
+	return &tracingSubResourceClient{client: c, subResource: "status", writer: c.Client.Status()}
+}
+
+func (c *tracingClient) SubResource(subResource string) client.SubResourceClient {
+	sub := c.Client.SubResource(subResource)
+	return &tracingSubResourceClient{client: c, subResource: subResource, reader: sub, writer: sub}
+}
+
+// tracingSubResourceClient is a client.SubResourceClient starting a span for each Kubernetes API call.
+type tracingSubResourceClient struct {
+	client      *tracingClient
+	subResource string
+	reader      client.SubResourceReader
+	writer      client.SubResourceWriter
+}
+
+func (c *tracingSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) (err error) {
+	ctx, span := c.client.start(ctx, "Get", obj, AttributeSubResource.String(c.subResource))
+	defer func() { End(span, err) }()
+	return c.reader.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *tracingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) (err error) {
+	ctx, span := c.client.start(ctx, "Create", obj, AttributeSubResource.String(c.subResource))
+	defer func() { End(span, err) }()
+	return c.writer.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *tracingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) (err error) {
+	ctx, span := c.client.start(ctx, "Update", obj, AttributeSubResource.String(c.subResource))
+	defer func() { End(span, err) }()
+	return c.writer.Update(ctx, obj, opts...)
+}
+
+func (c *tracingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) (err error) {
+	ctx, span := c.client.start(ctx, "Patch", obj, AttributeSubResource.String(c.subResource))
+	defer func() { End(span, err) }()
+	return c.writer.Patch(ctx, obj, patch, opts...)
+}
+
+// contextClient is a client.Client tracing the Kubernetes API calls made with a context carrying no span, such as
+// context.TODO(), as children of the span of its context.
+type contextClient struct {
+	client.Client
+	ctx context.Context
+}
+
+// WithContext returns a client tracing the Kubernetes API calls made through the given client with a context
+// carrying no span as children of the span of ctx, annotated with the ArgoCD instance of ctx. It lets the
+// reconcilers, which make their calls with context.TODO(), trace them under the span of the reconciliation.
+func WithContext(ctx context.Context, c client.Client) client.Client {
+	return &contextClient{Client: c, ctx: ctx}
+}
+
+// withParent returns the given context of a Kubernetes API call, carrying the span and ArgoCD instance of the context
+// of the client if it carries no span.
+func (c *contextClient) withParent(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	ctx = trace.ContextWithSpan(ctx, trace.SpanFromContext(c.ctx))
+	if instance := c.ctx.Value(instanceKey{}); instance != nil {
+		ctx = context.WithValue(ctx, instanceKey{}, instance)
+	}
+	return ctx
+}
+
+func (c *contextClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.Client.Get(c.withParent(ctx), key, obj, opts...)
+}
+
+func (c *contextClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.Client.List(c.withParent(ctx), list, opts...)
+}
+
+func (c *contextClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.Client.Create(c.withParent(ctx), obj, opts...)
+}
+
+func (c *contextClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.Client.Delete(c.withParent(ctx), obj, opts...)
+}
+
+func (c *contextClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.Client.Update(c.withParent(ctx), obj, opts...)
+}
+
+func (c *contextClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return c.Client.Patch(c.withParent(ctx), obj, patch, opts...)
+}
+
+func (c *contextClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return c.Client.DeleteAllOf(c.withParent(ctx), obj, opts...)
+}
+
+func (c *contextClient) Status() client.SubResourceWriter {
+	return &contextSubResourceClient{client: c, writer: c.Client.Status()}
+}
+
+func (c *contextClient) SubResource(subResource string) client.SubResourceClient {
+	sub := c.Client.SubResource(subResource)
+	return &contextSubResourceClient{client: c, reader: sub, writer: sub}
+}
+
+// contextSubResourceClient is a client.SubResourceClient tracing the Kubernetes API calls made with a context
+// carrying no span as children of the span of the context of its client.
+type contextSubResourceClient struct {
+	client *contextClient
+	reader client.SubResourceReader
+	writer client.SubResourceWriter
+}
+
+func (c *contextSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	return c.reader.Get(c.client.withParent(ctx), obj, subResource, opts...)
+}
+
+func (c *contextSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	return c.writer.Create(c.client.withParent(ctx), obj, subResource, opts...)
+}
+
+func (c *contextSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	return c.writer.Update(c.client.withParent(ctx), obj, opts...)
+}
+
+func (c *contextSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	return c.writer.Patch(c.client.withParent(ctx), obj, patch, opts...)
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClient(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm", Namespace: "argocd"}}
+	tc := NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build())
+
+	ctx, parent := Start(WithInstance(context.Background(), "argocd", "example"), "Reconcile")
+	c := WithContext(ctx, tc)
+	assert.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(cm), cm))
+	assert.NoError(t, c.List(context.TODO(), &corev1.SecretList{}, client.InNamespace("argocd")))
+	assert.NoError(t, c.Update(context.TODO(), cm))
+	assert.Error(t, c.Delete(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "argocd"}}))
+	parent.End()
+
+	spans := recorder.Ended()
+	names := []string{}
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	assert.Equal(t, []string{"Get ConfigMap", "List SecretList", "Update ConfigMap", "Delete Secret", "Reconcile"}, names)
+	for _, span := range spans[:4] {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.String("argocd.instance.name", "example"))
+	}
+	assert.Contains(t, spans[0].Attributes(), attribute.String("k8s.object.name", "argocd-cm"))
+	assert.Contains(t, spans[1].Attributes(), attribute.String("k8s.namespace.name", "argocd"))
+	assert.Equal(t, codes.Error, spans[3].Status().Code)
+}
+
+func TestWithContext_SpanOfCall(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "argocd-cm", Namespace: "argocd"}}
+	tc := NewClient(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build())
+
+	ctx, parent := Start(WithInstance(context.Background(), "argocd", "example"), "Reconcile")
+	c := WithContext(ctx, tc)
+	// the span of the context of a call takes precedence over the one of the client
+	stepCtx, step := Start(ctx, "reconcile configmaps")
+	assert.NoError(t, c.Update(stepCtx, cm))
+	step.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "Update ConfigMap", spans[0].Name())
+	assert.Equal(t, step.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("argocd.instance.name", "example"))
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer of the operator.
+	TracerName = "github.com/argoproj-labs/argocd-operator"
+
+	// ServiceName is the name of the operator in the exported traces.
+	ServiceName = "argocd-operator"
+
+	// AttributeInstanceNamespace is the attribute holding the namespace of the ArgoCD instance of a span.
+	AttributeInstanceNamespace = attribute.Key("argocd.instance.namespace")
+	// AttributeInstanceName is the attribute holding the name of the ArgoCD instance of a span.
+	AttributeInstanceName = attribute.Key("argocd.instance.name")
+)
+
+type instanceKey struct{}
+
+// Setup exports the spans of the operator to the OTLP collector listening on the given HTTP endpoint, such as
+// http://otel-collector:4318. It returns a function flushing the pending spans and stopping the export.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// WithInstance returns a copy of ctx whose spans are annotated with the given ArgoCD instance.
+func WithInstance(ctx context.Context, namespace, name string) context.Context {
+	return context.WithValue(ctx, instanceKey{}, []attribute.KeyValue{
+		AttributeInstanceNamespace.String(namespace),
+		AttributeInstanceName.String(name),
+	})
+}
+
+// Start starts a span with the given name and attributes, child of the span of ctx and annotated with its ArgoCD
+// instance.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if instance, ok := ctx.Value(instanceKey{}).([]attribute.KeyValue); ok {
+		attrs = append(attrs, instance...)
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the given error on the given span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}